@@ -2,17 +2,44 @@ package client
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
+// SanitizeAddress converts a cosigner p2p address, either a tcp URL such as tcp://signer-1:2222 or a bare
+// host and port, to the host:port address dialed by gRPC and raft. IPv6 hosts must be enclosed in brackets,
+// e.g. tcp://[::1]:2222.
 func SanitizeAddress(address string) (string, error) {
-	u, err := url.Parse(address)
+	hostPort := address
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return "", fmt.Errorf("error parsing URL: %w", err)
+		}
+		if u.Scheme != "tcp" {
+			return "", fmt.Errorf("address %s must use the tcp scheme", address)
+		}
+		if u.Path != "" && u.Path != "/" {
+			return "", fmt.Errorf("address %s must not have a path", address)
+		}
+		hostPort = u.Host
+	}
+
+	host, port, err := net.SplitHostPort(hostPort)
 	if err != nil {
-		return "", fmt.Errorf("error parsing URL: %w", err)
+		return "", fmt.Errorf("address %s must be a host and port, e.g. tcp://signer-1:2222 or tcp://[::1]:2222: %w",
+			address, err)
+	}
+	if host == "" {
+		return "", fmt.Errorf("address %s is missing a host", address)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", fmt.Errorf("address %s has an invalid port %q", address, port)
 	}
 
-	return u.Host, nil
+	return net.JoinHostPort(host, port), nil
 }
 
 func MultiAddress(addresses []string) (string, error) {
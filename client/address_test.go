@@ -53,3 +53,42 @@ func TestLeaderElectionMultiAddressIPv6(t *testing.T) {
 
 	require.Equal(t, expected, multiAddress)
 }
+
+func TestSanitizeAddress(t *testing.T) {
+	for _, tc := range []struct {
+		address string
+		want    string
+	}{
+		{"tcp://10.0.0.1:2222", "10.0.0.1:2222"},
+		{"tcp://signer-1.example.com:2222", "signer-1.example.com:2222"},
+		{"tcp://[::1]:2222", "[::1]:2222"},
+		{"tcp://[2001:db8::1234:5678]:2222/", "[2001:db8::1234:5678]:2222"},
+		{"10.0.0.1:2222", "10.0.0.1:2222"},
+		{"localhost:2222", "localhost:2222"},
+		{"[::1]:2222", "[::1]:2222"},
+	} {
+		got, err := client.SanitizeAddress(tc.address)
+		require.NoError(t, err, tc.address)
+		require.Equal(t, tc.want, got, tc.address)
+	}
+
+	for _, tc := range []struct {
+		address string
+		err     string
+	}{
+		{"tcp://10.0.0.1", "must be a host and port"},
+		{"tcp://signer-1", "must be a host and port"},
+		{"tcp://::1:2222", "must be a host and port"},
+		{"::1", "must be a host and port"},
+		{"tcp://:2222", "is missing a host"},
+		{":2222", "is missing a host"},
+		{"tcp://signer-1:port", "invalid port"},
+		{"tcp://signer-1:70000", "invalid port"},
+		{"http://signer-1:2222", "must use the tcp scheme"},
+		{"unix:///run/signer.sock", "must use the tcp scheme"},
+		{"tcp://signer-1:2222/raft", "must not have a path"},
+	} {
+		_, err := client.SanitizeAddress(tc.address)
+		require.ErrorContains(t, err, tc.err, tc.address)
+	}
+}
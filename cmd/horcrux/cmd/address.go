@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/cometbft/cometbft/crypto"
@@ -13,6 +14,8 @@ import (
 	"github.com/strangelove-ventures/horcrux/signer"
 )
 
+const flagBech32Prefix = "bech32-prefix"
+
 type AddressCmdOutput struct {
 	HexAddress        string
 	PubKey            string
@@ -24,73 +27,21 @@ func addressCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "address chain-id [bech32]",
 		Short:        "Get public key hex address and valcons address",
-		Example:      `horcrux cosigner address cosmos`,
+		Example:      `horcrux address cosmoshub-4 cosmos`,
 		SilenceUsage: true,
 		Args:         cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-
-			var pubKey crypto.PubKey
-
-			chainID := args[0]
-
-			switch config.Config.SignMode {
-			case signer.SignModeThreshold:
-				err := config.Config.ValidateThresholdModeConfig()
-				if err != nil {
-					return err
-				}
-
-				keyFile, err := config.KeyFileExistsCosigner(chainID)
-				if err != nil {
-					return err
-				}
-
-				key, err := signer.LoadCosignerEd25519Key(keyFile)
-				if err != nil {
-					return fmt.Errorf("error reading cosigner key: %w, check that key is present for chain ID: %s", err, chainID)
-				}
-
-				pubKey = key.PubKey
-			case signer.SignModeSingle:
-				err := config.Config.ValidateSingleSignerConfig()
-				if err != nil {
-					return err
-				}
-				keyFile, err := config.KeyFileExistsSingleSigner(chainID)
-				if err != nil {
-					return fmt.Errorf("error reading priv-validator key: %w, check that key is present for chain ID: %s", err, chainID)
-				}
-
-				filePV := cometprivval.LoadFilePVEmptyState(keyFile, "")
-				pubKey = filePV.Key.PubKey
-			default:
-				panic(fmt.Errorf("unexpected sign mode: %s", config.Config.SignMode))
+			var bech32Prefix string
+			if len(args) == 2 {
+				bech32Prefix = args[1]
 			}
 
-			pubKeyAddress := pubKey.Address()
-
-			pubKeyJSON, err := signer.PubKey("", pubKey)
+			output, err := validatorAddress(args[0], bech32Prefix)
 			if err != nil {
 				return err
 			}
 
-			output := AddressCmdOutput{
-				HexAddress: strings.ToUpper(hex.EncodeToString(pubKeyAddress)),
-				PubKey:     pubKeyJSON,
-			}
-
-			if len(args) == 2 {
-				bech32ValConsAddress, err := bech32.ConvertAndEncode(args[1]+"valcons", pubKeyAddress)
-				if err != nil {
-					return err
-				}
-				output.ValConsAddress = bech32ValConsAddress
-				pubKeyBech32, err := signer.PubKey(args[1], pubKey)
-				if err != nil {
-					return err
-				}
-				output.ValConsPubAddress = pubKeyBech32
-			} else {
+			if bech32Prefix == "" {
 				bech32Hint := "Pass bech32 base prefix as argument to generate (e.g. cosmos)"
 				output.ValConsAddress = bech32Hint
 				output.ValConsPubAddress = bech32Hint
@@ -109,3 +60,135 @@ func addressCmd() *cobra.Command {
 
 	return cmd
 }
+
+func cosignerAddressCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "address",
+		Short: "Print the consensus public key and address of the validator",
+		Long: `Print the consensus public key and address of the validator for a chain.
+The public key is read from the key shard of this cosigner in threshold mode, which holds the public key
+of the whole validator, or from the priv validator key in single signer mode. Pass --bech32-prefix to
+also print the bech32 valcons address and public key, e.g. for unjail or slashing queries.`,
+		Example: `horcrux cosigner address --chain-id cosmoshub-4
+horcrux cosigner address --chain-id cosmoshub-4 --bech32-prefix cosmos --output json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := cmd.Flags()
+
+			outputFormat, _ := f.GetString(flagOutput)
+			if outputFormat != outputText && outputFormat != outputJSON {
+				return fmt.Errorf("invalid %s %q, must be one of: %s, %s", flagOutput, outputFormat, outputText, outputJSON)
+			}
+
+			chainID, _ := f.GetString(flagChainID)
+			bech32Prefix, _ := f.GetString(flagBech32Prefix)
+
+			output, err := validatorAddress(chainID, bech32Prefix)
+			if err != nil {
+				return err
+			}
+
+			return printAddress(cmd.OutOrStdout(), outputFormat, output)
+		},
+	}
+
+	f := cmd.Flags()
+	f.String(flagChainID, "", "chain ID of the validator")
+	f.String(flagBech32Prefix, "", "bech32 base prefix of the chain, e.g. cosmos")
+	f.StringP(flagOutput, "o", outputText, "output format, text or json")
+	_ = cmd.MarkFlagRequired(flagChainID)
+
+	return cmd
+}
+
+// validatorPubKey returns the consensus public key of the validator for the chain, from the key shard
+// in threshold mode or the priv validator key in single signer mode.
+func validatorPubKey(chainID string) (crypto.PubKey, error) {
+	switch config.Config.SignMode {
+	case signer.SignModeThreshold:
+		err := config.Config.ValidateThresholdModeConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		keyFile, err := config.KeyFileExistsCosigner(chainID)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := signer.LoadCosignerEd25519Key(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cosigner key: %w, check that key is present for chain ID: %s", err, chainID)
+		}
+
+		return key.PubKey, nil
+	case signer.SignModeSingle:
+		err := config.Config.ValidateSingleSignerConfig()
+		if err != nil {
+			return nil, err
+		}
+		keyFile, err := config.KeyFileExistsSingleSigner(chainID)
+		if err != nil {
+			return nil, fmt.Errorf("error reading priv-validator key: %w, check that key is present for chain ID: %s", err, chainID)
+		}
+
+		filePV := cometprivval.LoadFilePVEmptyState(keyFile, "")
+		return filePV.Key.PubKey, nil
+	default:
+		panic(fmt.Errorf("unexpected sign mode: %s", config.Config.SignMode))
+	}
+}
+
+// validatorAddress returns the addresses of the validator for the chain. The bech32 addresses are left
+// empty unless a bech32 base prefix is given.
+func validatorAddress(chainID string, bech32Prefix string) (AddressCmdOutput, error) {
+	pubKey, err := validatorPubKey(chainID)
+	if err != nil {
+		return AddressCmdOutput{}, err
+	}
+
+	pubKeyAddress := pubKey.Address()
+
+	pubKeyJSON, err := signer.PubKey("", pubKey)
+	if err != nil {
+		return AddressCmdOutput{}, err
+	}
+
+	output := AddressCmdOutput{
+		HexAddress: strings.ToUpper(hex.EncodeToString(pubKeyAddress)),
+		PubKey:     pubKeyJSON,
+	}
+
+	if bech32Prefix == "" {
+		return output, nil
+	}
+
+	bech32ValConsAddress, err := bech32.ConvertAndEncode(bech32Prefix+"valcons", pubKeyAddress)
+	if err != nil {
+		return AddressCmdOutput{}, err
+	}
+	output.ValConsAddress = bech32ValConsAddress
+	pubKeyBech32, err := signer.PubKey(bech32Prefix, pubKey)
+	if err != nil {
+		return AddressCmdOutput{}, err
+	}
+	output.ValConsPubAddress = pubKeyBech32
+
+	return output, nil
+}
+
+// printAddress prints the addresses of the validator as text or json.
+func printAddress(w io.Writer, outputFormat string, output AddressCmdOutput) error {
+	if outputFormat == outputJSON {
+		return json.NewEncoder(w).Encode(output)
+	}
+
+	fmt.Fprintf(w, "Address: %s\n", output.HexAddress)
+	fmt.Fprintf(w, "Public key: %s\n", output.PubKey)
+	if output.ValConsAddress != "" {
+		fmt.Fprintf(w, "Valcons address: %s\n", output.ValConsAddress)
+		fmt.Fprintf(w, "Valcons public key: %s\n", output.ValConsPubAddress)
+	}
+	return nil
+}
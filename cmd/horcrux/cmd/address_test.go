@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosignerAddressCmd(t *testing.T) {
+	tmpHome := t.TempDir()
+	tmpConfig := filepath.Join(tmpHome, ".horcrux")
+
+	cmd := rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{
+		"--home", tmpConfig,
+		"config", "init",
+		"-m", "single",
+		"-n", "tcp://10.168.0.1:1234",
+	})
+	require.NoError(t, cmd.Execute())
+
+	privKey := ed25519.GenPrivKey()
+	pv := privval.NewFilePV(privKey, filepath.Join(tmpConfig, testChainID+"_priv_validator_key.json"),
+		filepath.Join(tmpHome, "priv_validator_state.json"))
+	pv.Save()
+
+	address := strings.ToUpper(hex.EncodeToString(privKey.PubKey().Address()))
+	valcons, err := bech32.ConvertAndEncode("cosmosvalcons", privKey.PubKey().Address())
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	cmd = rootCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"--home", tmpConfig,
+		"cosigner", "address",
+		"--chain-id", testChainID,
+		"--bech32-prefix", "cosmos",
+		"--output", "json",
+	})
+	require.NoError(t, cmd.Execute())
+
+	var output AddressCmdOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &output))
+	require.Equal(t, address, output.HexAddress)
+	require.Equal(t, valcons, output.ValConsAddress)
+	require.True(t, strings.HasPrefix(output.ValConsPubAddress, "cosmosvalconspub"))
+
+	// the bech32 addresses are only printed with a prefix.
+	out.Reset()
+	cmd = rootCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"--home", tmpConfig,
+		"cosigner", "address",
+		"--chain-id", testChainID,
+	})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), "Address: "+address+"\n")
+	require.NotContains(t, out.String(), "Valcons")
+
+	cmd = rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{
+		"--home", tmpConfig,
+		"cosigner", "address",
+		"--chain-id", "other-1",
+	})
+	require.Error(t, cmd.Execute())
+}
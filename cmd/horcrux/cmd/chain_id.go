@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/horcrux/signer"
+)
+
+const flagDryRun = "dry-run"
+
+func chainIDCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chain-id",
+		Short: "Commands to manage the per chain-id files of the horcrux signer",
+	}
+
+	cmd.AddCommand(chainIDSetCmd())
+
+	return cmd
+}
+
+func chainIDSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set [old-chain-id] [new-chain-id]",
+		Short: "move the state and key files of a chain-id to a new chain-id",
+		Long: `move the state and key files of a chain-id to a new chain-id, e.g. for a testnet to mainnet cutover
+reusing the same home directory.
+the sign states are validated before they are moved, and nothing is moved if a file already exists for the new chain-id.
+if a move fails, the files already moved are moved back.
+horcrux must be stopped while the files are moved.
+		`,
+		Example: `horcrux config chain-id set cosmoshub-testnet cosmoshub-4 --dry-run`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			oldChainID, newChainID := args[0], args[1]
+			if oldChainID == newChainID {
+				return fmt.Errorf("new chain-id is the same as the old chain-id %s", oldChainID)
+			}
+
+			// a running signer would keep writing the sign states at the old paths.
+			if err := signer.RequireNotRunning(config.PidFile); err != nil {
+				return err
+			}
+
+			renames, err := chainIDRenames(oldChainID, newChainID)
+			if err != nil {
+				return err
+			}
+
+			// silence usage after all input has been validated
+			cmd.SilenceUsage = true
+
+			dryRun, _ := cmd.Flags().GetBool(flagDryRun)
+			out := cmd.OutOrStdout()
+			if dryRun {
+				for _, r := range renames {
+					fmt.Fprintf(out, "Would move %s to %s\n", r.from, r.to)
+				}
+				return nil
+			}
+			return moveChainIDFiles(out, renames)
+		},
+	}
+
+	cmd.Flags().Bool(flagDryRun, false, "print the files that would be moved without moving them")
+	return cmd
+}
+
+// renameFile is swapped in tests to inject rename failures.
+var renameFile = os.Rename
+
+type chainIDRename struct {
+	from, to string
+}
+
+// moveChainIDFiles moves the files of the renames in order.
+// If a move fails, the files already moved are moved back, so that the chain-id is never left half renamed.
+func moveChainIDFiles(out io.Writer, renames []chainIDRename) error {
+	for i, r := range renames {
+		if err := renameFile(r.from, r.to); err != nil {
+			err = fmt.Errorf("failed to move %s to %s: %w", r.from, r.to, err)
+			for j := i - 1; j >= 0; j-- {
+				if rerr := renameFile(renames[j].to, renames[j].from); rerr != nil {
+					return fmt.Errorf("%w, and failed to move %s back to %s: %v", err, renames[j].to, renames[j].from, rerr)
+				}
+				fmt.Fprintf(out, "Moved %s back to %s\n", renames[j].to, renames[j].from)
+			}
+			return err
+		}
+		fmt.Fprintf(out, "Moved %s to %s\n", r.from, r.to)
+	}
+	return nil
+}
+
+// chainIDRenames returns the files of the old chain-id that exist and the path for the new chain-id of each.
+// It returns an error if a sign state can not be loaded, or if any file already exists for the new chain-id,
+// before anything is moved.
+func chainIDRenames(oldChainID, newChainID string) ([]chainIDRename, error) {
+	stateFiles := map[string]bool{
+		config.PrivValStateFile(oldChainID):  true,
+		config.CosignerStateFile(oldChainID): true,
+	}
+
+	candidates := []chainIDRename{
+		{config.PrivValStateFile(oldChainID), config.PrivValStateFile(newChainID)},
+		{config.CosignerStateFile(oldChainID), config.CosignerStateFile(newChainID)},
+		{config.SignWALFile(oldChainID), config.SignWALFile(newChainID)},
+		{config.KeyFilePathSingleSigner(oldChainID), config.KeyFilePathSingleSigner(newChainID)},
+		{config.KeyFilePathCosigner(oldChainID), config.KeyFilePathCosigner(newChainID)},
+	}
+
+	var renames []chainIDRename
+	for _, r := range candidates {
+		if _, err := os.Stat(r.from); os.IsNotExist(err) {
+			continue
+		}
+		if stateFiles[r.from] {
+			if _, err := signer.LoadSignState(r.from); err != nil {
+				return nil, fmt.Errorf("failed to load sign state %s: %w", r.from, err)
+			}
+		}
+		renames = append(renames, r)
+	}
+
+	if len(renames) == 0 {
+		return nil, fmt.Errorf("no state or key files found for chain-id %s", oldChainID)
+	}
+
+	// a file left for the new chain-id would be mixed with the moved ones, even without a counterpart to move.
+	for _, r := range candidates {
+		if _, err := os.Stat(r.to); !os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s already exists for chain-id %s, refusing to overwrite it", r.to, newChainID)
+		}
+	}
+	return renames, nil
+}
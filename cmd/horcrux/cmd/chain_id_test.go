@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/strangelove-ventures/horcrux/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigChainIDSetCmd(t *testing.T) {
+	tmpHome := t.TempDir()
+	tmpConfig := filepath.Join(tmpHome, ".horcrux")
+	stateDir := filepath.Join(tmpConfig, "state")
+
+	const (
+		oldChainID   = "horcrux-testnet-1"
+		newChainID   = "horcrux-1"
+		otherChainID = "horcrux-2"
+	)
+
+	execute := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := rootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs(append([]string{"--home", tmpConfig}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	_, err := execute(
+		"config", "init",
+		"-n", "tcp://10.168.0.1:1234",
+		"-t", "2",
+		"-c", "tcp://10.168.1.1:2222,tcp://10.168.1.2:2222,tcp://10.168.1.3:2222",
+	)
+	require.NoError(t, err)
+
+	for _, chainID := range []string{oldChainID, otherChainID} {
+		for _, file := range []string{"_priv_validator_state.json", "_share_sign_state.json"} {
+			_, err := signer.LoadOrCreateSignState(filepath.Join(stateDir, chainID+file))
+			require.NoError(t, err)
+		}
+	}
+	walFile := filepath.Join(stateDir, oldChainID+"_sign_wal.json")
+	require.NoError(t, os.WriteFile(walFile, []byte("{}"), 0600))
+	shardFile := filepath.Join(tmpConfig, oldChainID+"_shard.json")
+	require.NoError(t, os.WriteFile(shardFile, []byte("{}"), 0600))
+
+	oldFiles := []string{
+		filepath.Join(stateDir, oldChainID+"_priv_validator_state.json"),
+		filepath.Join(stateDir, oldChainID+"_share_sign_state.json"),
+		walFile,
+		shardFile,
+	}
+	newFiles := []string{
+		filepath.Join(stateDir, newChainID+"_priv_validator_state.json"),
+		filepath.Join(stateDir, newChainID+"_share_sign_state.json"),
+		filepath.Join(stateDir, newChainID+"_sign_wal.json"),
+		filepath.Join(tmpConfig, newChainID+"_shard.json"),
+	}
+
+	// nothing is moved while horcrux is running, the parent process stands in for it.
+	pidFile := filepath.Join(tmpConfig, "horcrux.pid")
+	require.NoError(t, os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getppid())), 0600))
+	_, err = execute("config", "chain-id", "set", oldChainID, newChainID)
+	require.ErrorContains(t, err, "horcrux is already running")
+	for _, file := range oldFiles {
+		require.FileExists(t, file)
+	}
+	require.NoError(t, os.Remove(pidFile))
+
+	// a dry run only prints the plan.
+	out, err := execute("config", "chain-id", "set", oldChainID, newChainID, "--dry-run")
+	require.NoError(t, err)
+	for i := range oldFiles {
+		require.Contains(t, out, "Would move "+oldFiles[i]+" to "+newFiles[i])
+		require.FileExists(t, oldFiles[i])
+		require.NoFileExists(t, newFiles[i])
+	}
+
+	// a failed move puts back the files already moved.
+	renames := 0
+	renameFile = func(from, to string) error {
+		renames++
+		if renames == 2 {
+			return errors.New("injected failure")
+		}
+		return os.Rename(from, to)
+	}
+	out, err = execute("config", "chain-id", "set", oldChainID, newChainID)
+	renameFile = os.Rename
+	require.ErrorContains(t, err, "injected failure")
+	require.Contains(t, out, "Moved "+newFiles[0]+" back to "+oldFiles[0])
+	for i := range oldFiles {
+		require.FileExists(t, oldFiles[i])
+		require.NoFileExists(t, newFiles[i])
+	}
+
+	out, err = execute("config", "chain-id", "set", oldChainID, newChainID)
+	require.NoError(t, err)
+	for i := range oldFiles {
+		require.Contains(t, out, "Moved "+oldFiles[i]+" to "+newFiles[i])
+		require.NoFileExists(t, oldFiles[i])
+		require.FileExists(t, newFiles[i])
+	}
+
+	// the state of the other chain-id must not be clobbered.
+	_, err = execute("config", "chain-id", "set", newChainID, otherChainID)
+	require.ErrorContains(t, err, "already exists for chain-id "+otherChainID)
+	for _, file := range newFiles {
+		require.FileExists(t, file)
+	}
+
+	_, err = execute("config", "chain-id", "set", oldChainID, otherChainID)
+	require.ErrorContains(t, err, "no state or key files found for chain-id "+oldChainID)
+}
@@ -1,16 +1,24 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/strangelove-ventures/horcrux/signer"
+	"gopkg.in/yaml.v2"
 )
 
 const (
 	flagSignMode    = "mode"
 	flagNode        = "node"
+	flagBackupNode  = "backup-node"
+	flagNodesFile   = "nodes-file"
 	flagCosigner    = "cosigner"
 	flagDebugAddr   = "debug-addr"
 	flagKeyDir      = "key-dir"
@@ -18,6 +26,7 @@ const (
 	flagGRPCTimeout = "grpc-timeout"
 	flagOverwrite   = "overwrite"
 	flagBare        = "bare"
+	flagImportState = "import-state"
 )
 
 func configCmd() *cobra.Command {
@@ -28,10 +37,329 @@ func configCmd() *cobra.Command {
 
 	cmd.AddCommand(initCmd())
 	cmd.AddCommand(migrateCmd())
+	cmd.AddCommand(migrateToThresholdCmd())
+	cmd.AddCommand(validateCmd())
+	cmd.AddCommand(showCmd())
+	cmd.AddCommand(cosignersCmd())
+	cmd.AddCommand(nodesCmd())
+	cmd.AddCommand(chainIDCmd())
 
 	return cmd
 }
 
+func nodesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nodes",
+		Short: "Commands to configure the chain nodes",
+	}
+
+	cmd.AddCommand(nodesAddCmd())
+	cmd.AddCommand(nodesRemoveCmd())
+	cmd.AddCommand(nodesLoadCmd())
+
+	return cmd
+}
+
+func nodesAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "add [node]",
+		Short:   "add a chain node to the configuration file",
+		Long:    "add a chain node to the configuration file, in format tcp://{node-addr}:{privval-port}",
+		Example: `horcrux config nodes add tcp://sentry-4:1234`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cn, err := signer.ChainNodesFromFlag(args)
+			if err != nil {
+				return err
+			}
+			node := cn[0]
+
+			cfg := config.Config
+			for _, n := range cfg.ChainNodes {
+				if n.PrivValAddr == node.PrivValAddr {
+					fmt.Fprintf(cmd.OutOrStdout(), "Chain node %s is already configured in %s\n",
+						node.PrivValAddr, config.ConfigFile)
+					return nil
+				}
+			}
+
+			cfg.ChainNodes = append(append(signer.ChainNodes{}, cfg.ChainNodes...), node)
+
+			if err := cfg.ValidateAll(); err != nil {
+				return err
+			}
+
+			// silence usage after all input has been validated
+			cmd.SilenceUsage = true
+
+			config.Config = cfg
+			if err := config.WriteConfigFile(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Added chain node %s to %s, restart horcrux to apply\n",
+				node.PrivValAddr, config.ConfigFile)
+			return nil
+		},
+	}
+}
+
+func nodesRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove [node]",
+		Aliases: []string{"rm"},
+		Short:   "remove a chain node from the configuration file",
+		Long:    "remove a chain node from the configuration file, in format tcp://{node-addr}:{privval-port}",
+		Example: `horcrux config nodes remove tcp://sentry-4:1234`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cn, err := signer.ChainNodesFromFlag(args)
+			if err != nil {
+				return err
+			}
+			node := cn[0]
+
+			cfg := config.Config
+			remaining := make(signer.ChainNodes, 0, len(cfg.ChainNodes))
+			for _, n := range cfg.ChainNodes {
+				if n.PrivValAddr != node.PrivValAddr {
+					remaining = append(remaining, n)
+				}
+			}
+			if len(remaining) == len(cfg.ChainNodes) {
+				return fmt.Errorf("chain node %s is not configured in %s", node.PrivValAddr, config.ConfigFile)
+			}
+			cfg.ChainNodes = remaining
+
+			if err := cfg.ValidateAll(); err != nil {
+				return err
+			}
+
+			// silence usage after all input has been validated
+			cmd.SilenceUsage = true
+
+			config.Config = cfg
+			if err := config.WriteConfigFile(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed chain node %s from %s, restart horcrux to apply\n",
+				node.PrivValAddr, config.ConfigFile)
+			return nil
+		},
+	}
+}
+
+func nodesLoadCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "load [file]",
+		Short: "replace the chain nodes in the configuration file with the chain nodes listed in a file",
+		Long: `replace the chain nodes in the configuration file with the chain nodes listed in a file.
+the file contains one chain node per line in format tcp://{node-addr}:{privval-port}.
+blank lines and lines starting with # are skipped.
+		`,
+		Example: `horcrux config nodes load ./chain-nodes.txt`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nodes, err := signer.ReadChainNodesFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			cn, err := signer.ChainNodesFromFlag(nodes)
+			if err != nil {
+				return err
+			}
+
+			if dupl := signer.DuplicateChainNodes(nodes); len(dupl) != 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: ignoring duplicate chain node(s): %v\n", dupl)
+			}
+
+			cfg := config.Config
+			cfg.ChainNodes = cn
+
+			if err := cfg.ValidateAll(); err != nil {
+				return err
+			}
+
+			// silence usage after all input has been validated
+			cmd.SilenceUsage = true
+
+			config.Config = cfg
+			if err := config.WriteConfigFile(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Loaded %d chain nodes into %s, restart horcrux to apply\n",
+				len(cn), config.ConfigFile)
+			return nil
+		},
+	}
+}
+
+func cosignersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cosigners",
+		Aliases: []string{"peers"},
+		Short:   "Commands to configure the cosigners of a threshold signer",
+	}
+
+	cmd.AddCommand(cosignersSetCmd())
+	cmd.AddCommand(cosignersAddCmd())
+	cmd.AddCommand(cosignersRemoveCmd())
+
+	return cmd
+}
+
+func cosignersSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set [cosigners]",
+		Short: "replace the entire list of cosigners in the configuration file",
+		Long: `replace the entire list of cosigners in the configuration file.
+cosigners are comma separated in format tcp://{cosigner-addr}:{p2p-port}, optionally suffixed with |{shard-id}.
+cosigners without a shard ID are assigned the shard ID matching their position in the list.
+the new list is validated in its entirety before the configuration file is replaced.
+		`,
+		Example: `horcrux config cosigners set "tcp://horcrux-1:2222|1,tcp://horcrux-2:2222|2,tcp://horcrux-3:2222|3"`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if config.Config.ThresholdModeConfig == nil {
+				return fmt.Errorf("cosigners can only be configured for threshold mode, %s has no thresholdMode section",
+					config.ConfigFile)
+			}
+
+			cosigners, err := signer.CosignersFromFlag(strings.Split(args[0], ","))
+			if err != nil {
+				return err
+			}
+
+			// the whole cluster is being replaced, so also reject cosigners sharing an address.
+			if dupl := signer.DuplicateCosignerAddrs(cosigners); len(dupl) != 0 {
+				return fmt.Errorf("found duplicate cosigner p2p address(es) in args: %v", dupl)
+			}
+
+			if err := writeCosigners(cmd, cosigners); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Replaced %d cosigners in %s, restart horcrux to apply\n",
+				len(cosigners), config.ConfigFile)
+			return nil
+		},
+	}
+}
+
+func cosignersAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add [cosigner]",
+		Short: "add a cosigner to the configuration file",
+		Long: `add a cosigner to the configuration file.
+the cosigner is in format tcp://{cosigner-addr}:{p2p-port}, optionally suffixed with |{shard-id}.
+a cosigner without a shard ID is assigned the shard ID following the configured cosigners.
+a cosigner with the shard ID of a configured cosigner is rejected.
+the key shards of every cosigner must be recreated for the new number of cosigners before restarting.
+		`,
+		Example: `horcrux config cosigners add "tcp://horcrux-4:2222|4"`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if config.Config.ThresholdModeConfig == nil {
+				return fmt.Errorf("cosigners can only be configured for threshold mode, %s has no thresholdMode section",
+					config.ConfigFile)
+			}
+			existing := config.Config.ThresholdModeConfig.Cosigners
+
+			arg := args[0]
+			if !strings.Contains(arg, "|") {
+				arg = fmt.Sprintf("%s|%d", arg, len(existing)+1)
+			}
+			added, err := signer.CosignersFromFlag([]string{arg})
+			if err != nil {
+				return err
+			}
+			cosigner := added[0]
+
+			for _, c := range existing {
+				if c.ShardID == cosigner.ShardID {
+					return fmt.Errorf("shard ID %d is already configured for cosigner %s", c.ShardID, c.P2PAddr)
+				}
+			}
+
+			cosigners := append(append(signer.CosignersConfig{}, existing...), cosigner)
+			if err := writeCosigners(cmd, cosigners); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Added cosigner %s with shard ID %d to %s, restart horcrux to apply\n",
+				cosigner.P2PAddr, cosigner.ShardID, config.ConfigFile)
+			return nil
+		},
+	}
+}
+
+func cosignersRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove [cosigner]",
+		Aliases: []string{"rm"},
+		Short:   "remove a cosigner from the configuration file",
+		Long: `remove a cosigner from the configuration file.
+the cosigner is identified by either its address in format tcp://{cosigner-addr}:{p2p-port} or its shard ID.
+		`,
+		Example: `horcrux config cosigners remove tcp://horcrux-4:2222
+horcrux config cosigners remove 4`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if config.Config.ThresholdModeConfig == nil {
+				return fmt.Errorf("cosigners can only be configured for threshold mode, %s has no thresholdMode section",
+					config.ConfigFile)
+			}
+			existing := config.Config.ThresholdModeConfig.Cosigners
+
+			shardID, err := strconv.Atoi(args[0])
+			isShardID := err == nil
+
+			var removed *signer.CosignerConfig
+			cosigners := make(signer.CosignersConfig, 0, len(existing))
+			for i, c := range existing {
+				if (isShardID && c.ShardID == shardID) || (!isShardID && c.P2PAddr == args[0]) {
+					removed = &existing[i]
+					continue
+				}
+				cosigners = append(cosigners, c)
+			}
+			if removed == nil {
+				return fmt.Errorf("cosigner %s is not configured in %s", args[0], config.ConfigFile)
+			}
+
+			if err := writeCosigners(cmd, cosigners); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed cosigner %s with shard ID %d from %s, restart horcrux to apply\n",
+				removed.P2PAddr, removed.ShardID, config.ConfigFile)
+			return nil
+		},
+	}
+}
+
+// writeCosigners validates the threshold mode config with the cosigners replaced,
+// and writes it to the configuration file if it is valid.
+func writeCosigners(cmd *cobra.Command, cosigners signer.CosignersConfig) error {
+	cfg := config.Config
+	thresholdCfg := *cfg.ThresholdModeConfig
+	thresholdCfg.Cosigners = cosigners
+	cfg.ThresholdModeConfig = &thresholdCfg
+
+	if err := cfg.ValidateThresholdModeConfig(); err != nil {
+		return err
+	}
+
+	// silence usage after all input has been validated
+	cmd.SilenceUsage = true
+
+	config.Config = cfg
+	return config.WriteConfigFile()
+}
+
 func initCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "init",
@@ -44,14 +372,52 @@ for threshold signer mode, --cosigner flags and --threshold flag are required.
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			cmdFlags := cmd.Flags()
 
+			output, _ := cmdFlags.GetString(flagOutput)
+			if output != outputText && output != outputJSON {
+				return fmt.Errorf("invalid %s %q, must be one of: %s, %s", flagOutput, output, outputText, outputJSON)
+			}
+
 			bare, _ := cmdFlags.GetBool(flagBare)
 			nodes, _ := cmdFlags.GetStringSlice(flagNode)
 
+			if nodesFile, _ := cmdFlags.GetString(flagNodesFile); nodesFile != "" {
+				fileNodes, err := signer.ReadChainNodesFile(nodesFile)
+				if err != nil {
+					return err
+				}
+				nodes = append(nodes, fileNodes...)
+			}
+
 			cn, err := signer.ChainNodesFromFlag(nodes)
 			if err != nil {
 				return err
 			}
 
+			if dupl := signer.DuplicateChainNodes(nodes); len(dupl) != 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: ignoring duplicate chain node(s): %v\n", dupl)
+			}
+
+			backupNodes, _ := cmdFlags.GetStringSlice(flagBackupNode)
+			var backupCN signer.ChainNodes
+			if len(backupNodes) > 0 {
+				backupCN, err = signer.ChainNodesFromFlag(backupNodes)
+				if err != nil {
+					return err
+				}
+			}
+
+			importState, _ := cmdFlags.GetString(flagImportState)
+			importChainID, _ := cmdFlags.GetString(flagChainID)
+			var pvStateJSON []byte
+			if importState != "" {
+				if importChainID == "" {
+					return fmt.Errorf("--%s requires --%s", flagImportState, flagChainID)
+				}
+				if pvStateJSON, err = os.ReadFile(importState); err != nil {
+					return fmt.Errorf("error reading priv_validator_state.json: %w", err)
+				}
+			}
+
 			overwrite, _ := cmdFlags.GetBool(flagOverwrite)
 
 			if _, err := os.Stat(config.ConfigFile); !os.IsNotExist(err) && !overwrite {
@@ -88,8 +454,9 @@ for threshold signer mode, --cosigner flags and --threshold flag are required.
 						GRPCTimeout: grpcTimeout,
 						RaftTimeout: raftTimeout,
 					},
-					ChainNodes: cn,
-					DebugAddr:  debugAddr,
+					ChainNodes:       cn,
+					BackupChainNodes: backupCN,
+					DebugAddr:        debugAddr,
 				}
 
 				if !bare {
@@ -100,10 +467,11 @@ for threshold signer mode, --cosigner flags and --threshold flag are required.
 			} else {
 				// Single Signer Config
 				cfg = signer.Config{
-					SignMode:      signer.SignModeSingle,
-					PrivValKeyDir: keyDir,
-					ChainNodes:    cn,
-					DebugAddr:     debugAddr,
+					SignMode:         signer.SignModeSingle,
+					PrivValKeyDir:    keyDir,
+					ChainNodes:       cn,
+					BackupChainNodes: backupCN,
+					DebugAddr:        debugAddr,
 				}
 				if !bare {
 					if err = cfg.ValidateSingleSignerConfig(); err != nil {
@@ -116,15 +484,45 @@ for threshold signer mode, --cosigner flags and --threshold flag are required.
 			cmd.SilenceUsage = true
 
 			// create all directories up to the state directory
-			if err = os.MkdirAll(config.StateDir, 0755); err != nil {
+			if err = os.MkdirAll(config.StateDir, 0700); err != nil {
 				return err
 			}
+			if overwrite {
+				backup, err := backupConfigFile(time.Now())
+				if err != nil {
+					return err
+				}
+				if backup != "" {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Backed up the existing config to %s\n", backup)
+				}
+			}
+
 			// create the config file
 			config.Config = cfg
 			if err = config.WriteConfigFile(); err != nil {
 				return err
 			}
 
+			if importState != "" {
+				if err := signer.RequireNotRunning(config.PidFile); err != nil {
+					return err
+				}
+				// keep stdout to the summary with --output json.
+				importOut := cmd.OutOrStdout()
+				if output == outputJSON {
+					importOut = cmd.ErrOrStderr()
+				}
+				if err := importSignState(importOut, importChainID, pvStateJSON); err != nil {
+					return err
+				}
+			}
+
+			if output == outputJSON {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(newInitSummary(config))
+			}
+
 			fmt.Printf("Successfully initialized configuration: %s\n", config.ConfigFile)
 			return nil
 		},
@@ -136,9 +534,13 @@ for threshold signer mode, --cosigner flags and --threshold flag are required.
 	)
 	f.StringSliceP(flagNode, "n", []string{}, "chain nodes in format tcp://{node-addr}:{privval-port} \n"+
 		"(e.g. --node tcp://sentry-1:1234 --node tcp://sentry-2:1234 --node tcp://sentry-3:1234 )")
+	f.String(flagNodesFile, "", "file with one chain node per line in format tcp://{node-addr}:{privval-port}, \n"+
+		"in addition to any --node chain nodes")
+	f.StringSlice(flagBackupNode, []string{}, "backup chain nodes in format tcp://{node-addr}:{privval-port}, \n"+
+		"only connected to while none of the --node chain nodes are reachable")
 
 	f.StringSliceP(flagCosigner, "c", []string{},
-		`cosigners in format tcp://{cosigner-addr}:{p2p-port}
+		`cosigners in format tcp://{cosigner-addr}:{p2p-port}, optionally suffixed with |{shard-id}
 (e.g. --cosigner tcp://horcrux-1:2222 --cosigner tcp://horcrux-2:2222 --cosigner tcp://horcrux-3:2222)`)
 
 	f.IntP(flagThreshold, "t", 0, "number of shards required for threshold signature")
@@ -152,7 +554,11 @@ for threshold signer mode, --cosigner flags and --threshold flag are required.
 		"accepts valid duration strings for Go's time.ParseDuration() e.g. 1s, 1000ms, 1.5m")
 	f.String(flagGRPCTimeout, "1500ms", "cosigner grpc timeout value, \n"+
 		"accepts valid duration strings for Go's time.ParseDuration() e.g. 1s, 1000ms, 1.5m")
-	f.BoolP(flagOverwrite, "o", false, "overwrite an existing config.yaml")
+	f.BoolP(flagOverwrite, "o", false, "overwrite an existing config.yaml, after backing it up to config.yaml.{time}.bak")
+	f.String(flagOutput, outputText, "output format, text or json")
+	f.String(flagImportState, "", "priv_validator_state.json of the validator being migrated, \n"+
+		"to import its last signed height, round and step as the sign state of --chain-id")
+	f.String(flagChainID, "", "chain-id of the --import-state priv_validator_state.json")
 	f.Bool(
 		flagBare,
 		false,
@@ -160,3 +566,125 @@ for threshold signer mode, --cosigner flags and --threshold flag are required.
 	)
 	return cmd
 }
+
+// backupConfigFile copies the existing config file to a timestamped config.yaml.{time}.bak file,
+// so that a config overwritten by config init can be restored. It returns the path of the backup,
+// or an empty path if no config exists.
+func backupConfigFile(now time.Time) (string, error) {
+	bz, err := os.ReadFile(config.ConfigFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read the existing config: %w", err)
+	}
+
+	backup := fmt.Sprintf("%s.%s.bak", config.ConfigFile, now.UTC().Format("20060102T150405Z"))
+	if err := os.WriteFile(backup, bz, 0600); err != nil {
+		return "", fmt.Errorf("failed to back up the existing config: %w", err)
+	}
+	return backup, nil
+}
+
+// initSummary is printed by config init with --output json for provisioning scripts.
+// The config does not have a chain-id, the state files of each chain-id are created on the first sign request.
+type initSummary struct {
+	HomeDir    string `json:"homeDir"`
+	ConfigFile string `json:"configFile"`
+	StateDir   string `json:"stateDir"`
+	SignMode   string `json:"signMode"`
+	Threshold  int    `json:"threshold,omitempty"`
+	Peers      int    `json:"peers"`
+	ChainNodes int    `json:"chainNodes"`
+}
+
+func newInitSummary(config signer.RuntimeConfig) initSummary {
+	s := initSummary{
+		HomeDir:    config.HomeDir,
+		ConfigFile: config.ConfigFile,
+		StateDir:   config.StateDir,
+		SignMode:   string(config.Config.SignMode),
+		ChainNodes: len(config.Config.ChainNodes),
+	}
+	if tc := config.Config.ThresholdModeConfig; tc != nil {
+		s.Threshold = tc.Threshold
+		if len(tc.Cosigners) > 0 {
+			s.Peers = len(tc.Cosigners) - 1
+		}
+	}
+	return s
+}
+
+func validateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [file]",
+		Short: "validate a configuration file against every config rule and report all failures",
+		Long: `validate a configuration file against every config rule and report all failures.
+defaults to the config file in the home directory if no file is provided.
+the sign state files in the state directory of the home directory must also be readable.
+exits with a non-zero code if the config is invalid, so it can be used to gate config changes in CI.
+		`,
+		Example: `horcrux config validate
+horcrux config validate ./cosigner-1/config.yaml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := config.ConfigFile
+			if len(args) == 1 {
+				file = args[0]
+			}
+
+			bz, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read config file: %w", err)
+			}
+
+			// silence usage after the config file has been read
+			cmd.SilenceUsage = true
+
+			out := cmd.OutOrStdout()
+
+			var cfg signer.Config
+			if err := yaml.UnmarshalStrict(bz, &cfg); err != nil {
+				fmt.Fprintf(out, "%s is invalid:\n  - %v\n", file, err)
+				return fmt.Errorf("failed to parse config file: %s", file)
+			}
+
+			var errs []error
+			if err := cfg.ValidateAll(); err != nil {
+				errs = []error{err}
+				if joined, ok := err.(interface{ Unwrap() []error }); ok {
+					errs = joined.Unwrap()
+				}
+			}
+			errs = append(errs, stateFileErrors()...)
+
+			if len(errs) == 0 {
+				fmt.Fprintf(out, "%s is valid\n", file)
+				return nil
+			}
+
+			fmt.Fprintf(out, "%s is invalid:\n", file)
+			for _, err := range errs {
+				fmt.Fprintf(out, "  - %v\n", err)
+			}
+
+			return fmt.Errorf("found %d error(s) in config file: %s", len(errs), file)
+		},
+	}
+}
+
+// stateFileErrors returns an error for each sign state file in the state directory that can not be read or loaded.
+func stateFileErrors() (errs []error) {
+	for _, pattern := range []string{config.PrivValStateFile("*"), config.CosignerStateFile("*")} {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return append(errs, err)
+		}
+		for _, file := range files {
+			if _, err := signer.LoadSignState(file); err != nil {
+				errs = append(errs, fmt.Errorf("failed to load sign state %s: %w", file, err))
+			}
+		}
+	}
+	return errs
+}
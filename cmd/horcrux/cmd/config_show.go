@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	flagRaw = "raw"
+
+	outputYAML = "yaml"
+
+	redacted = "REDACTED"
+)
+
+func showCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "print the configuration of the horcrux signer",
+		Long: `print the configuration of the horcrux signer, with the paths to key material redacted.
+use --raw to print the configuration as is.
+		`,
+		Example: `horcrux config show
+horcrux config show --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(flagOutput)
+			if output != outputYAML && output != outputJSON {
+				return fmt.Errorf("invalid %s %q, must be one of: %s, %s", flagOutput, output, outputYAML, outputJSON)
+			}
+
+			if _, err := os.Stat(config.ConfigFile); err != nil {
+				return fmt.Errorf("no config exists at %s: %w", config.ConfigFile, err)
+			}
+
+			// silence usage after all input has been validated
+			cmd.SilenceUsage = true
+
+			cfg := config.Config
+			if raw, _ := cmd.Flags().GetBool(flagRaw); !raw {
+				if cfg.PrivValKeyDir != nil {
+					keyDir := redacted
+					cfg.PrivValKeyDir = &keyDir
+				}
+			}
+
+			bz := cfg.MustMarshalYaml()
+			if output == outputJSON {
+				compact, err := yaml.YAMLToJSON(bz)
+				if err != nil {
+					return fmt.Errorf("failed to convert config to json: %w", err)
+				}
+				var indented bytes.Buffer
+				if err := json.Indent(&indented, compact, "", "  "); err != nil {
+					return fmt.Errorf("failed to convert config to json: %w", err)
+				}
+				bz = append(indented.Bytes(), '\n')
+			}
+
+			_, err := cmd.OutOrStdout().Write(bz)
+			return err
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringP(flagOutput, "o", outputYAML, "output format, yaml or json")
+	f.Bool(flagRaw, false, "print the paths to key material instead of redacting them")
+
+	return cmd
+}
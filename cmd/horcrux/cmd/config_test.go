@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -47,6 +50,64 @@ chainNodes:
 - privValAddr: tcp://10.168.0.1:1234
 - privValAddr: tcp://10.168.0.2:1234
 debugAddr: ""
+`,
+		},
+		{
+			name: "duplicate chain nodes",
+			home: tmpHome + "_duplicate_chain-nodes",
+			args: []string{
+				"-m", "single",
+				"-n", "tcp://10.168.0.1:1234",
+				"-n", "tcp://10.168.0.2:1234",
+				"-n", "TCP://10.168.0.1:1234",
+			},
+			expectConfig: `signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+- privValAddr: tcp://10.168.0.2:1234
+debugAddr: ""
+`,
+		},
+		{
+			name: "backup chain nodes",
+			home: tmpHome + "_backup_chain_nodes",
+			args: []string{
+				"-m", "single",
+				"-n", "tcp://10.168.0.1:1234",
+				"--backup-node", "tcp://10.169.0.1:1234",
+			},
+			expectConfig: `signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+backupChainNodes:
+- privValAddr: tcp://10.169.0.1:1234
+debugAddr: ""
+`,
+		},
+		{
+			name: "backup chain node is also primary",
+			home: tmpHome + "_backup_chain_node_primary",
+			args: []string{
+				"-m", "single",
+				"-n", "tcp://10.168.0.1:1234",
+				"--backup-node", "TCP://10.168.0.1:1234",
+			},
+			expectErr: "chain node tcp://10.168.0.1:1234 is configured as both a primary and a backup",
+		},
+		{
+			name: "nodes file",
+			home: tmpHome + "_nodes_file",
+			args: []string{
+				"-m", "single",
+				"-n", "tcp://10.168.0.1:1234",
+				"--nodes-file", "testdata/chain-nodes.txt",
+			},
+			expectConfig: `signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+- privValAddr: tcp://10.168.0.2:1234
+- privValAddr: tcp://10.168.0.3:1234
+debugAddr: ""
 `,
 		},
 		{
@@ -92,7 +153,7 @@ debugAddr: ""
 				"--raft-timeout", "1500ms",
 				"--grpc-timeout", "1500ms",
 			},
-			expectErr: `failed to parse cosigner (shard ID: 1) p2p address: parse "://10.168.1.1:2222": missing protocol scheme`,
+			expectErr: `failed to parse cosigner (shard ID: 1) p2p address: error parsing URL: parse "://10.168.1.1:2222": missing protocol scheme`,
 		},
 		{
 			name: "invalid threshold",
@@ -139,6 +200,19 @@ debugAddr: ""
 			},
 			expectErr: `invalid grpcTimeout: time: missing unit in duration "1500"`,
 		},
+		{
+			name: "invalid chain nodes",
+			home: tmpHome + "_invalid_chain_nodes",
+			args: []string{
+				"-n", "tcp://10.168.0.1:1234, ,10.168.0.2:1234",
+				"-c", "tcp://10.168.1.1:2222",
+				"-c", "tcp://10.168.1.2:2222",
+				"-c", "tcp://10.168.1.3:2222",
+				"-t", "2",
+			},
+			expectErr: "chain node privval address is empty\n" +
+				`parse "10.168.0.2:1234": first path segment in URL cannot contain colon`,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -165,6 +239,664 @@ debugAddr: ""
 				require.NoError(t, err)
 
 				require.Equal(t, tc.expectConfig, string(actualConfig))
+
+				// the home directory holds the key shards, so it must not be accessible by group or other
+				stat, err := os.Stat(tmpConfig)
+				require.NoError(t, err)
+				require.Equal(t, os.FileMode(0700), stat.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestConfigInitCmdOutputJSON(t *testing.T) {
+	tmpConfig := filepath.Join(t.TempDir(), ".horcrux")
+
+	var out bytes.Buffer
+	cmd := rootCmd()
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{
+		"--home", tmpConfig,
+		"config", "init",
+		"-n", "tcp://10.168.0.1:1234",
+		"-n", "tcp://10.168.0.2:1234",
+		"-c", "tcp://10.168.1.1:2222,tcp://10.168.1.2:2222,tcp://10.168.1.3:2222",
+		"-t", "2",
+		"--output", "json",
+	})
+	require.NoError(t, cmd.Execute())
+
+	var summary initSummary
+	require.NoError(t, json.Unmarshal(out.Bytes(), &summary))
+	require.Equal(t, initSummary{
+		HomeDir:    tmpConfig,
+		ConfigFile: filepath.Join(tmpConfig, "config.yaml"),
+		StateDir:   filepath.Join(tmpConfig, "state"),
+		SignMode:   "threshold",
+		Threshold:  2,
+		Peers:      2,
+		ChainNodes: 2,
+	}, summary)
+	require.FileExists(t, summary.ConfigFile)
+	require.DirExists(t, summary.StateDir)
+}
+
+func TestConfigInitCmdOverwriteBackup(t *testing.T) {
+	tmpConfig := filepath.Join(t.TempDir(), ".horcrux")
+	configFile := filepath.Join(tmpConfig, "config.yaml")
+
+	execute := func(args ...string) error {
+		cmd := rootCmd()
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs(append([]string{"--home", tmpConfig, "config", "init", "--mode", "single"}, args...))
+		return cmd.Execute()
+	}
+
+	require.NoError(t, execute("-n", "tcp://10.168.0.1:1234"))
+	original, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, execute("-n", "tcp://10.168.0.2:1234"), "already exists")
+
+	require.NoError(t, execute("-n", "tcp://10.168.0.2:1234", "--overwrite"))
+	backups, err := filepath.Glob(configFile + ".*.bak")
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	backup, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	require.Equal(t, original, backup)
+
+	overwritten, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	require.Contains(t, string(overwritten), "tcp://10.168.0.2:1234")
+}
+
+func TestConfigValidateCmd(t *testing.T) {
+	tmpHome := t.TempDir()
+	tcs := []struct {
+		name         string
+		config       string
+		expectErr    string
+		expectOutput []string
+	}{
+		{
+			name: "valid threshold",
+			config: `signMode: threshold
+thresholdMode:
+  threshold: 2
+  cosigners:
+  - shardID: 1
+    p2pAddr: tcp://10.168.1.1:2222
+  - shardID: 2
+    p2pAddr: tcp://10.168.1.2:2222
+  - shardID: 3
+    p2pAddr: tcp://10.168.1.3:2222
+  grpcTimeout: 1500ms
+  raftTimeout: 1500ms
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAddr: ""
+`,
+			expectOutput: []string{"is valid"},
+		},
+		{
+			name: "reports all failures",
+			config: `signMode: threshold
+thresholdMode:
+  threshold: 1
+  cosigners:
+  - shardID: 1
+    p2pAddr: tcp://10.168.1.1:2222
+  - shardID: 2
+    p2pAddr: tcp://0.0.0.0:2222
+  - shardID: 3
+    p2pAddr: tcp://10.168.1.3:2222
+  grpcTimeout: 1500
+  raftTimeout: 1500ms
+chainNodes: []
+debugAddr: ""
+`,
+			expectErr: "found 4 error(s) in config file",
+			expectOutput: []string{
+				"need to have chainNodes configured for priv-val connection",
+				"threshold (1) must be greater than number of shards (3) / 2",
+				`invalid grpcTimeout: time: missing unit in duration "1500"`,
+				"host cannot be 0.0.0.0, must be reachable from other cosigners",
+			},
+		},
+		{
+			name: "unknown field",
+			config: `signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAdr: localhost:8543
+`,
+			expectErr:    "failed to parse config file",
+			expectOutput: []string{"field debugAdr not found"},
+		},
+	}
+
+	for i, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			configFile := filepath.Join(tmpHome, fmt.Sprintf("config_%d.yaml", i))
+			require.NoError(t, os.WriteFile(configFile, []byte(tc.config), 0600))
+
+			cmd := rootCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs([]string{"--home", filepath.Join(tmpHome, ".horcrux"), "config", "validate", configFile})
+			err := cmd.Execute()
+
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for _, expect := range tc.expectOutput {
+				require.Contains(t, out.String(), expect)
+			}
+		})
+	}
+
+	t.Run("unreadable state file", func(t *testing.T) {
+		stateDir := filepath.Join(tmpHome, ".horcrux", "state")
+		require.NoError(t, os.MkdirAll(stateDir, 0700))
+		stateFile := filepath.Join(stateDir, "horcrux-1_share_sign_state.json")
+		require.NoError(t, os.WriteFile(stateFile, []byte("{"), 0600))
+
+		configFile := filepath.Join(tmpHome, "config_0.yaml")
+
+		cmd := rootCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs([]string{"--home", filepath.Join(tmpHome, ".horcrux"), "config", "validate", configFile})
+		require.ErrorContains(t, cmd.Execute(), "found 1 error(s) in config file")
+		require.Contains(t, out.String(), "failed to load sign state "+stateFile)
+	})
+}
+
+func TestConfigCosignersSetCmd(t *testing.T) {
+	const initialConfig = `signMode: threshold
+thresholdMode:
+  threshold: 2
+  cosigners:
+  - shardID: 1
+    p2pAddr: tcp://10.168.1.1:2222
+  - shardID: 2
+    p2pAddr: tcp://10.168.1.2:2222
+  - shardID: 3
+    p2pAddr: tcp://10.168.1.3:2222
+  grpcTimeout: 1500ms
+  raftTimeout: 1500ms
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAddr: ""
+`
+
+	tcs := []struct {
+		name         string
+		cosigners    string
+		expectErr    string
+		expectConfig string
+	}{
+		{
+			name:      "replaces cosigners",
+			cosigners: "tcp://10.168.2.3:2222|3,tcp://10.168.2.1:2222|1,tcp://10.168.2.2:2222|2",
+			expectConfig: `signMode: threshold
+thresholdMode:
+  threshold: 2
+  cosigners:
+  - shardID: 3
+    p2pAddr: tcp://10.168.2.3:2222
+  - shardID: 1
+    p2pAddr: tcp://10.168.2.1:2222
+  - shardID: 2
+    p2pAddr: tcp://10.168.2.2:2222
+  grpcTimeout: 1500ms
+  raftTimeout: 1500ms
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAddr: ""
+`,
+		},
+		{
+			name:      "shard IDs from position",
+			cosigners: "tcp://10.168.2.1:2222,tcp://10.168.2.2:2222,tcp://10.168.2.3:2222",
+			expectConfig: `signMode: threshold
+thresholdMode:
+  threshold: 2
+  cosigners:
+  - shardID: 1
+    p2pAddr: tcp://10.168.2.1:2222
+  - shardID: 2
+    p2pAddr: tcp://10.168.2.2:2222
+  - shardID: 3
+    p2pAddr: tcp://10.168.2.3:2222
+  grpcTimeout: 1500ms
+  raftTimeout: 1500ms
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAddr: ""
+`,
+		},
+		{
+			name:      "duplicate shard ID",
+			cosigners: "tcp://10.168.2.1:2222|1,tcp://10.168.2.2:2222|1,tcp://10.168.2.3:2222|3",
+			expectErr: "found duplicate cosigner shard ID(s) in args: " +
+				"map[1:[tcp://10.168.2.1:2222 tcp://10.168.2.2:2222]]",
+		},
+		{
+			name:      "duplicate address",
+			cosigners: "tcp://10.168.2.1:2222|1,tcp://10.168.2.1:2222|2,tcp://10.168.2.3:2222|3",
+			expectErr: "found duplicate cosigner p2p address(es) in args: map[tcp://10.168.2.1:2222:[1 2]]",
+		},
+		{
+			name:      "threshold no longer met",
+			cosigners: "tcp://10.168.2.1:2222|1,tcp://10.168.2.2:2222|2,tcp://10.168.2.3:2222|3,tcp://10.168.2.4:2222|4",
+			expectErr: "threshold (2) must be greater than number of shards (4) / 2",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpConfig := filepath.Join(t.TempDir(), ".horcrux")
+			configFile := filepath.Join(tmpConfig, "config.yaml")
+
+			require.NoError(t, os.MkdirAll(tmpConfig, 0700))
+			require.NoError(t, os.WriteFile(configFile, []byte(initialConfig), 0600))
+
+			cmd := rootCmd()
+			cmd.SetOutput(io.Discard)
+			cmd.SetArgs([]string{"--home", tmpConfig, "config", "peers", "set", tc.cosigners})
+			err := cmd.Execute()
+
+			actualConfig, readErr := os.ReadFile(configFile)
+			require.NoError(t, readErr)
+
+			if tc.expectErr != "" {
+				require.EqualError(t, err, tc.expectErr)
+				// the config file is left untouched
+				require.Equal(t, initialConfig, string(actualConfig))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectConfig, string(actualConfig))
+			}
+		})
+	}
+}
+
+func TestConfigNodesLoadCmd(t *testing.T) {
+	const initialConfig = `signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAddr: ""
+`
+
+	tcs := []struct {
+		name         string
+		nodesFile    string
+		expectErr    string
+		expectConfig string
+	}{
+		{
+			name: "replaces chain nodes",
+			nodesFile: `# sentries
+tcp://10.168.0.2:1234
+
+  TCP://10.168.0.3:1234
+tcp://10.168.0.2:1234
+`,
+			expectConfig: `signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.2:1234
+- privValAddr: tcp://10.168.0.3:1234
+debugAddr: ""
+`,
+		},
+		{
+			name: "malformed chain node",
+			nodesFile: `tcp://10.168.0.2:1234
+# sentry 3
+://10.168.0.3:1234
+`,
+			expectErr: `line 3: invalid chain node "://10.168.0.3:1234": ` +
+				`parse "://10.168.0.3:1234": missing protocol scheme`,
+		},
+		{
+			name:      "no chain nodes",
+			nodesFile: "# no sentries yet\n",
+			expectErr: "need to have chainNodes configured for priv-val connection",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpConfig := filepath.Join(t.TempDir(), ".horcrux")
+			configFile := filepath.Join(tmpConfig, "config.yaml")
+			nodesFile := filepath.Join(tmpConfig, "nodes.txt")
+
+			require.NoError(t, os.MkdirAll(tmpConfig, 0700))
+			require.NoError(t, os.WriteFile(configFile, []byte(initialConfig), 0600))
+			require.NoError(t, os.WriteFile(nodesFile, []byte(tc.nodesFile), 0600))
+
+			cmd := rootCmd()
+			cmd.SetOutput(io.Discard)
+			cmd.SetArgs([]string{"--home", tmpConfig, "config", "nodes", "load", nodesFile})
+			err := cmd.Execute()
+
+			actualConfig, readErr := os.ReadFile(configFile)
+			require.NoError(t, readErr)
+
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				// the config file is left untouched
+				require.Equal(t, initialConfig, string(actualConfig))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectConfig, string(actualConfig))
+			}
+		})
+	}
+}
+
+func TestConfigNodesAddRemoveCmd(t *testing.T) {
+	const initialConfig = `signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+- privValAddr: tcp://10.168.0.2:1234
+debugAddr: ""
+`
+
+	tcs := []struct {
+		name         string
+		args         []string
+		expectErr    string
+		expectOutput string
+		expectConfig string
+	}{
+		{
+			name:         "add",
+			args:         []string{"add", "TCP://10.168.0.3:1234"},
+			expectOutput: "Added chain node tcp://10.168.0.3:1234",
+			expectConfig: `signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+- privValAddr: tcp://10.168.0.2:1234
+- privValAddr: tcp://10.168.0.3:1234
+debugAddr: ""
+`,
+		},
+		{
+			name:         "add duplicate",
+			args:         []string{"add", "tcp://10.168.0.2:1234"},
+			expectOutput: "Chain node tcp://10.168.0.2:1234 is already configured",
+			expectConfig: initialConfig,
+		},
+		{
+			name:      "add invalid",
+			args:      []string{"add", "://10.168.0.3:1234"},
+			expectErr: `parse "://10.168.0.3:1234": missing protocol scheme`,
+		},
+		{
+			name:         "remove",
+			args:         []string{"remove", "tcp://10.168.0.1:1234"},
+			expectOutput: "Removed chain node tcp://10.168.0.1:1234",
+			expectConfig: `signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.2:1234
+debugAddr: ""
+`,
+		},
+		{
+			name:      "remove missing",
+			args:      []string{"remove", "tcp://10.168.0.3:1234"},
+			expectErr: "chain node tcp://10.168.0.3:1234 is not configured in ",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpConfig := filepath.Join(t.TempDir(), ".horcrux")
+			configFile := filepath.Join(tmpConfig, "config.yaml")
+
+			require.NoError(t, os.MkdirAll(tmpConfig, 0700))
+			require.NoError(t, os.WriteFile(configFile, []byte(initialConfig), 0600))
+
+			var out bytes.Buffer
+			cmd := rootCmd()
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(append([]string{"--home", tmpConfig, "config", "nodes"}, tc.args...))
+			err := cmd.Execute()
+
+			actualConfig, readErr := os.ReadFile(configFile)
+			require.NoError(t, readErr)
+
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				// the config file is left untouched
+				require.Equal(t, initialConfig, string(actualConfig))
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out.String(), tc.expectOutput)
+				require.Equal(t, tc.expectConfig, string(actualConfig))
+			}
+		})
+	}
+}
+
+func TestConfigCosignersAddRemoveCmd(t *testing.T) {
+	const initialConfig = `signMode: threshold
+thresholdMode:
+  threshold: 3
+  cosigners:
+  - shardID: 1
+    p2pAddr: tcp://10.168.1.1:2222
+  - shardID: 2
+    p2pAddr: tcp://10.168.1.2:2222
+  - shardID: 3
+    p2pAddr: tcp://10.168.1.3:2222
+  - shardID: 4
+    p2pAddr: tcp://10.168.1.4:2222
+  grpcTimeout: 1500ms
+  raftTimeout: 1500ms
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAddr: ""
+`
+
+	const fiveCosignersConfig = `signMode: threshold
+thresholdMode:
+  threshold: 3
+  cosigners:
+  - shardID: 1
+    p2pAddr: tcp://10.168.1.1:2222
+  - shardID: 2
+    p2pAddr: tcp://10.168.1.2:2222
+  - shardID: 3
+    p2pAddr: tcp://10.168.1.3:2222
+  - shardID: 4
+    p2pAddr: tcp://10.168.1.4:2222
+  - shardID: 5
+    p2pAddr: tcp://10.168.1.5:2222
+  grpcTimeout: 1500ms
+  raftTimeout: 1500ms
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAddr: ""
+`
+
+	const threeCosignersConfig = `signMode: threshold
+thresholdMode:
+  threshold: 3
+  cosigners:
+  - shardID: 1
+    p2pAddr: tcp://10.168.1.1:2222
+  - shardID: 2
+    p2pAddr: tcp://10.168.1.2:2222
+  - shardID: 3
+    p2pAddr: tcp://10.168.1.3:2222
+  grpcTimeout: 1500ms
+  raftTimeout: 1500ms
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAddr: ""
+`
+
+	tcs := []struct {
+		name         string
+		args         []string
+		expectErr    string
+		expectConfig string
+	}{
+		{
+			name:         "add with shard ID",
+			args:         []string{"add", "tcp://10.168.1.5:2222|5"},
+			expectConfig: fiveCosignersConfig,
+		},
+		{
+			name:         "add shard ID from position",
+			args:         []string{"add", "tcp://10.168.1.5:2222"},
+			expectConfig: fiveCosignersConfig,
+		},
+		{
+			name:      "add existing shard ID",
+			args:      []string{"add", "tcp://10.168.1.5:2222|2"},
+			expectErr: "shard ID 2 is already configured for cosigner tcp://10.168.1.2:2222",
+		},
+		{
+			name:         "remove by address",
+			args:         []string{"remove", "tcp://10.168.1.4:2222"},
+			expectConfig: threeCosignersConfig,
+		},
+		{
+			name:         "remove by shard ID",
+			args:         []string{"remove", "4"},
+			expectConfig: threeCosignersConfig,
+		},
+		{
+			name:      "remove breaks shard IDs",
+			args:      []string{"remove", "2"},
+			expectErr: "cosigner shard ID 4 in args is out of range, must be between 1 and 3, inclusive",
+		},
+		{
+			name:      "remove missing",
+			args:      []string{"remove", "5"},
+			expectErr: "cosigner 5 is not configured in ",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpConfig := filepath.Join(t.TempDir(), ".horcrux")
+			configFile := filepath.Join(tmpConfig, "config.yaml")
+
+			require.NoError(t, os.MkdirAll(tmpConfig, 0700))
+			require.NoError(t, os.WriteFile(configFile, []byte(initialConfig), 0600))
+
+			cmd := rootCmd()
+			cmd.SetOutput(io.Discard)
+			cmd.SetArgs(append([]string{"--home", tmpConfig, "config", "cosigners"}, tc.args...))
+			err := cmd.Execute()
+
+			actualConfig, readErr := os.ReadFile(configFile)
+			require.NoError(t, readErr)
+
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				// the config file is left untouched
+				require.Equal(t, initialConfig, string(actualConfig))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectConfig, string(actualConfig))
+			}
+		})
+	}
+}
+
+func TestConfigShowCmd(t *testing.T) {
+	const initialConfig = `keyDir: /secrets/horcrux
+signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAddr: ""
+`
+
+	tcs := []struct {
+		name         string
+		args         []string
+		noConfig     bool
+		expectErr    string
+		expectOutput string
+	}{
+		{
+			name: "yaml",
+			expectOutput: `keyDir: REDACTED
+signMode: single
+chainNodes:
+- privValAddr: tcp://10.168.0.1:1234
+debugAddr: ""
+`,
+		},
+		{
+			name:         "raw",
+			args:         []string{"--raw"},
+			expectOutput: initialConfig,
+		},
+		{
+			name: "json",
+			args: []string{"--output", "json"},
+			expectOutput: `{
+  "chainNodes": [
+    {
+      "privValAddr": "tcp://10.168.0.1:1234"
+    }
+  ],
+  "debugAddr": "",
+  "keyDir": "REDACTED",
+  "signMode": "single"
+}
+`,
+		},
+		{
+			name:      "invalid output",
+			args:      []string{"--output", "toml"},
+			expectErr: `invalid output "toml", must be one of: yaml, json`,
+		},
+		{
+			name:      "no config",
+			noConfig:  true,
+			expectErr: "no config exists at ",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpConfig := filepath.Join(t.TempDir(), ".horcrux")
+
+			if !tc.noConfig {
+				require.NoError(t, os.MkdirAll(tmpConfig, 0700))
+				require.NoError(t, os.WriteFile(filepath.Join(tmpConfig, "config.yaml"), []byte(initialConfig), 0600))
+			}
+
+			var out bytes.Buffer
+			cmd := rootCmd()
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(append([]string{"--home", tmpConfig, "config", "show"}, tc.args...))
+			err := cmd.Execute()
+
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectOutput, out.String())
 			}
 		})
 	}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/horcrux/client"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"google.golang.org/grpc"
+)
+
+const flagRestarted = "restarted"
+
+func cosignerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cosigner",
+		Short: "Commands to operate the cosigners of a threshold cluster",
+	}
+
+	cmd.AddCommand(restartSequenceCmd())
+	cmd.AddCommand(cosignerAddressCmd())
+
+	return cmd
+}
+
+func restartSequenceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restart-sequence",
+		Short: "Print a safe order to restart the cosigners of the cluster in",
+		Long: `Print a safe order to restart the cosigners of the cluster in.
+Queries each cosigner in the config for its status and the current leader, and plans a restart
+of one cosigner at a time that never leaves fewer than threshold cosigners healthy, with the leader
+restarted last after transferring leadership away from it.
+Pass the shard IDs of the cosigners restarted so far with --restarted to get the next step. No further
+step is recommended until all of them are healthy again.`,
+		Example: `horcrux cosigner restart-sequence
+horcrux cosigner restart-sequence --restarted 2,3`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			thresholdCfg := config.Config.ThresholdModeConfig
+			if thresholdCfg == nil {
+				return fmt.Errorf("threshold mode configuration is not present in config file")
+			}
+
+			restartedIDs, _ := cmd.Flags().GetIntSlice(flagRestarted)
+			restarted := make(map[int]bool, len(restartedIDs))
+			for _, id := range restartedIDs {
+				restarted[id] = true
+			}
+
+			cosigners := make([]cosignerHealth, len(thresholdCfg.Cosigners))
+			for i, c := range thresholdCfg.Cosigners {
+				cosigners[i] = cosignerHealth{shardID: c.ShardID}
+				cosigners[i].raftAddr, _ = client.SanitizeAddress(c.P2PAddr)
+				cosigners[i].leader, cosigners[i].err = getCosignerHealth(cmd.Context(), c.P2PAddr)
+			}
+
+			steps, err := planRestartSequence(cosigners, thresholdCfg.Threshold, restarted)
+			if err != nil {
+				return err
+			}
+
+			printRestartSequence(cmd.OutOrStdout(), steps, restartedIDs)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntSlice(flagRestarted, nil, "shard IDs of the cosigners that have been restarted so far")
+	return cmd
+}
+
+// cosignerCredentials returns the dial option with the transport credentials of the cosigners,
+// so that commands can connect to cosigners that require mutual TLS.
+func cosignerCredentials() (grpc.DialOption, error) {
+	creds, err := config.CosignerTransportCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return grpc.WithTransportCredentials(creds), nil
+}
+
+// cosignerHealth is the health of a cosigner and the raft address of the leader it reports.
+type cosignerHealth struct {
+	shardID  int
+	raftAddr string
+	leader   string
+	err      error
+}
+
+// getCosignerHealth returns the leader reported by the cosigner, or an error if it is unreachable or not ready.
+func getCosignerHealth(ctx context.Context, p2pAddr string) (string, error) {
+	grpcAddress, err := client.SanitizeAddress(p2pAddr)
+	if err != nil {
+		return "", err
+	}
+
+	creds, err := cosignerCredentials()
+	if err != nil {
+		return "", err
+	}
+
+	conn, err := grpc.Dial(grpcAddress, creds)
+	if err != nil {
+		return "", fmt.Errorf("dialing failed: %w", err)
+	}
+	defer conn.Close()
+
+	ctx, cancelFunc := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelFunc()
+
+	grpcClient := proto.NewCosignerGRPCClient(conn)
+
+	status, err := grpcClient.GetStatus(ctx, &proto.CosignerGRPCGetStatusRequest{})
+	if err != nil {
+		return "", err
+	}
+	if !status.Ready {
+		return "", fmt.Errorf("not ready, %d bytes free in the state directory", status.StateDirFreeBytes)
+	}
+
+	res, err := grpcClient.GetLeader(ctx, &proto.CosignerGRPCGetLeaderRequest{})
+	if err != nil {
+		return "", err
+	}
+	return res.Leader, nil
+}
+
+// restartStep is a cosigner to restart, after electing electID as the leader if it is not 0.
+type restartStep struct {
+	shardID int
+	electID int
+}
+
+// planRestartSequence returns the remaining cosigners to restart, in order, one at a time.
+// It refuses to plan while a restarted cosigner is not healthy, or if restarting a cosigner would leave
+// fewer than threshold healthy cosigners. Unhealthy cosigners are restarted first since they are not
+// signing anyway, and the leader is restarted last after transferring leadership to a restarted cosigner.
+func planRestartSequence(cosigners []cosignerHealth, threshold int, restarted map[int]bool) ([]restartStep, error) {
+	sorted := append([]cosignerHealth{}, cosigners...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].shardID < sorted[j].shardID })
+
+	var (
+		healthy   int
+		leader    string
+		unhealthy []int
+		pending   []cosignerHealth
+	)
+	for _, c := range sorted {
+		if c.err != nil {
+			if restarted[c.shardID] {
+				return nil, fmt.Errorf("cosigner %d has not recovered since it was restarted (%v), "+
+					"wait until it is healthy before restarting the next cosigner", c.shardID, c.err)
+			}
+			unhealthy = append(unhealthy, c.shardID)
+			continue
+		}
+
+		healthy++
+		if leader == "" {
+			leader = c.leader
+		} else if c.leader != "" && c.leader != leader {
+			return nil, fmt.Errorf("cosigners report different leaders (%s, %s), wait for the cluster to settle",
+				leader, c.leader)
+		}
+		if !restarted[c.shardID] {
+			pending = append(pending, c)
+		}
+	}
+
+	steps := make([]restartStep, 0, len(unhealthy)+len(pending))
+	for _, id := range unhealthy {
+		steps = append(steps, restartStep{shardID: id})
+	}
+
+	// an unhealthy cosigner is restarted next, which does not reduce the number of healthy cosigners.
+	if len(unhealthy) == 0 && len(pending) > 0 && healthy-1 < threshold {
+		return nil, fmt.Errorf("restarting a healthy cosigner would leave %d of %d cosigners healthy, need at least %d",
+			healthy-1, len(cosigners), threshold)
+	}
+
+	var leaderStep *restartStep
+	for _, c := range pending {
+		if leader == "" || c.raftAddr != leader {
+			steps = append(steps, restartStep{shardID: c.shardID})
+			continue
+		}
+		leaderStep = &restartStep{shardID: c.shardID}
+	}
+
+	if leaderStep != nil {
+		// prefer a restarted cosigner as the new leader so that leadership is only transferred once.
+		for _, c := range sorted {
+			if c.shardID == leaderStep.shardID || c.err != nil {
+				continue
+			}
+			if restarted[c.shardID] {
+				leaderStep.electID = c.shardID
+				break
+			}
+			if leaderStep.electID == 0 {
+				leaderStep.electID = c.shardID
+			}
+		}
+		steps = append(steps, *leaderStep)
+	}
+
+	return steps, nil
+}
+
+func printRestartSequence(out io.Writer, steps []restartStep, restarted []int) {
+	if len(steps) == 0 {
+		fmt.Fprintln(out, "All cosigners have been restarted and are healthy.")
+		return
+	}
+
+	fmt.Fprintln(out, "Restart the cosigners one at a time, in this order:")
+	for i, s := range steps {
+		if s.electID != 0 {
+			fmt.Fprintf(out, "  %d. Transfer leadership with `horcrux elect %d`, then restart cosigner %d (the leader)\n",
+				i+1, s.electID, s.shardID)
+			continue
+		}
+		fmt.Fprintf(out, "  %d. Restart cosigner %d\n", i+1, s.shardID)
+	}
+
+	next := make([]string, 0, len(restarted)+1)
+	for _, id := range restarted {
+		next = append(next, strconv.Itoa(id))
+	}
+	next = append(next, strconv.Itoa(steps[0].shardID))
+	fmt.Fprintf(out, "After restarting cosigner %d, run `horcrux cosigner restart-sequence --restarted %s` "+
+		"and only proceed once it reports the next step.\n", steps[0].shardID, strings.Join(next, ","))
+}
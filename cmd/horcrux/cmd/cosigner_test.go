@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRestartSequence(t *testing.T) {
+	const leader = "10.168.1.1:2222"
+	healthy := func(shardID int) cosignerHealth {
+		return cosignerHealth{shardID: shardID, raftAddr: fmt.Sprintf("10.168.1.%d:2222", shardID), leader: leader}
+	}
+	unhealthy := func(shardID int) cosignerHealth {
+		c := healthy(shardID)
+		c.leader, c.err = "", fmt.Errorf("connection refused")
+		return c
+	}
+
+	tcs := []struct {
+		name        string
+		cosigners   []cosignerHealth
+		threshold   int
+		restarted   map[int]bool
+		expectSteps []restartStep
+		expectErr   string
+	}{
+		{
+			name:        "leader last",
+			cosigners:   []cosignerHealth{healthy(3), healthy(1), healthy(2)},
+			threshold:   2,
+			expectSteps: []restartStep{{shardID: 2}, {shardID: 3}, {shardID: 1, electID: 2}},
+		},
+		{
+			name:        "leadership transferred to a restarted cosigner",
+			cosigners:   []cosignerHealth{healthy(1), healthy(2), healthy(3)},
+			threshold:   2,
+			restarted:   map[int]bool{3: true},
+			expectSteps: []restartStep{{shardID: 2}, {shardID: 1, electID: 3}},
+		},
+		{
+			name:      "restarted cosigner not healthy yet",
+			cosigners: []cosignerHealth{healthy(1), unhealthy(2), healthy(3)},
+			threshold: 2,
+			restarted: map[int]bool{2: true},
+			expectErr: "cosigner 2 has not recovered since it was restarted",
+		},
+		{
+			name:        "unhealthy cosigner first",
+			cosigners:   []cosignerHealth{healthy(1), healthy(2), unhealthy(3)},
+			threshold:   2,
+			expectSteps: []restartStep{{shardID: 3}, {shardID: 2}, {shardID: 1, electID: 2}},
+		},
+		{
+			name:      "below threshold",
+			cosigners: []cosignerHealth{healthy(1), healthy(2), healthy(3)},
+			threshold: 3,
+			expectErr: "restarting a healthy cosigner would leave 2 of 3 cosigners healthy, need at least 3",
+		},
+		{
+			name:        "all restarted",
+			cosigners:   []cosignerHealth{healthy(1), healthy(2), healthy(3)},
+			threshold:   2,
+			restarted:   map[int]bool{1: true, 2: true, 3: true},
+			expectSteps: []restartStep{},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			steps, err := planRestartSequence(tc.cosigners, tc.threshold, tc.restarted)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectSteps, steps)
+		})
+	}
+}
+
+func TestPrintRestartSequence(t *testing.T) {
+	var out bytes.Buffer
+	printRestartSequence(&out, []restartStep{{shardID: 2}, {shardID: 1, electID: 3}}, []int{3})
+	require.Equal(t, "Restart the cosigners one at a time, in this order:\n"+
+		"  1. Restart cosigner 2\n"+
+		"  2. Transfer leadership with `horcrux elect 3`, then restart cosigner 1 (the leader)\n"+
+		"After restarting cosigner 2, run `horcrux cosigner restart-sequence --restarted 3,2` "+
+		"and only proceed once it reports the next step.\n", out.String())
+}
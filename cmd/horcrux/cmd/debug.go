@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	comet "github.com/cometbft/cometbft/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagHeight       = "height"
+	flagRound        = "round"
+	flagStep         = "step"
+	flagBlockID      = "block-id"
+	flagPartSetHash  = "part-set-hash"
+	flagPartSetTotal = "part-set-total"
+	flagPOLRound     = "pol-round"
+	flagTimestamp    = "timestamp"
+)
+
+func debugCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Commands for diagnosing signing issues",
+	}
+
+	cmd.AddCommand(debugSignBytesCmd())
+
+	return cmd
+}
+
+func debugSignBytesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-bytes",
+		Short: "Print the canonical sign bytes for a block in hex",
+		Long: `Print the canonical sign bytes that horcrux would sign for the provided height, round and step, in hex.
+step is 1 for a proposal, 2 for a prevote and 3 for a precommit.
+The output can be compared against the sign bytes expected by the chain node to diagnose signature mismatches.`,
+		Example: `horcrux debug sign-bytes --chain-id cosmoshub-4 --height 100 --round 0 --step 3 \
+  --block-id 5A3B... --part-set-hash 1F2E... --part-set-total 1 --timestamp 2023-06-01T00:00:00.123456789Z`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := cmd.Flags()
+
+			chainID, _ := f.GetString(flagChainID)
+			height, _ := f.GetInt64(flagHeight)
+			round, _ := f.GetInt32(flagRound)
+			step, _ := f.GetInt8(flagStep)
+			polRound, _ := f.GetInt32(flagPOLRound)
+			partSetTotal, _ := f.GetUint32(flagPartSetTotal)
+
+			blockIDFlag, _ := f.GetString(flagBlockID)
+			blockHash, err := hex.DecodeString(blockIDFlag)
+			if err != nil {
+				return fmt.Errorf("invalid %s, must be hex: %w", flagBlockID, err)
+			}
+
+			partSetHashFlag, _ := f.GetString(flagPartSetHash)
+			partSetHash, err := hex.DecodeString(partSetHashFlag)
+			if err != nil {
+				return fmt.Errorf("invalid %s, must be hex: %w", flagPartSetHash, err)
+			}
+
+			var timestamp time.Time
+			if ts, _ := f.GetString(flagTimestamp); ts != "" {
+				timestamp, err = time.Parse(time.RFC3339Nano, ts)
+				if err != nil {
+					return fmt.Errorf("invalid %s, must be RFC3339: %w", flagTimestamp, err)
+				}
+			}
+
+			blockID := cometproto.BlockID{
+				Hash: blockHash,
+				PartSetHeader: cometproto.PartSetHeader{
+					Total: partSetTotal,
+					Hash:  partSetHash,
+				},
+			}
+
+			var signBytes []byte
+			switch step {
+			case 1:
+				signBytes = comet.ProposalSignBytes(chainID, &cometproto.Proposal{
+					Type:      cometproto.ProposalType,
+					Height:    height,
+					Round:     round,
+					PolRound:  polRound,
+					BlockID:   blockID,
+					Timestamp: timestamp,
+				})
+			case 2, 3:
+				voteType := cometproto.PrevoteType
+				if step == 3 {
+					voteType = cometproto.PrecommitType
+				}
+				signBytes = comet.VoteSignBytes(chainID, &cometproto.Vote{
+					Type:      voteType,
+					Height:    height,
+					Round:     round,
+					BlockID:   blockID,
+					Timestamp: timestamp,
+				})
+			default:
+				return fmt.Errorf("invalid %s %d, must be 1 (proposal), 2 (prevote) or 3 (precommit)", flagStep, step)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), hex.EncodeToString(signBytes))
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.String(flagChainID, "", "chain ID of the block")
+	f.Int64(flagHeight, 0, "height of the block")
+	f.Int32(flagRound, 0, "consensus round")
+	f.Int8(flagStep, 0, "sign step: 1 (proposal), 2 (prevote) or 3 (precommit)")
+	f.String(flagBlockID, "", "block hash in hex, omit for a nil vote")
+	f.String(flagPartSetHash, "", "block part set header hash in hex")
+	f.Uint32(flagPartSetTotal, 0, "block part set header total")
+	f.Int32(flagPOLRound, -1, "proof of lock round, only used for proposals")
+	f.String(flagTimestamp, "", "timestamp in RFC3339 format, e.g. 2023-06-01T00:00:00.123456789Z")
+	_ = cmd.MarkFlagRequired(flagChainID)
+	_ = cmd.MarkFlagRequired(flagHeight)
+	_ = cmd.MarkFlagRequired(flagStep)
+
+	return cmd
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	comet "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugSignBytesCmd(t *testing.T) {
+	timestamp := time.Date(2023, 6, 1, 0, 0, 0, 123456789, time.UTC)
+	blockHash := bytes.Repeat([]byte{0xAB}, 32)
+	partSetHash := bytes.Repeat([]byte{0xCD}, 32)
+
+	blockID := cometproto.BlockID{
+		Hash:          blockHash,
+		PartSetHeader: cometproto.PartSetHeader{Total: 2, Hash: partSetHash},
+	}
+
+	tcs := []struct {
+		name      string
+		args      []string
+		expect    []byte
+		expectErr string
+	}{
+		{
+			name: "precommit",
+			args: []string{
+				"--chain-id", "horcrux-1",
+				"--height", "100",
+				"--round", "1",
+				"--step", "3",
+				"--block-id", hex.EncodeToString(blockHash),
+				"--part-set-hash", hex.EncodeToString(partSetHash),
+				"--part-set-total", "2",
+				"--timestamp", timestamp.Format(time.RFC3339Nano),
+			},
+			expect: comet.VoteSignBytes("horcrux-1", &cometproto.Vote{
+				Type:      cometproto.PrecommitType,
+				Height:    100,
+				Round:     1,
+				BlockID:   blockID,
+				Timestamp: timestamp,
+			}),
+		},
+		{
+			name: "nil prevote",
+			args: []string{
+				"--chain-id", "horcrux-1",
+				"--height", "100",
+				"--step", "2",
+			},
+			expect: comet.VoteSignBytes("horcrux-1", &cometproto.Vote{
+				Type:   cometproto.PrevoteType,
+				Height: 100,
+			}),
+		},
+		{
+			name: "proposal",
+			args: []string{
+				"--chain-id", "horcrux-1",
+				"--height", "100",
+				"--step", "1",
+				"--block-id", hex.EncodeToString(blockHash),
+				"--part-set-hash", hex.EncodeToString(partSetHash),
+				"--part-set-total", "2",
+				"--timestamp", timestamp.Format(time.RFC3339Nano),
+			},
+			expect: comet.ProposalSignBytes("horcrux-1", &cometproto.Proposal{
+				Type:      cometproto.ProposalType,
+				Height:    100,
+				PolRound:  -1,
+				BlockID:   blockID,
+				Timestamp: timestamp,
+			}),
+		},
+		{
+			name: "invalid step",
+			args: []string{
+				"--chain-id", "horcrux-1",
+				"--height", "100",
+				"--step", "4",
+			},
+			expectErr: "invalid step 4, must be 1 (proposal), 2 (prevote) or 3 (precommit)",
+		},
+		{
+			name: "invalid block id",
+			args: []string{
+				"--chain-id", "horcrux-1",
+				"--height", "100",
+				"--step", "2",
+				"--block-id", "xyz",
+			},
+			expectErr: "invalid block-id, must be hex: encoding/hex: invalid byte: U+0078 'x'",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := rootCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(append([]string{"--home", t.TempDir(), "debug", "sign-bytes"}, tc.args...))
+			err := cmd.Execute()
+
+			if tc.expectErr != "" {
+				require.EqualError(t, err, tc.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, hex.EncodeToString(tc.expect), strings.TrimSpace(out.String()))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/horcrux/client"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"google.golang.org/grpc"
+)
+
+const (
+	flagID       = "id"
+	flagDuration = "duration"
+)
+
+func drillCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drill",
+		Short: "Commands for resilience drills of a running cosigner cluster",
+		Long: `Commands for resilience drills of a running cosigner cluster.
+drills must be enabled on the targeted cosigners with thresholdMode.enableDrills in their config.`,
+	}
+
+	cmd.AddCommand(drillKillCosignerCmd())
+
+	return cmd
+}
+
+func drillKillCosignerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kill-cosigner",
+		Short: "Take a cosigner down for a duration to verify the cluster keeps signing",
+		Long: `Take a cosigner down for a duration to verify the cluster keeps signing and alerts fire.
+While down, every gRPC request to and from the cosigner fails, including raft and read-only requests,
+as if the cosigner process was stopped. Leadership is transferred off the cosigner first if it is the leader.
+The cosigner recovers automatically after the duration.`,
+		Example:      `horcrux drill kill-cosigner --id 2 --duration 60s`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			thresholdCfg := config.Config.ThresholdModeConfig
+			if thresholdCfg == nil {
+				return fmt.Errorf("threshold mode configuration is not present in config file")
+			}
+
+			id, _ := cmd.Flags().GetInt(flagID)
+			duration, _ := cmd.Flags().GetDuration(flagDuration)
+
+			var p2pAddr string
+			for _, c := range thresholdCfg.Cosigners {
+				if c.ShardID == id {
+					p2pAddr = c.P2PAddr
+				}
+			}
+			if p2pAddr == "" {
+				return fmt.Errorf("cosigner with shard ID %d is not present in config file", id)
+			}
+
+			grpcAddress, err := client.SanitizeAddress(p2pAddr)
+			if err != nil {
+				return err
+			}
+
+			creds, err := cosignerCredentials()
+			if err != nil {
+				return err
+			}
+
+			conn, err := grpc.Dial(grpcAddress, creds)
+			if err != nil {
+				return fmt.Errorf("dialing failed: %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancelFunc := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancelFunc()
+
+			res, err := proto.NewCosignerGRPCClient(conn).DrillKill(ctx, &proto.CosignerGRPCDrillKillRequest{
+				Duration: int64(duration),
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Cosigner %d is down until %s\n",
+				id, time.Unix(0, res.Until).Format(time.RFC3339))
+
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.Int(flagID, 0, "shard ID of the cosigner to take down")
+	f.Duration(flagDuration, time.Minute, "how long the cosigner stays down")
+	_ = cmd.MarkFlagRequired(flagID)
+
+	return cmd
+}
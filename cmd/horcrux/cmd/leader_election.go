@@ -2,9 +2,14 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
 	"time"
 
+	cometlog "github.com/cometbft/cometbft/libs/log"
 	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"github.com/spf13/cobra"
 	"github.com/strangelove-ventures/horcrux/client"
@@ -12,7 +17,6 @@ import (
 	"github.com/strangelove-ventures/horcrux/signer/multiresolver"
 	"github.com/strangelove-ventures/horcrux/signer/proto"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 func init() {
@@ -24,7 +28,9 @@ func leaderElectionCmd() *cobra.Command {
 		Use:   "elect [node_id]",
 		Short: "Elect new raft leader",
 		Long: `To choose the next eligible leader, pass no argument.
-To choose a specific leader, pass that leader's ID as an argument.
+To choose a specific leader, pass that leader's shard ID as an argument.
+
+Use this to move leadership off a cosigner before restarting it for maintenance.
 `,
 		Args: cobra.RangeArgs(0, 1),
 		Example: `horcrux elect # elect next eligible leader
@@ -39,38 +45,24 @@ horcrux elect 2 # elect specific leader`,
 				return fmt.Errorf("threshold mode configuration has no cosigners")
 			}
 
-			serviceConfig := `{"healthCheckConfig": {"serviceName": "Leader"}, "loadBalancingConfig": [ { "round_robin": {} } ]}`
-			retryOpts := []grpcretry.CallOption{
-				grpcretry.WithBackoff(grpcretry.BackoffExponential(100 * time.Millisecond)),
-				grpcretry.WithMax(5),
-			}
-
-			grpcAddress, err := config.Config.ThresholdModeConfig.LeaderElectMultiAddress()
+			leaderID, err := electLeaderID(config.Config.ThresholdModeConfig.Cosigners, args)
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Broadcasting to address: %s\n", grpcAddress)
-			conn, err := grpc.Dial(grpcAddress,
-				grpc.WithDefaultServiceConfig(serviceConfig), grpc.WithTransportCredentials(insecure.NewCredentials()),
-				grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
-				grpc.WithUnaryInterceptor(grpcretry.UnaryClientInterceptor(retryOpts...)))
+			logger := newLogger(cmd.OutOrStdout(), "elect")
+
+			conn, err := dialLeader(logger)
 			if err != nil {
-				return fmt.Errorf("dialing failed: %v", err)
+				return err
 			}
 			defer conn.Close()
 
-			leaderID := ""
-
-			if len(args) > 0 {
-				leaderID = args[0]
-			}
-
 			ctx, cancelFunc := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancelFunc()
 
 			grpcClient := proto.NewCosignerGRPCClient(conn)
-			_, err = grpcClient.TransferLeadership(
+			res, err := grpcClient.TransferLeadership(
 				ctx,
 				&proto.CosignerGRPCTransferLeadershipRequest{LeaderID: leaderID},
 			)
@@ -78,24 +70,91 @@ horcrux elect 2 # elect specific leader`,
 				return err
 			}
 
-			res, err := grpcClient.GetLeader(ctx, &proto.CosignerGRPCGetLeaderRequest{})
+			if res.LeaderID != "" {
+				logger.Info("Leader election successful", "new_leader", res.LeaderID, "address", res.LeaderAddress)
+				return nil
+			}
+
+			// the leader picks the next candidate itself, it is only known once it has taken over.
+			leaderRes, err := grpcClient.GetLeader(ctx, &proto.CosignerGRPCGetLeaderRequest{})
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Leader election successful. New leader: %s\n", res.Leader)
+			logger.Info("Leader election successful", "new_leader", leaderRes.Leader)
 
 			return nil
 		},
 	}
 }
 
+// dialLeader connects to the raft leader, using the health check of the cosigners to find it.
+// Requests are retried while no cosigner reports being the leader.
+func dialLeader(logger cometlog.Logger) (*grpc.ClientConn, error) {
+	serviceConfig := `{"healthCheckConfig": {"serviceName": "Leader"}, "loadBalancingConfig": [ { "round_robin": {} } ]}`
+	retryOpts := []grpcretry.CallOption{
+		grpcretry.WithBackoff(grpcretry.BackoffExponential(100 * time.Millisecond)),
+		grpcretry.WithMax(5),
+	}
+
+	grpcAddress, err := config.Config.ThresholdModeConfig.LeaderElectMultiAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := cosignerCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Broadcasting to cosigners", "address", grpcAddress)
+	conn, err := grpc.Dial(grpcAddress,
+		grpc.WithDefaultServiceConfig(serviceConfig), creds,
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+		grpc.WithUnaryInterceptor(grpcretry.UnaryClientInterceptor(retryOpts...)))
+	if err != nil {
+		return nil, fmt.Errorf("dialing failed: %v", err)
+	}
+	return conn, nil
+}
+
+// electLeaderID returns the raft server ID of the cosigner to elect from the arguments of the elect command,
+// or an empty ID to elect the next eligible leader.
+func electLeaderID(cosigners signer.CosignersConfig, args []string) (string, error) {
+	if len(args) == 0 {
+		return "", nil
+	}
+
+	shardID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return "", fmt.Errorf("invalid shard ID %q: %w", args[0], err)
+	}
+
+	for _, c := range cosigners {
+		if c.ShardID == shardID {
+			return string(signer.RaftServerID(shardID)), nil
+		}
+	}
+
+	return "", fmt.Errorf("cosigner with shard ID %d is not in the threshold mode configuration", shardID)
+}
+
+const (
+	flagAddress = "address"
+
+	// exitCodeNoLeader is the exit code of horcrux leader when the cluster has no leader.
+	exitCodeNoLeader = 2
+)
+
 func getLeaderCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:          "leader",
-		Short:        "Get current raft leader",
-		Args:         cobra.NoArgs,
-		Example:      `horcrux leader`,
+	cmd := &cobra.Command{
+		Use:   "leader",
+		Short: "Get current raft leader",
+		Long: `Get the current raft leader as seen by a cosigner, this cosigner by default.
+Exits with code 2 if no leader is elected.`,
+		Args: cobra.NoArgs,
+		Example: `horcrux leader
+horcrux leader --address tcp://10.168.1.2:2222 --output json`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			thresholdCfg := config.Config.ThresholdModeConfig
@@ -107,55 +166,38 @@ func getLeaderCmd() *cobra.Command {
 				return fmt.Errorf("threshold mode configuration has no cosigners")
 			}
 
-			var id int
-
-			keyFileECIES, err := config.KeyFileExistsCosignerECIES()
-			if err != nil {
-				keyFileRSA, err := config.KeyFileExistsCosignerRSA()
-				if err != nil {
-					return fmt.Errorf("cosigner encryption keys not found (%s) - (%s): %w", keyFileECIES, keyFileRSA, err)
-				}
-
-				key, err := signer.LoadCosignerRSAKey(keyFileRSA)
-				if err != nil {
-					return fmt.Errorf("error reading cosigner key (%s): %w", keyFileRSA, err)
-				}
-
-				id = key.ID
-			} else {
-				key, err := signer.LoadCosignerECIESKey(keyFileECIES)
-				if err != nil {
-					return fmt.Errorf("error reading cosigner key (%s): %w", keyFileECIES, err)
-				}
-
-				id = key.ID
+			output, _ := cmd.Flags().GetString(flagOutput)
+			if output != outputText && output != outputJSON {
+				return fmt.Errorf("invalid %s %q, must be one of: %s, %s", flagOutput, output, outputText, outputJSON)
 			}
 
-			var p2pListen string
-
-			for _, c := range thresholdCfg.Cosigners {
-				if c.ShardID == id {
-					p2pListen = c.P2PAddr
+			p2pAddr, _ := cmd.Flags().GetString(flagAddress)
+			if p2pAddr == "" {
+				if p2pAddr, err = localCosignerP2PAddr(thresholdCfg.Cosigners); err != nil {
+					return err
 				}
 			}
 
-			if p2pListen == "" {
-				return fmt.Errorf("cosigner config does not exist for our shard ID %d", id)
-			}
-
 			retryOpts := []grpcretry.CallOption{
 				grpcretry.WithBackoff(grpcretry.BackoffExponential(100 * time.Millisecond)),
 				grpcretry.WithMax(5),
 			}
 
-			grpcAddress, err := client.SanitizeAddress(p2pListen)
+			grpcAddress, err := client.SanitizeAddress(p2pAddr)
+			if err != nil {
+				return err
+			}
+
+			creds, err := cosignerCredentials()
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Request address: %s\n", grpcAddress)
+			if output == outputText {
+				fmt.Fprintf(cmd.OutOrStdout(), "Request address: %s\n", grpcAddress)
+			}
 			conn, err := grpc.Dial(grpcAddress,
-				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				creds,
 				grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
 				grpc.WithUnaryInterceptor(grpcretry.UnaryClientInterceptor(retryOpts...)))
 			if err != nil {
@@ -173,10 +215,87 @@ func getLeaderCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Printf("Current leader: %s\n", res.Leader)
-
-			return nil
+			return printLeader(cmd.OutOrStdout(), output, resolveLeader(thresholdCfg.Cosigners, res.Leader))
 		},
 	}
 
+	f := cmd.Flags()
+	f.String(flagAddress, "", "p2p address of the cosigner to ask, e.g. tcp://10.168.1.2:2222 (default this cosigner)")
+	f.StringP(flagOutput, "o", outputText, "output format, text or json")
+
+	return cmd
+}
+
+// localCosignerP2PAddr returns the p2p address of this cosigner, identified by its cosigner key.
+func localCosignerP2PAddr(cosigners signer.CosignersConfig) (string, error) {
+	var id int
+
+	keyFileECIES, err := config.KeyFileExistsCosignerECIES()
+	if err != nil {
+		keyFileRSA, err := config.KeyFileExistsCosignerRSA()
+		if err != nil {
+			return "", fmt.Errorf("cosigner encryption keys not found (%s) - (%s): %w", keyFileECIES, keyFileRSA, err)
+		}
+
+		key, err := signer.LoadCosignerRSAKey(keyFileRSA)
+		if err != nil {
+			return "", fmt.Errorf("error reading cosigner key (%s): %w", keyFileRSA, err)
+		}
+
+		id = key.ID
+	} else {
+		key, err := signer.LoadCosignerECIESKey(keyFileECIES)
+		if err != nil {
+			return "", fmt.Errorf("error reading cosigner key (%s): %w", keyFileECIES, err)
+		}
+
+		id = key.ID
+	}
+
+	for _, c := range cosigners {
+		if c.ShardID == id {
+			return c.P2PAddr, nil
+		}
+	}
+
+	return "", fmt.Errorf("cosigner config does not exist for our shard ID %d", id)
+}
+
+// raftLeader is the raft leader reported by horcrux leader.
+type raftLeader struct {
+	// ShardID is 0 if the address is not one of the configured cosigners.
+	ShardID int    `json:"shardID,omitempty"`
+	Address string `json:"address"`
+}
+
+// resolveLeader resolves the shard ID of the leader from its raft address.
+func resolveLeader(cosigners signer.CosignersConfig, address string) raftLeader {
+	leader := raftLeader{Address: address}
+	if address == "" {
+		return leader
+	}
+	for _, c := range cosigners {
+		if addr, err := client.SanitizeAddress(c.P2PAddr); err == nil && addr == address {
+			leader.ShardID = c.ShardID
+			break
+		}
+	}
+	return leader
+}
+
+func printLeader(out io.Writer, output string, leader raftLeader) error {
+	if leader.Address == "" {
+		return &exitCodeError{code: exitCodeNoLeader, err: errors.New("no leader elected")}
+	}
+
+	if output == outputJSON {
+		return json.NewEncoder(out).Encode(leader)
+	}
+
+	if leader.ShardID == 0 {
+		fmt.Fprintf(out, "Current leader: %s\n", leader.Address)
+		return nil
+	}
+	fmt.Fprintf(out, "Current leader: %d - Address: %s\n", leader.ShardID, leader.Address)
+	return nil
 }
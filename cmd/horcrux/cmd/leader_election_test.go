@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/horcrux/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElectLeaderID(t *testing.T) {
+	cosigners := signer.CosignersConfig{
+		{ShardID: 1, P2PAddr: "tcp://cosigner-1:2222"},
+		{ShardID: 2, P2PAddr: "tcp://cosigner-2:2222"},
+		{ShardID: 3, P2PAddr: "tcp://cosigner-3:2222"},
+	}
+
+	tcs := []struct {
+		name   string
+		args   []string
+		expect string
+		errMsg string
+	}{
+		{name: "next candidate", args: nil, expect: ""},
+		{name: "shard ID", args: []string{"2"}, expect: "2"},
+		{name: "not a number", args: []string{"cosigner-2"}, errMsg: `invalid shard ID "cosigner-2"`},
+		{
+			name:   "unknown shard ID",
+			args:   []string{"4"},
+			errMsg: "cosigner with shard ID 4 is not in the threshold mode configuration",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			leaderID, err := electLeaderID(cosigners, tc.args)
+			if tc.errMsg != "" {
+				require.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, leaderID)
+		})
+	}
+}
+
+func TestPrintLeader(t *testing.T) {
+	cosigners := signer.CosignersConfig{
+		{ShardID: 1, P2PAddr: "tcp://cosigner-1:2222"},
+		{ShardID: 2, P2PAddr: "tcp://cosigner-2:2222"},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, printLeader(&out, outputText, resolveLeader(cosigners, "cosigner-2:2222")))
+	require.Equal(t, "Current leader: 2 - Address: cosigner-2:2222\n", out.String())
+
+	out.Reset()
+	require.NoError(t, printLeader(&out, outputJSON, resolveLeader(cosigners, "cosigner-2:2222")))
+	require.JSONEq(t, `{"shardID":2,"address":"cosigner-2:2222"}`, out.String())
+
+	// a leader that is not in our config is still reported.
+	out.Reset()
+	require.NoError(t, printLeader(&out, outputJSON, resolveLeader(cosigners, "cosigner-9:2222")))
+	require.JSONEq(t, `{"address":"cosigner-9:2222"}`, out.String())
+
+	out.Reset()
+	err := printLeader(&out, outputJSON, resolveLeader(cosigners, ""))
+	require.EqualError(t, err, "no leader elected")
+	var exitErr *exitCodeError
+	require.True(t, errors.As(err, &exitErr))
+	require.Equal(t, exitCodeNoLeader, exitErr.code)
+	require.Empty(t, out.String())
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+)
+
+const (
+	flagLogFormat = "log-format"
+	flagLogLevel  = "log-level"
+
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// logFormat and logLevel are set by the --log-format and --log-level flags of the root command.
+var (
+	logFormat = logFormatText
+	logLevel  = "info"
+)
+
+// validateLogFlags returns an error if the --log-format or --log-level flag is invalid.
+func validateLogFlags() error {
+	switch logFormat {
+	case logFormatText, logFormatJSON:
+	default:
+		return fmt.Errorf("invalid --%s %q, must be %s or %s", flagLogFormat, logFormat, logFormatText, logFormatJSON)
+	}
+	if _, err := cometlog.AllowLevel(logLevel); err != nil {
+		return fmt.Errorf("invalid --%s: %w", flagLogLevel, err)
+	}
+	return nil
+}
+
+// newLogger returns a logger for the module writing to out in the format and at the level of the flags.
+func newLogger(out io.Writer, module string) cometlog.Logger {
+	var logger cometlog.Logger
+	if logFormat == logFormatJSON {
+		logger = cometlog.NewTMJSONLogger(cometlog.NewSyncWriter(out))
+	} else {
+		logger = cometlog.NewTMLogger(cometlog.NewSyncWriter(out))
+	}
+
+	// Validated prior in validateLogFlags
+	allowed, err := cometlog.AllowLevel(logLevel)
+	if err != nil {
+		allowed = cometlog.AllowInfo()
+	}
+
+	return cometlog.NewFilter(logger, allowed).With("module", module)
+}
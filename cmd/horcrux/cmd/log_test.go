@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogger(t *testing.T) {
+	t.Cleanup(func() { logFormat, logLevel = logFormatText, "info" })
+
+	logFormat, logLevel = logFormatJSON, "info"
+	var buf bytes.Buffer
+	logger := newLogger(&buf, "validator")
+	logger.Debug("Filtered out")
+	logger.Error("Failed to sign", "chain_id", testChainID, "height", 10)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "error", entry["level"])
+	require.Equal(t, "Failed to sign", entry["_msg"])
+	require.Equal(t, "validator", entry["module"])
+	require.Equal(t, testChainID, entry["chain_id"])
+
+	logFormat, logLevel = logFormatText, "debug"
+	buf.Reset()
+	newLogger(&buf, "validator").Debug("Shown", "chain_id", testChainID)
+	require.Contains(t, buf.String(), "Shown")
+	require.Contains(t, buf.String(), "chain_id="+testChainID)
+}
+
+func TestLogFlags(t *testing.T) {
+	t.Cleanup(func() { logFormat, logLevel = logFormatText, "info" })
+
+	for _, args := range [][]string{
+		{"--log-format", "xml"},
+		{"--log-level", "trace"},
+	} {
+		cmd := rootCmd()
+		cmd.SetOutput(io.Discard)
+		cmd.SetArgs(append([]string{"version", "--home", t.TempDir()}, args...))
+		require.ErrorContains(t, cmd.Execute(), "invalid --"+args[0][2:])
+	}
+
+	cmd := rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{"version", "--home", t.TempDir(), "--log-format", "json", "--log-level", "error"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, logFormatJSON, logFormat)
+}
@@ -2,42 +2,276 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/pprof"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/armon/go-metrics"
 	gmprometheus "github.com/armon/go-metrics/prometheus"
 	cometlog "github.com/cometbft/cometbft/libs/log"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/horcrux/signer"
 )
 
-func AddPrometheusMetrics(mux *http.ServeMux, out io.Writer) {
-	logger := cometlog.NewTMLogger(cometlog.NewSyncWriter(out)).With("module", "metrics")
+const (
+	flagOutput = "output"
+	flagAll    = "all"
 
-	// Add metrics from raft's implementation of go-metrics
-	cfg := gmprometheus.DefaultPrometheusOpts
-	sink, err := gmprometheus.NewPrometheusSinkFrom(cfg)
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// horcruxMetricPrefixes are the prefixes of the signer metrics and of the raft metrics emitted through go-metrics.
+var horcruxMetricPrefixes = []string{"signer_", "horcrux_"}
+
+func metricsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Commands to inspect the metrics of a running horcrux signer",
+	}
+
+	cmd.AddCommand(metricsDumpCmd())
+
+	return cmd
+}
+
+func metricsDumpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Print a snapshot of the current metrics of the running horcrux signer",
+		Long: `Print a snapshot of the current metrics of the running horcrux signer.
+The metrics are read from the prometheus endpoint of the debug server at the configured debugAddr,
+so the debug server must be enabled and the metrics backend must be prometheus.
+Only the horcrux signer and raft metrics are printed, unless --all is provided.`,
+		Example: `horcrux metrics dump
+horcrux metrics dump --output json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(flagOutput)
+			if output != outputText && output != outputJSON {
+				return fmt.Errorf("invalid %s %q, must be one of: %s, %s", flagOutput, output, outputText, outputJSON)
+			}
+
+			if config.Config.MetricsBackend == signer.MetricsBackendStatsd {
+				return fmt.Errorf("metrics are emitted to statsd, they are not available from the debug server")
+			}
+
+			url, err := metricsURL(config.Config.DebugAddr)
+			if err != nil {
+				return err
+			}
+
+			families, err := fetchMetrics(cmd.Context(), url)
+			if err != nil {
+				return err
+			}
+
+			all, _ := cmd.Flags().GetBool(flagAll)
+			samples := flattenMetrics(families, all)
+
+			out := cmd.OutOrStdout()
+			if output == outputJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(samples)
+			}
+			for _, s := range samples {
+				fmt.Fprintln(out, s)
+			}
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringP(flagOutput, "o", outputText, "output format, text or json")
+	f.Bool(flagAll, false, "include the go runtime and process metrics")
+
+	return cmd
+}
+
+// metricsURL returns the URL of the prometheus endpoint of the debug server listening on debugAddr.
+func metricsURL(debugAddr string) (string, error) {
+	if debugAddr == "" {
+		return "", fmt.Errorf("debugAddr is not configured, the debug server is disabled")
+	}
+	host, port, err := net.SplitHostPort(debugAddr)
 	if err != nil {
-		logger.Error("Could not configure Raft Metrics")
-		panic(err)
+		return "", fmt.Errorf("invalid debugAddr (%s): %w", debugAddr, err)
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
 	}
-	_, err = metrics.NewGlobal(metrics.DefaultConfig("horcrux"), sink)
+	return fmt.Sprintf("http://%s/metrics", net.JoinHostPort(host, port)), nil
+}
+
+func fetchMetrics(ctx context.Context, url string) (map[string]*dto.MetricFamily, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		logger.Error("Could not add Raft Metrics")
-		panic(err)
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics, is horcrux running?: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get metrics from %s: %s", url, res.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	return families, nil
+}
+
+// metricSample is a single value of a metric. Summaries and histograms are
+// flattened into their quantiles, sum and count, as in the prometheus text format.
+type metricSample struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Value  float64           `json:"value"`
+}
+
+func (s metricSample) String() string {
+	if len(s.Labels) == 0 {
+		return fmt.Sprintf("%s %g", s.Name, s.Value)
+	}
+	labels := make([]string, 0, len(s.Labels))
+	for k, v := range s.Labels {
+		labels = append(labels, fmt.Sprintf("%s=%q", k, v))
 	}
+	sort.Strings(labels)
+	return fmt.Sprintf("%s{%s} %g", s.Name, strings.Join(labels, ","), s.Value)
+}
+
+func isHorcruxMetric(name string) bool {
+	for _, prefix := range horcruxMetricPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenMetrics returns the samples of the metric families sorted by name.
+// Only the horcrux metrics are included, unless all is true.
+func flattenMetrics(families map[string]*dto.MetricFamily, all bool) (samples []metricSample) {
+	names := make([]string, 0, len(families))
+	for name := range families {
+		if all || isHorcruxMetric(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, m := range families[name].Metric {
+			labels := make(map[string]string, len(m.Label))
+			for _, l := range m.Label {
+				labels[l.GetName()] = l.GetValue()
+			}
+			sample := func(suffix string, value float64, extraLabels ...string) {
+				sampleLabels := labels
+				if len(extraLabels) > 0 {
+					sampleLabels = make(map[string]string, len(labels)+1)
+					for k, v := range labels {
+						sampleLabels[k] = v
+					}
+					sampleLabels[extraLabels[0]] = extraLabels[1]
+				}
+				samples = append(samples, metricSample{Name: name + suffix, Labels: sampleLabels, Value: value})
+			}
+
+			switch {
+			case m.Counter != nil:
+				sample("", m.Counter.GetValue())
+			case m.Gauge != nil:
+				sample("", m.Gauge.GetValue())
+			case m.Untyped != nil:
+				sample("", m.Untyped.GetValue())
+			case m.Summary != nil:
+				for _, q := range m.Summary.Quantile {
+					sample("", q.GetValue(), "quantile", fmt.Sprint(q.GetQuantile()))
+				}
+				sample("_sum", m.Summary.GetSampleSum())
+				sample("_count", float64(m.Summary.GetSampleCount()))
+			case m.Histogram != nil:
+				for _, b := range m.Histogram.Bucket {
+					sample("_bucket", float64(b.GetCumulativeCount()), "le", fmt.Sprint(b.GetUpperBound()))
+				}
+				sample("_sum", m.Histogram.GetSampleSum())
+				sample("_count", float64(m.Histogram.GetSampleCount()))
+			}
+		}
+	}
+
+	return samples
+}
+
+// raftPrometheusMetrics registers the raft metrics once, for both the debug server and the metrics server.
+var raftPrometheusMetrics sync.Once
+
+func AddPrometheusMetrics(mux *http.ServeMux, out io.Writer, addr string) {
+	logger := newLogger(out, "metrics")
+
+	raftPrometheusMetrics.Do(func() {
+		// Add metrics from raft's implementation of go-metrics
+		cfg := gmprometheus.DefaultPrometheusOpts
+		sink, err := gmprometheus.NewPrometheusSinkFrom(cfg)
+		if err != nil {
+			logger.Error("Could not configure Raft Metrics")
+			panic(err)
+		}
+		_, err = metrics.NewGlobal(metrics.DefaultConfig("horcrux"), sink)
+		if err != nil {
+			logger.Error("Could not add Raft Metrics")
+			panic(err)
+		}
+	})
 
 	mux.Handle("/metrics", promhttp.Handler())
-	logger.Info("Prometheus Metrics Listening", "address", config.Config.DebugAddr, "path", "/metrics")
+	logger.Info("Prometheus Metrics Listening", "address", addr, "path", "/metrics")
+}
+
+// EnableStatsdMetrics emits the signer and raft metrics to the configured statsd server.
+func EnableStatsdMetrics(out io.Writer) error {
+	logger := newLogger(out, "metrics")
+
+	sink, err := signer.EnableStatsdMetrics(config.Config.StatsdAddr)
+	if err != nil {
+		return err
+	}
+
+	// Add metrics from raft's implementation of go-metrics
+	if _, err = metrics.NewGlobal(metrics.DefaultConfig("horcrux"), sink); err != nil {
+		return fmt.Errorf("could not add raft metrics: %w", err)
+	}
+
+	logger.Info("StatsD Metrics Enabled", "address", config.Config.StatsdAddr)
+	return nil
 }
 
 // EnableDebugAndMetrics - Initialization errors are not fatal, only logged
 func EnableDebugAndMetrics(ctx context.Context, out io.Writer) {
-	logger := cometlog.NewTMLogger(cometlog.NewSyncWriter(out)).With("module", "debugserver")
+	logger := newLogger(out, "debugserver")
 
 	// Configure Shared Debug HTTP Server for pprof and prometheus
 	if len(config.Config.DebugAddr) == 0 {
@@ -58,42 +292,59 @@ func EnableDebugAndMetrics(ctx context.Context, out io.Writer) {
 	// so operators don't see a mysterious 404 page.
 	mux.Handle("/", http.RedirectHandler("/debug/pprof", http.StatusSeeOther))
 
-	// Add prometheus metrics
-	AddPrometheusMetrics(mux, out)
+	// Add prometheus metrics, unless they are emitted to statsd
+	if config.Config.MetricsBackend != signer.MetricsBackendStatsd {
+		AddPrometheusMetrics(mux, out, config.Config.DebugAddr)
+	}
+
+	serveUntilDone(ctx, logger, "Debug", newHTTPServer(mux, config.Config.DebugAddr))
+}
+
+// EnableMetricsServer serves only the prometheus metrics on metricsAddr, separately from the debug server,
+// so that they can be scraped without exposing pprof.
+func EnableMetricsServer(ctx context.Context, out io.Writer, metricsAddr string) {
+	logger := newLogger(out, "metricsserver")
+	logger.Info("Metrics Server Listening", "address", metricsAddr)
+
+	mux := http.NewServeMux()
+	AddPrometheusMetrics(mux, out, metricsAddr)
+
+	serveUntilDone(ctx, logger, "Metrics", newHTTPServer(mux, metricsAddr))
+}
 
-	// Configure Debug Server Network Parameters
-	srv := &http.Server{
-		Handler:           mux,
-		Addr:              config.Config.DebugAddr,
+func newHTTPServer(handler http.Handler, addr string) *http.Server {
+	return &http.Server{
+		Handler:           handler,
+		Addr:              addr,
 		ReadTimeout:       1 * time.Second,
 		WriteTimeout:      30 * time.Second,
 		IdleTimeout:       30 * time.Second,
 		ReadHeaderTimeout: 2 * time.Second,
 	}
+}
 
-	// Start Debug Server.
+// serveUntilDone starts the server, and shuts it down once ctx is done.
+func serveUntilDone(ctx context.Context, logger cometlog.Logger, name string, srv *http.Server) {
 	go func() {
 		if err := srv.ListenAndServe(); err != nil {
 			if errors.Is(err, http.ErrServerClosed) {
-				logger.Info("Debug Server Shutdown Complete")
+				logger.Info(name + " Server Shutdown Complete")
 				return
 			}
-			logger.Error(fmt.Sprintf("Debug Endpoint failed to start: %+v", err))
+			logger.Error(fmt.Sprintf("%s Endpoint failed to start: %+v", name, err))
 			panic(err)
 		}
 	}()
 
-	// Shutdown Debug Server on ctx request
 	go func() {
 		<-ctx.Done()
-		logger.Info("Gracefully Stopping Debug Server")
+		logger.Info("Gracefully Stopping " + name + " Server")
 		if err := srv.Shutdown(context.Background()); err != nil {
-			logger.Error("Error in Stopping Debug Server", err)
-			logger.Info("Force Stopping Debug Server")
+			logger.Error("Error in Stopping "+name+" Server", err)
+			logger.Info("Force Stopping " + name + " Server")
 			if err = srv.Close(); err != nil {
-				logger.Error("Error in Force Stopping Debug Server", err)
+				logger.Error("Error in Force Stopping "+name+" Server", err)
 			}
 		}
 	}()
-
 }
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMetrics = `# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 42
+# HELP signer_total_precommits_signed Total Precommit Signed
+# TYPE signer_total_precommits_signed counter
+signer_total_precommits_signed 10
+# HELP signer_cosigner_sign_lag_seconds Time taken to get cosigner signature
+# TYPE signer_cosigner_sign_lag_seconds summary
+signer_cosigner_sign_lag_seconds{peerid="2",quantile="0.5"} 0.01
+signer_cosigner_sign_lag_seconds_sum{peerid="2"} 0.1
+signer_cosigner_sign_lag_seconds_count{peerid="2"} 8
+`
+
+func TestMetricsDumpCmd(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/metrics", r.URL.Path)
+		fmt.Fprint(w, testMetrics)
+	}))
+	defer srv.Close()
+
+	tmpConfig := filepath.Join(t.TempDir(), ".horcrux")
+
+	cmd := rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{
+		"--home", tmpConfig,
+		"config", "init",
+		"-m", "single",
+		"-n", "tcp://10.168.0.1:1234",
+		"-d", strings.TrimPrefix(srv.URL, "http://"),
+	})
+	require.NoError(t, cmd.Execute())
+
+	dump := func(args ...string) string {
+		var out bytes.Buffer
+		cmd := rootCmd()
+		cmd.SetOut(&out)
+		cmd.SetArgs(append([]string{"--home", tmpConfig, "metrics", "dump"}, args...))
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	require.Equal(t, `signer_cosigner_sign_lag_seconds{peerid="2",quantile="0.5"} 0.01
+signer_cosigner_sign_lag_seconds_sum{peerid="2"} 0.1
+signer_cosigner_sign_lag_seconds_count{peerid="2"} 8
+signer_total_precommits_signed 10
+`, dump())
+
+	require.Contains(t, dump("--all"), "go_goroutines 42\n")
+
+	var samples []metricSample
+	require.NoError(t, json.Unmarshal([]byte(dump("-o", "json")), &samples))
+	require.Len(t, samples, 4)
+	require.Equal(t, metricSample{Name: "signer_total_precommits_signed", Value: 10}, samples[3])
+	require.Equal(t, map[string]string{"peerid": "2", "quantile": "0.5"}, samples[0].Labels)
+}
+
+func TestMetricsURL(t *testing.T) {
+	for addr, expect := range map[string]string{
+		"localhost:8543":  "http://localhost:8543/metrics",
+		":8543":           "http://localhost:8543/metrics",
+		"0.0.0.0:8543":    "http://localhost:8543/metrics",
+		"10.168.0.1:8543": "http://10.168.0.1:8543/metrics",
+		"[::]:8543":       "http://localhost:8543/metrics",
+		"horcrux-1:8543":  "http://horcrux-1:8543/metrics",
+		"[fd00::1]:8543":  "http://[fd00::1]:8543/metrics",
+	} {
+		url, err := metricsURL(addr)
+		require.NoError(t, err)
+		require.Equal(t, expect, url)
+	}
+
+	_, err := metricsURL("")
+	require.ErrorContains(t, err, "debugAddr is not configured")
+}
+
+func TestEnableMetricsServer(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	EnableMetricsServer(ctx, io.Discard, addr)
+
+	var body []byte
+	require.Eventually(t, func() bool {
+		res, err := http.Get("http://" + addr + "/metrics")
+		if err != nil {
+			return false
+		}
+		defer res.Body.Close()
+		body, err = io.ReadAll(res.Body)
+		return err == nil && res.StatusCode == http.StatusOK
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Contains(t, string(body), "signer_raft_term")
+
+	// only the metrics are served, not pprof.
+	res, err := http.Get("http://" + addr + "/debug/pprof/")
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	cometjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/horcrux/signer"
+)
+
+// singleSignerChain is the key and sign state of a chain of a single signer, to migrate to threshold mode.
+type singleSignerChain struct {
+	chainID   string
+	key       privval.FilePVKey
+	signState signer.SignStateConsensus
+}
+
+func migrateToThresholdCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-to-threshold",
+		Args:  cobra.NoArgs,
+		Short: "Migrate a single signer to a threshold signer cluster",
+		Long: `Shard the priv_validator_key.json of each chain of a single signer, and write the home directory
+of each cosigner of the new cluster to a cosigner_{id} directory: the key shards, the ECIES keys, a threshold
+mode config.yaml copied from the single signer config, and the sign state of the single signer, so that the
+cluster does not sign below the last height, round and step signed by the single signer.
+Stop the single signer first. Copy each cosigner_{id} directory to the home directory of the cosigner,
+and do not start the single signer again.`,
+		Example: `horcrux config migrate-to-threshold --threshold 2 --out ./cluster \
+--cosigner tcp://10.168.1.1:2222 --cosigner tcp://10.168.1.2:2222 --cosigner tcp://10.168.1.3:2222`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+
+			chainIDs, _ := flags.GetStringSlice(flagChainID)
+			cosignersFlag, _ := flags.GetStringSlice(flagCosigner)
+			threshold, _ := flags.GetInt(flagThreshold)
+			raftTimeout, _ := flags.GetString(flagRaftTimeout)
+			grpcTimeout, _ := flags.GetString(flagGRPCTimeout)
+			encrypt, _ := flags.GetBool(flagEncrypt)
+			vault, _ := flags.GetBool(flagVault)
+			out, _ := flags.GetString(flagOutputDir)
+
+			if encrypt && vault {
+				return fmt.Errorf("encrypt and vault flags are mutually exclusive")
+			}
+
+			if config.Config.SignMode != signer.SignModeSingle {
+				return fmt.Errorf("sign mode is %q, only a %s signer can be migrated to threshold mode",
+					config.Config.SignMode, signer.SignModeSingle)
+			}
+
+			cosigners, err := signer.CosignersFromFlag(cosignersFlag)
+			if err != nil {
+				return err
+			}
+
+			cfg := config.Config
+			cfg.SignMode = signer.SignModeThreshold
+			// the key shards are written to the home directory of each cosigner.
+			cfg.PrivValKeyDir = nil
+			cfg.ThresholdModeConfig = &signer.ThresholdModeConfig{
+				Threshold:   threshold,
+				Cosigners:   cosigners,
+				GRPCTimeout: grpcTimeout,
+				RaftTimeout: raftTimeout,
+			}
+			if err := cfg.ValidateThresholdModeConfig(); err != nil {
+				return err
+			}
+
+			// the sign state must not change while it is carried over.
+			if err := signer.RequireNotRunning(config.PidFile); err != nil {
+				return err
+			}
+
+			if len(chainIDs) == 0 {
+				if chainIDs, err = singleSignerChainIDs(); err != nil {
+					return err
+				}
+			}
+
+			chains := make([]singleSignerChain, len(chainIDs))
+			for i, chainID := range chainIDs {
+				if chains[i], err = loadSingleSignerChain(cmd, chainID); err != nil {
+					return err
+				}
+			}
+
+			for _, c := range cosigners {
+				configFile := filepath.Join(out, fmt.Sprintf("cosigner_%d", c.ShardID), "config.yaml")
+				if _, err := os.Stat(configFile); err == nil {
+					return fmt.Errorf("%s already exists, use an empty --%s directory", configFile, flagOutputDir)
+				}
+			}
+
+			enc, err := newShardEncryption(cmd, encrypt, vault)
+			if err != nil {
+				return err
+			}
+
+			for _, chain := range chains {
+				csKeys := signer.CreateCosignerEd25519Shards(chain.key, uint8(threshold), uint8(len(cosigners)))
+				if err := writeCosignerEd25519Shards(cmd, chain.chainID, csKeys, enc); err != nil {
+					return err
+				}
+			}
+
+			eciesKeys, err := signer.CreateCosignerECIESShards(len(cosigners))
+			if err != nil {
+				return err
+			}
+
+			for _, key := range eciesKeys {
+				dir, err := createCosignerDirectoryIfNecessary(out, key.ID)
+				if err != nil {
+					return err
+				}
+				if err := signer.WriteCosignerECIESShardFile(key, filepath.Join(dir, "ecies_keys.json")); err != nil {
+					return err
+				}
+
+				cosignerConfig := signer.RuntimeConfig{
+					ConfigFile: filepath.Join(dir, "config.yaml"),
+					StateDir:   filepath.Join(dir, "state"),
+					Config:     cfg,
+				}
+				if err := cosignerConfig.WriteConfigFile(); err != nil {
+					return err
+				}
+				if err := os.MkdirAll(cosignerConfig.StateDir, 0700); err != nil {
+					return err
+				}
+				for _, chain := range chains {
+					if err := writeMigratedSignState(cosignerConfig, chain); err != nil {
+						return err
+					}
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Created cosigner home directory %s\n", dir)
+			}
+
+			return nil
+		},
+	}
+
+	addOutputDirFlag(cmd)
+
+	f := cmd.Flags()
+	f.StringSlice(flagChainID, nil, "chain IDs to migrate, defaults to every chain with a key in the key directory")
+	f.StringSliceP(flagCosigner, "c", []string{},
+		"cosigner p2p addresses in format tcp://{p2p-addr}:{port}, with an optional |{shard-id}")
+	_ = cmd.MarkFlagRequired(flagCosigner)
+	f.Int(flagThreshold, 0, "number of shards required for threshold signature")
+	_ = cmd.MarkFlagRequired(flagThreshold)
+	f.String(flagRaftTimeout, "1500ms", "cosigner raft timeout value, \n"+
+		"accepts valid duration strings for Go's time.ParseDuration() e.g. 1s, 1000ms, 1.5m")
+	f.String(flagGRPCTimeout, "1500ms", "cosigner grpc timeout value, \n"+
+		"accepts valid duration strings for Go's time.ParseDuration() e.g. 1s, 1000ms, 1.5m")
+	f.Bool(flagEncrypt, false, "encrypt the key shards with a passphrase, read from "+envShardPassphrase+
+		" or entered on the terminal")
+	f.Bool(flagVault, false, "encrypt the key shards with the Vault transit key of vaultTransit in the config")
+
+	return cmd
+}
+
+// singleSignerChainIDs returns the chain IDs of the priv_validator_key.json files in the key directory.
+func singleSignerChainIDs() ([]string, error) {
+	files, err := filepath.Glob(config.KeyFilePathSingleSigner("*"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no {chain-id}_priv_validator_key.json files found in the key directory %s",
+			filepath.Dir(config.KeyFilePathSingleSigner("")))
+	}
+	chainIDs := make([]string, len(files))
+	for i, file := range files {
+		chainIDs[i] = strings.TrimSuffix(filepath.Base(file), "_priv_validator_key.json")
+	}
+	sort.Strings(chainIDs)
+	return chainIDs, nil
+}
+
+// loadSingleSignerChain loads the key and the last sign state of a chain of the single signer.
+func loadSingleSignerChain(cmd *cobra.Command, chainID string) (singleSignerChain, error) {
+	keyFile, err := config.KeyFileExistsSingleSigner(chainID)
+	if err != nil {
+		return singleSignerChain{}, err
+	}
+	key, err := signer.ReadPrivValidatorFile(keyFile)
+	if err != nil {
+		return singleSignerChain{}, fmt.Errorf("error reading %s: %w", keyFile, err)
+	}
+
+	chain := singleSignerChain{chainID: chainID, key: key}
+
+	// the single signer keeps the sign state in the CometBFT priv_validator_state.json format.
+	stateFile := config.PrivValStateFile(chainID)
+	pvStateJSON, err := os.ReadFile(stateFile)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: no sign state found for %s, the cluster starts without one\n", chainID)
+	case err != nil:
+		return chain, fmt.Errorf("error reading the sign state of %s: %w", chainID, err)
+	default:
+		pvState := &FilePVLastSignState{}
+		if err := cometjson.Unmarshal(pvStateJSON, pvState); err != nil {
+			return chain, fmt.Errorf("error parsing %s: %w", stateFile, err)
+		}
+		chain.signState = signer.SignStateConsensus{
+			Height:    pvState.Height,
+			Round:     int64(pvState.Round),
+			Step:      pvState.Step,
+			Signature: pvState.Signature,
+			SignBytes: pvState.SignBytes,
+		}
+	}
+	return chain, nil
+}
+
+// writeMigratedSignState writes the sign state of the single signer as the privval sign state of a cosigner.
+// The share sign state only gets the height, round and step, since the signature is not a share signature.
+func writeMigratedSignState(cosignerConfig signer.RuntimeConfig, chain singleSignerChain) error {
+	pv, err := signer.LoadOrCreateSignState(cosignerConfig.PrivValStateFile(chain.chainID))
+	if err != nil {
+		return err
+	}
+	if err := saveImportedSignState(pv, chain.signState); err != nil {
+		return fmt.Errorf("error saving privval sign state: %w", err)
+	}
+
+	cs, err := signer.LoadOrCreateSignState(cosignerConfig.CosignerStateFile(chain.chainID))
+	if err != nil {
+		return err
+	}
+	if err := saveImportedSignState(cs, signer.SignStateConsensus{
+		Height: chain.signState.Height,
+		Round:  chain.signState.Round,
+		Step:   chain.signState.Step,
+	}); err != nil {
+		return fmt.Errorf("error saving share sign state: %w", err)
+	}
+	return nil
+}
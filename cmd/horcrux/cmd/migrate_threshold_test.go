@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cometjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/strangelove-ventures/horcrux/signer"
+	"github.com/stretchr/testify/require"
+	tsed25519 "gitlab.com/unit410/threshold-ed25519/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+func TestMigrateToThresholdCmd(t *testing.T) {
+	tmpHome := t.TempDir()
+	tmpConfig := filepath.Join(tmpHome, ".horcrux")
+
+	run := func(args ...string) error {
+		cmd := rootCmd()
+		cmd.SetOutput(io.Discard)
+		cmd.SetArgs(append([]string{"--home", tmpConfig}, args...))
+		return cmd.Execute()
+	}
+
+	require.NoError(t, run("config", "init", "-m", "single", "-n", "tcp://10.168.0.1:1234"))
+
+	privKey := ed25519.GenPrivKey()
+	pv := privval.NewFilePV(privKey, filepath.Join(tmpConfig, testChainID+"_priv_validator_key.json"),
+		filepath.Join(tmpHome, "priv_validator_state.json"))
+	pv.Save()
+
+	stateDir := filepath.Join(tmpConfig, "state")
+	require.NoError(t, os.MkdirAll(stateDir, 0700))
+	pvStateJSON, err := cometjson.Marshal(FilePVLastSignState{Height: 100, Round: 1, Step: 2})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(stateDir, testChainID+"_priv_validator_state.json"), pvStateJSON, 0600))
+
+	out := filepath.Join(tmpHome, "cluster")
+	migrate := func() error {
+		return run("config", "migrate-to-threshold", "--out", out, "--threshold", "2",
+			"-c", "tcp://cosigner-1:2222", "-c", "tcp://cosigner-2:2222", "-c", "tcp://cosigner-3:2222")
+	}
+	require.NoError(t, migrate())
+
+	shares := make([][]byte, 3)
+	for id := 1; id <= 3; id++ {
+		dir := filepath.Join(out, fmt.Sprintf("cosigner_%d", id))
+
+		configBz, err := os.ReadFile(filepath.Join(dir, "config.yaml"))
+		require.NoError(t, err)
+		var cfg signer.Config
+		require.NoError(t, yaml.Unmarshal(configBz, &cfg))
+		require.Equal(t, signer.SignModeThreshold, cfg.SignMode)
+		require.Equal(t, 2, cfg.ThresholdModeConfig.Threshold)
+		require.Len(t, cfg.ThresholdModeConfig.Cosigners, 3)
+		require.Equal(t, "tcp://10.168.0.1:1234", cfg.ChainNodes[0].PrivValAddr)
+		require.NoError(t, cfg.ValidateThresholdModeConfig())
+
+		key, err := signer.LoadCosignerEd25519Key(filepath.Join(dir, testChainID+"_shard.json"))
+		require.NoError(t, err)
+		require.Equal(t, id, key.ID)
+		require.Equal(t, privKey.PubKey(), key.PubKey)
+		shares[id-1] = key.PrivateShard
+
+		_, err = os.Stat(filepath.Join(dir, "ecies_keys.json"))
+		require.NoError(t, err)
+
+		// the cluster carries on from the sign state of the single signer.
+		for _, file := range []string{
+			testChainID + "_priv_validator_state.json",
+			testChainID + "_share_sign_state.json",
+		} {
+			ss, err := signer.LoadSignState(filepath.Join(dir, "state", file))
+			require.NoError(t, err)
+			require.Equal(t, signer.HRSKey{Height: 100, Round: 1, Step: 2}, ss.HRSKey())
+		}
+	}
+
+	secret := tsed25519.CombineShares(3, []int{1, 3}, [][]byte{shares[0], shares[2]})
+	require.Equal(t, privKey.PubKey().Bytes(), []byte(tsed25519.ScalarMultiplyBase(secret)))
+
+	// an earlier migration is not overwritten.
+	require.ErrorContains(t, migrate(), "already exists")
+
+	// only a single signer is migrated.
+	cmd := rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{
+		"--home", filepath.Join(out, "cosigner_1"),
+		"config", "migrate-to-threshold",
+		"--out", filepath.Join(tmpHome, "other"),
+		"--threshold", "2",
+		"-c", "tcp://cosigner-1:2222", "-c", "tcp://cosigner-2:2222", "-c", "tcp://cosigner-3:2222",
+	})
+	require.ErrorContains(t, cmd.Execute(), "only a single signer can be migrated")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+)
+
+func pauseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause",
+		Short: "Pause signing on all cosigners",
+		Long: `Pause signing on all cosigners without stopping them, e.g. during an upgrade or an incident.
+The cosigners keep running and keep their raft cluster, but refuse every sign request until
+horcrux resume is run. The paused state is persisted by each cosigner, so restarting a cosigner
+does not resume signing.`,
+		Example:      `horcrux pause`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := setPaused(newLogger(cmd.OutOrStdout(), "pause"), true); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Signing paused, run horcrux resume to sign again")
+			return nil
+		},
+	}
+}
+
+func resumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "resume",
+		Short:        "Resume signing on all cosigners after horcrux pause",
+		Example:      `horcrux resume`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := setPaused(newLogger(cmd.OutOrStdout(), "resume"), false); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Signing resumed")
+			return nil
+		},
+	}
+}
+
+// setPaused asks the raft leader to pause or resume signing on all cosigners.
+func setPaused(logger cometlog.Logger, paused bool) error {
+	if config.Config.ThresholdModeConfig == nil {
+		return fmt.Errorf("threshold mode configuration is not present in config file")
+	}
+
+	if len(config.Config.ThresholdModeConfig.Cosigners) == 0 {
+		return fmt.Errorf("threshold mode configuration has no cosigners")
+	}
+
+	conn, err := dialLeader(logger)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelFunc()
+
+	_, err = proto.NewCosignerGRPCClient(conn).SetPaused(ctx, &proto.CosignerGRPCSetPausedRequest{Paused: paused})
+	return err
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/horcrux/client"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"google.golang.org/grpc"
+)
+
+const pingTimeout = 5 * time.Second
+
+func pingCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ping",
+		Short: "Test the connectivity to every cosigner in the config",
+		Long: `Test the connectivity to every cosigner in the config.
+For each cosigner, opens a TCP connection to its p2p address to check that it is reachable, then
+calls GetLeader over gRPC to check that it responds. A cosigner that is reachable but does not
+respond may be rejecting the connection, e.g. because of mismatched TLS certificates.
+Exits with an error if fewer than threshold cosigners respond.`,
+		Example:      `horcrux ping`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			thresholdCfg := config.Config.ThresholdModeConfig
+			if thresholdCfg == nil {
+				return fmt.Errorf("threshold mode configuration is not present in config file")
+			}
+
+			if len(thresholdCfg.Cosigners) == 0 {
+				return fmt.Errorf("threshold mode configuration has no cosigners")
+			}
+
+			// ping concurrently so that unreachable cosigners don't add up their timeouts.
+			pings := make([]cosignerPing, len(thresholdCfg.Cosigners))
+			var wg sync.WaitGroup
+			for i, c := range thresholdCfg.Cosigners {
+				wg.Add(1)
+				go func(i, shardID int, p2pAddr string) {
+					defer wg.Done()
+					pings[i] = pingCosigner(cmd.Context(), shardID, p2pAddr, pingTimeout)
+				}(i, c.ShardID, c.P2PAddr)
+			}
+			wg.Wait()
+
+			responding := printCosignerPings(cmd.OutOrStdout(), pings)
+			if responding < thresholdCfg.Threshold {
+				return fmt.Errorf("only %d of %d cosigners respond, threshold of %d is not reachable",
+					responding, len(pings), thresholdCfg.Threshold)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%d of %d cosigners respond, threshold of %d is reachable\n",
+				responding, len(pings), thresholdCfg.Threshold)
+			return nil
+		},
+	}
+}
+
+// cosignerPing is the result of pinging a cosigner.
+type cosignerPing struct {
+	shardID int
+	address string
+
+	// dialRTT is the time taken to open a TCP connection, dialErr is set if it could not be opened.
+	dialRTT time.Duration
+	dialErr error
+
+	// rpcRTT is the round-trip time of GetLeader, rpcErr is set if it failed.
+	rpcRTT time.Duration
+	leader string
+	rpcErr error
+}
+
+// pingCosigner checks that the cosigner at p2pAddr is reachable and responds to GetLeader.
+func pingCosigner(ctx context.Context, shardID int, p2pAddr string, timeout time.Duration) cosignerPing {
+	p := cosignerPing{shardID: shardID, address: p2pAddr}
+
+	grpcAddress, err := client.SanitizeAddress(p2pAddr)
+	if err != nil {
+		p.dialErr = err
+		return p
+	}
+	p.address = grpcAddress
+
+	start := time.Now()
+	tcpConn, err := (&net.Dialer{Timeout: timeout}).DialContext(ctx, "tcp", grpcAddress)
+	if err != nil {
+		p.dialErr = err
+		return p
+	}
+	p.dialRTT = time.Since(start)
+	_ = tcpConn.Close()
+
+	creds, err := cosignerCredentials()
+	if err != nil {
+		p.rpcErr = err
+		return p
+	}
+
+	conn, err := grpc.Dial(grpcAddress, creds)
+	if err != nil {
+		p.rpcErr = fmt.Errorf("dialing failed: %w", err)
+		return p
+	}
+	defer conn.Close()
+
+	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
+	defer cancelFunc()
+
+	start = time.Now()
+	res, err := proto.NewCosignerGRPCClient(conn).GetLeader(ctx, &proto.CosignerGRPCGetLeaderRequest{})
+	if err != nil {
+		p.rpcErr = err
+		return p
+	}
+	p.rpcRTT = time.Since(start)
+	p.leader = res.Leader
+
+	return p
+}
+
+// printCosignerPings prints a table of the cosigner pings and returns the number of cosigners that respond.
+func printCosignerPings(out io.Writer, pings []cosignerPing) (responding int) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SHARD ID\tADDRESS\tTCP\tGRPC\tLEADER")
+	for _, p := range pings {
+		switch {
+		case p.dialErr != nil:
+			fmt.Fprintf(w, "%d\t%s\tUNREACHABLE: %v\t-\t-\n", p.shardID, p.address, p.dialErr)
+		case p.rpcErr != nil:
+			fmt.Fprintf(w, "%d\t%s\t%s\tFAILED: %v\t-\n", p.shardID, p.address, formatRTT(p.dialRTT), p.rpcErr)
+		default:
+			responding++
+			leader := p.leader
+			if leader == "" {
+				leader = "none"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", p.shardID, p.address, formatRTT(p.dialRTT), formatRTT(p.rpcRTT), leader)
+		}
+	}
+	_ = w.Flush()
+
+	return responding
+}
+
+func formatRTT(d time.Duration) string {
+	return d.Round(10 * time.Microsecond).String()
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type pingTestServer struct {
+	proto.UnimplementedCosignerGRPCServer
+}
+
+func (pingTestServer) GetLeader(
+	context.Context,
+	*proto.CosignerGRPCGetLeaderRequest,
+) (*proto.CosignerGRPCGetLeaderResponse, error) {
+	return &proto.CosignerGRPCGetLeaderResponse{Leader: "10.168.1.1:2222"}, nil
+}
+
+func TestPingCosigner(t *testing.T) {
+	ctx := context.Background()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	proto.RegisterCosignerGRPCServer(srv, pingTestServer{})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	p := pingCosigner(ctx, 1, "tcp://"+lis.Addr().String(), time.Second)
+	require.NoError(t, p.dialErr)
+	require.NoError(t, p.rpcErr)
+	require.Equal(t, lis.Addr().String(), p.address)
+	require.Equal(t, "10.168.1.1:2222", p.leader)
+
+	// reachable, but does not speak gRPC.
+	rejecting, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer rejecting.Close()
+	go func() {
+		for {
+			conn, err := rejecting.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	p = pingCosigner(ctx, 2, "tcp://"+rejecting.Addr().String(), time.Second)
+	require.NoError(t, p.dialErr)
+	require.Error(t, p.rpcErr)
+
+	// unreachable.
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, closed.Close())
+
+	p = pingCosigner(ctx, 3, "tcp://"+closed.Addr().String(), time.Second)
+	require.Error(t, p.dialErr)
+}
+
+func TestPrintCosignerPings(t *testing.T) {
+	var out bytes.Buffer
+	responding := printCosignerPings(&out, []cosignerPing{
+		{shardID: 1, address: "10.168.1.1:2222", dialRTT: time.Millisecond, rpcRTT: 2 * time.Millisecond,
+			leader: "10.168.1.1:2222"},
+		{shardID: 2, address: "10.168.1.2:2222", dialRTT: time.Millisecond, rpcErr: errors.New("tls: bad certificate")},
+		{shardID: 3, address: "10.168.1.3:2222", dialErr: errors.New("connection refused")},
+	})
+	require.Equal(t, 1, responding)
+	require.Equal(t, `SHARD ID  ADDRESS          TCP                              GRPC                          LEADER
+1         10.168.1.1:2222  1ms                              2ms                           10.168.1.1:2222
+2         10.168.1.2:2222  1ms                              FAILED: tls: bad certificate  -
+3         10.168.1.3:2222  UNREACHABLE: connection refused  -                             -
+`, out.String())
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/horcrux/signer"
+)
+
+const flagShardFile = "shard-file"
+
+// reshareCmd is a cobra command for dealing new Ed25519 key shards for a new threshold and number of
+// cosigners from the current key shards, keeping the same validator public key.
+func reshareCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reshare",
+		Args:  cobra.NoArgs,
+		Short: "Reshare the Ed25519 key shards for a new threshold or number of cosigners",
+		Long: `Deal new Ed25519 key shards from at least the current threshold of key shards, for a new threshold
+and number of cosigners. The validator public key stays the same, so no consensus key change is needed.
+
+The validator key is reconstructed in memory to deal the new shards, so run this on a trusted machine.
+The new shards do not combine with the old ones: stop all cosigners, replace the key shards of every
+cosigner of the new set and update the cosigner and threshold config before starting them again.`,
+		Example: `horcrux reshare --chain-id cosmoshub-4 --threshold 3 --shards 5 --out ./reshared \
+--shard-file cosigner_1/cosmoshub-4_shard.json --shard-file cosigner_2/cosmoshub-4_shard.json`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flags := cmd.Flags()
+
+			chainID, _ := flags.GetString(flagChainID)
+			shardFiles, _ := flags.GetStringSlice(flagShardFile)
+			threshold, _ := flags.GetUint8(flagThreshold)
+			shards, _ := flags.GetUint8(flagShards)
+			encrypt, _ := flags.GetBool(flagEncrypt)
+			vault, _ := flags.GetBool(flagVault)
+
+			if encrypt && vault {
+				return fmt.Errorf("encrypt and vault flags are mutually exclusive")
+			}
+
+			if chainID == "" {
+				return fmt.Errorf("chain-id flag must not be empty")
+			}
+
+			if len(shardFiles) == 0 {
+				return fmt.Errorf("shard-file flag must be set for at least the current threshold of key shards")
+			}
+
+			if err := validateThresholdShards(threshold, shards); err != nil {
+				return err
+			}
+
+			keys := make([]signer.CosignerEd25519Key, len(shardFiles))
+			for i, file := range shardFiles {
+				if keys[i], err = signer.LoadCosignerEd25519Key(file); err != nil {
+					return fmt.Errorf("error reading key shard %s: %w", file, err)
+				}
+			}
+
+			if err := decryptReshareKeys(cmd, shardFiles, keys); err != nil {
+				return err
+			}
+
+			csKeys, err := signer.ReshareCosignerEd25519Keys(keys, threshold, shards)
+			if err != nil {
+				return err
+			}
+
+			enc, err := newShardEncryption(cmd, encrypt, vault)
+			if err != nil {
+				return err
+			}
+
+			return writeCosignerEd25519Shards(cmd, chainID, csKeys, enc)
+		},
+	}
+
+	addOutputDirFlag(cmd)
+	addTotalShardsFlag(cmd)
+
+	f := cmd.Flags()
+	f.StringSlice(flagShardFile, nil, "current key shard file, at least the current threshold of them is required")
+	_ = cmd.MarkFlagRequired(flagShardFile)
+	f.Uint8(flagThreshold, 0, "new threshold number of shards required to successfully sign")
+	_ = cmd.MarkFlagRequired(flagThreshold)
+	f.String(flagChainID, "", "key shards will sign for this chain ID")
+	_ = cmd.MarkFlagRequired(flagChainID)
+	f.Bool(flagEncrypt, false, "encrypt the new key shards with a passphrase, read from "+envShardPassphrase+
+		" or entered on the terminal")
+	f.Bool(flagVault, false, "encrypt the new key shards with the Vault transit key of vaultTransit in the config")
+
+	return cmd
+}
+
+// decryptReshareKeys decrypts the encrypted key shards in memory, with the passphrase or the Vault transit
+// key they are encrypted with.
+func decryptReshareKeys(cmd *cobra.Command, files []string, keys []signer.CosignerEd25519Key) (err error) {
+	var enc signer.ShardEncryption
+	for i := range keys {
+		if !keys[i].Encrypted() {
+			continue
+		}
+		scheme := keys[i].EncryptedShard.Scheme
+		if enc == nil {
+			if scheme == signer.ShardEncryptionSchemeVaultTransit {
+				enc, err = config.VaultTransitShardEncryption()
+			} else {
+				enc, err = passphraseShardEncryption(cmd.ErrOrStderr(), false)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := keys[i].Decrypt(enc); err != nil {
+			return fmt.Errorf("%s: %w", files[i], err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/strangelove-ventures/horcrux/signer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReshareCmd(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv(envShardPassphrase, "correct horse battery staple")
+
+	run := func(args ...string) error {
+		cmd := rootCmd()
+		cmd.SetOutput(io.Discard)
+		cmd.SetArgs(append([]string{"--home", tmp}, args...))
+		return cmd.Execute()
+	}
+
+	current := filepath.Join(tmp, "current")
+	require.NoError(t, run("create-ed25519-shards", "--out", current,
+		"--chain-id", testChainID,
+		"--threshold", "2",
+		"--shards", "3",
+		"--unsafe-seed", "horcrux-test-seed",
+		"--encrypt",
+	))
+
+	shardFile := func(dir string, id int) string {
+		return filepath.Join(dir, fmt.Sprintf("cosigner_%d", id), testChainID+"_shard.json")
+	}
+
+	reshared := filepath.Join(tmp, "reshared")
+	require.NoError(t, run("reshare", "--out", reshared,
+		"--chain-id", testChainID,
+		"--threshold", "3",
+		"--shards", "5",
+		"--shard-file", shardFile(current, 1),
+		"--shard-file", shardFile(current, 3),
+	))
+
+	expected := signer.CreateCosignerEd25519ShardsFromSeed([]byte("horcrux-test-seed"), 2, 3)
+	for id := 1; id <= 5; id++ {
+		key, err := signer.LoadCosignerEd25519Key(shardFile(reshared, id))
+		require.NoError(t, err)
+		require.False(t, key.Encrypted())
+		require.Equal(t, id, key.ID)
+		require.Equal(t, expected[0].PubKey, key.PubKey)
+	}
+
+	err := run("reshare", "--out", filepath.Join(tmp, "too-few"),
+		"--chain-id", testChainID,
+		"--threshold", "3",
+		"--shards", "5",
+		"--shard-file", shardFile(reshared, 1),
+		"--shard-file", shardFile(reshared, 2),
+	)
+	require.ErrorContains(t, err, "at least the current threshold of shards is required")
+
+	err = run("reshare", "--out", filepath.Join(tmp, "invalid"),
+		"--chain-id", testChainID,
+		"--threshold", "2",
+		"--shards", "4",
+		"--shard-file", shardFile(current, 1),
+	)
+	require.ErrorContains(t, err, "threshold must be greater than total shards divided by 2")
+}
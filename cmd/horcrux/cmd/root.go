@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -18,6 +19,9 @@ func rootCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "horcrux",
 		Short: "A tendermint remote signer with both threshold signer and single signer modes",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateLogFlags()
+		},
 	}
 
 	cmd.AddCommand(configCmd())
@@ -25,6 +29,8 @@ func rootCmd() *cobra.Command {
 	cmd.AddCommand(addressCmd())
 	cmd.AddCommand(createCosignerEd25519ShardsCmd())
 	cmd.AddCommand(createCosignerECIESShardsCmd())
+	cmd.AddCommand(createCosignerTLSCertsCmd())
+	cmd.AddCommand(reshareCmd())
 
 	rsaCmd := createCosignerRSAShardsCmd()
 	rsaCmd.Deprecated = `
@@ -39,14 +45,30 @@ horcrux create-ecies-shards
 	cmd.AddCommand(leaderElectionCmd())
 	cmd.AddCommand(getLeaderCmd())
 	cmd.AddCommand(stateCmd())
+	cmd.AddCommand(cosignerCmd())
+	cmd.AddCommand(pingCmd())
+	cmd.AddCommand(drillCmd())
+	cmd.AddCommand(pauseCmd())
+	cmd.AddCommand(resumeCmd())
+	cmd.AddCommand(metricsCmd())
+	cmd.AddCommand(debugCmd())
 	cmd.AddCommand(versionCmd())
 
+	// cobra only adds the --version flag if the version is set.
+	cmd.Version = Version
+	if cmd.Version == "" {
+		cmd.Version = "unknown"
+	}
+	cmd.SetVersionTemplate(NewInfo().String())
+
 	cmd.PersistentFlags().StringVar(
 		&config.HomeDir,
 		"home",
 		"",
 		"Directory for config and data (default is $HOME/.horcrux)",
 	)
+	cmd.PersistentFlags().StringVar(&logFormat, flagLogFormat, logFormatText, "format of the logs, text or json")
+	cmd.PersistentFlags().StringVar(&logLevel, flagLogLevel, "info", "level of the logs, debug, info, error or none")
 
 	return cmd
 }
@@ -56,10 +78,29 @@ horcrux create-ecies-shards
 func Execute() {
 	if err := rootCmd().Execute(); err != nil {
 		// Cobra will print the error
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
 
+// exitCodeError is returned by commands that exit with a specific code, so that scripts can tell
+// the outcome apart from other failures.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 }
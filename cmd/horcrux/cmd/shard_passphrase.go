@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/strangelove-ventures/horcrux/signer"
+	"golang.org/x/term"
+)
+
+// envShardPassphrase holds the passphrase of the encrypted key shards, so that horcrux can be started
+// without a terminal, e.g. as a service.
+const envShardPassphrase = "HORCRUX_SHARD_PASSPHRASE"
+
+// shardPassphrase returns the passphrase of the key shards from the environment, or prompts for it
+// on the terminal, twice if confirm is set.
+func shardPassphrase(out io.Writer, confirm bool) ([]byte, error) {
+	if passphrase, ok := os.LookupEnv(envShardPassphrase); ok {
+		return []byte(passphrase), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("a key shard passphrase is required, set %s or run in a terminal to enter it",
+			envShardPassphrase)
+	}
+
+	fmt.Fprint(out, "Enter key shard passphrase: ")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key shard passphrase: %w", err)
+	}
+
+	if confirm {
+		fmt.Fprint(out, "Confirm key shard passphrase: ")
+		confirmation, err := term.ReadPassword(fd)
+		fmt.Fprintln(out)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key shard passphrase: %w", err)
+		}
+		if !bytes.Equal(passphrase, confirmation) {
+			return nil, fmt.Errorf("key shard passphrases do not match")
+		}
+	}
+
+	return passphrase, nil
+}
+
+// loadShardEncryption returns the encryption of the key shards in the key directory, or nil if none of them
+// is encrypted. It is checked against every encrypted shard, so that a wrong passphrase or an unreachable
+// Vault fails at startup rather than on the first sign request of a chain.
+func loadShardEncryption(out io.Writer) (signer.ShardEncryption, error) {
+	files, err := filepath.Glob(config.KeyFilePathCosigner("*"))
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := make(map[string]signer.CosignerEd25519Key)
+	var scheme string
+	for _, file := range files {
+		key, err := signer.LoadCosignerEd25519Key(file)
+		if err != nil {
+			// reported when the shard is loaded for signing.
+			continue
+		}
+		if !key.Encrypted() {
+			continue
+		}
+		if scheme != "" && key.EncryptedShard.Scheme != scheme {
+			return nil, fmt.Errorf("key shards are encrypted with both %s and %s, use a single scheme",
+				scheme, key.EncryptedShard.Scheme)
+		}
+		scheme = key.EncryptedShard.Scheme
+		encrypted[file] = key
+	}
+	if len(encrypted) == 0 {
+		return nil, nil
+	}
+
+	var enc signer.ShardEncryption
+	switch scheme {
+	case signer.ShardEncryptionSchemeVaultTransit:
+		enc, err = config.VaultTransitShardEncryption()
+	default:
+		enc, err = passphraseShardEncryption(out, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for file, key := range encrypted {
+		if err := key.Decrypt(enc); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return enc, nil
+}
+
+// passphraseShardEncryption returns the encryption of the key shards with the passphrase from shardPassphrase.
+func passphraseShardEncryption(out io.Writer, confirm bool) (signer.ShardEncryption, error) {
+	passphrase, err := shardPassphrase(out, confirm)
+	if err != nil {
+		return nil, err
+	}
+	return signer.NewPassphraseShardEncryption(passphrase)
+}
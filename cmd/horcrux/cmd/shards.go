@@ -43,11 +43,14 @@ func createCosignerDirectoryIfNecessary(out string, id int) (string, error) {
 }
 
 const (
-	flagOutputDir = "out"
-	flagThreshold = "threshold"
-	flagShards    = "shards"
-	flagKeyFile   = "key-file"
-	flagChainID   = "chain-id"
+	flagOutputDir  = "out"
+	flagThreshold  = "threshold"
+	flagShards     = "shards"
+	flagKeyFile    = "key-file"
+	flagChainID    = "chain-id"
+	flagUnsafeSeed = "unsafe-seed"
+	flagEncrypt    = "encrypt"
+	flagVault      = "vault"
 )
 
 func addOutputDirFlag(cmd *cobra.Command) {
@@ -59,6 +62,30 @@ func addTotalShardsFlag(cmd *cobra.Command) {
 	_ = cmd.MarkFlagRequired(flagShards)
 }
 
+// validateThresholdShards validates the --threshold and --shards flags of the Ed25519 key shards.
+func validateThresholdShards(threshold, shards uint8) error {
+	if threshold == 0 {
+		return fmt.Errorf("threshold flag must be > 0, <= --shards, and > --shards/2")
+	}
+
+	if shards == 0 {
+		return fmt.Errorf("shards flag must be greater than zero")
+	}
+
+	if threshold > shards {
+		return fmt.Errorf(
+			"threshold cannot be greater than total shards, got [threshold](%d) > [shards](%d)",
+			threshold, shards,
+		)
+	}
+
+	if threshold <= shards/2 {
+		return fmt.Errorf("threshold must be greater than total shards "+
+			"divided by 2, got [threshold](%d) <= [shards](%d) / 2", threshold, shards)
+	}
+	return nil
+}
+
 // createCosignerEd25519ShardsCmd is a cobra command for creating
 // cosigner shards from a full priv validator key.
 func createCosignerEd25519ShardsCmd() *cobra.Command {
@@ -71,74 +98,62 @@ func createCosignerEd25519ShardsCmd() *cobra.Command {
 
 			chainID, _ := flags.GetString(flagChainID)
 			keyFile, _ := flags.GetString(flagKeyFile)
+			unsafeSeed, _ := flags.GetString(flagUnsafeSeed)
 			threshold, _ := flags.GetUint8(flagThreshold)
 			shards, _ := flags.GetUint8(flagShards)
+			encrypt, _ := flags.GetBool(flagEncrypt)
+			vault, _ := flags.GetBool(flagVault)
 
 			var errs []error
 
-			if keyFile == "" {
-				return fmt.Errorf("key-file flag must not be empty")
-			}
-
-			if chainID == "" {
-				return fmt.Errorf("chain-id flag must not be empty")
+			if keyFile != "" && unsafeSeed != "" {
+				return fmt.Errorf("key-file and unsafe-seed flags are mutually exclusive")
 			}
 
-			if threshold == 0 {
-				return fmt.Errorf("threshold flag must be > 0, <= --shards, and > --shards/2")
+			if encrypt && vault {
+				return fmt.Errorf("encrypt and vault flags are mutually exclusive")
 			}
 
-			if shards == 0 {
-				return fmt.Errorf("shards flag must be greater than zero")
+			if keyFile == "" && unsafeSeed == "" {
+				return fmt.Errorf("key-file flag must not be empty")
 			}
 
-			if _, err := os.Stat(keyFile); err != nil {
-				return fmt.Errorf("error accessing priv_validator_key file(%s): %w", keyFile, err)
+			if chainID == "" {
+				return fmt.Errorf("chain-id flag must not be empty")
 			}
 
-			if threshold > shards {
-				return fmt.Errorf(
-					"threshold cannot be greater than total shards, got [threshold](%d) > [shards](%d)",
-					threshold, shards,
-				)
+			if err := validateThresholdShards(threshold, shards); err != nil {
+				return err
 			}
 
-			if threshold <= shards/2 {
-				return fmt.Errorf("threshold must be greater than total shards "+
-					"divided by 2, got [threshold](%d) <= [shards](%d) / 2", threshold, shards)
+			if keyFile != "" {
+				if _, err := os.Stat(keyFile); err != nil {
+					return fmt.Errorf("error accessing priv_validator_key file(%s): %w", keyFile, err)
+				}
 			}
 
 			if len(errs) > 0 {
 				return nil
 			}
 
-			csKeys, err := signer.CreateCosignerEd25519ShardsFromFile(keyFile, threshold, shards)
-			if err != nil {
-				return err
-			}
-
-			out, _ := cmd.Flags().GetString(flagOutputDir)
-			if out != "" {
-				if err := os.MkdirAll(out, 0700); err != nil {
+			var csKeys []signer.CosignerEd25519Key
+			if unsafeSeed != "" {
+				fmt.Fprintln(cmd.ErrOrStderr(), "WARNING: creating shards of a key derived from --unsafe-seed, "+
+					"UNSAFE FOR PRODUCTION. Anyone who knows the seed can recover the key.")
+				csKeys = signer.CreateCosignerEd25519ShardsFromSeed([]byte(unsafeSeed), threshold, shards)
+			} else {
+				csKeys, err = signer.CreateCosignerEd25519ShardsFromFile(keyFile, threshold, shards)
+				if err != nil {
 					return err
 				}
 			}
 
-			// silence usage after all input has been validated
-			cmd.SilenceUsage = true
-
-			for _, c := range csKeys {
-				dir, err := createCosignerDirectoryIfNecessary(out, c.ID)
-				if err != nil {
-					return err
-				}
-				filename := filepath.Join(dir, fmt.Sprintf("%s_shard.json", chainID))
-				if err = signer.WriteCosignerEd25519ShardFile(c, filename); err != nil {
-					return err
-				}
-				fmt.Fprintf(cmd.OutOrStdout(), "Created Ed25519 Shard %s\n", filename)
+			enc, err := newShardEncryption(cmd, encrypt, vault)
+			if err != nil {
+				return err
 			}
-			return nil
+
+			return writeCosignerEd25519Shards(cmd, chainID, csKeys, enc)
 		},
 	}
 
@@ -149,13 +164,66 @@ func createCosignerEd25519ShardsCmd() *cobra.Command {
 	f.Uint8(flagThreshold, 0, "threshold number of shards required to successfully sign")
 	_ = cmd.MarkFlagRequired(flagThreshold)
 	f.String(flagKeyFile, "", "priv_validator_key.json file to shard")
-	_ = cmd.MarkFlagRequired(flagKeyFile)
+	f.String(flagUnsafeSeed, "", "UNSAFE FOR PRODUCTION: instead of --key-file, shard a key derived from this seed \n"+
+		"with deterministic shares, so that tests can rely on the same key shards across runs")
 	f.String(flagChainID, "", "key shards will sign for this chain ID")
 	_ = cmd.MarkFlagRequired(flagChainID)
+	f.Bool(flagEncrypt, false, "encrypt the key shards with a passphrase, read from "+envShardPassphrase+
+		" or entered on the terminal")
+	f.Bool(flagVault, false, "encrypt the key shards with the Vault transit key of vaultTransit in the config")
 
 	return cmd
 }
 
+// newShardEncryption returns the encryption of new key shards with a passphrase or the Vault transit key,
+// or nil if neither encrypt nor vault is set.
+func newShardEncryption(cmd *cobra.Command, encrypt, vault bool) (signer.ShardEncryption, error) {
+	switch {
+	case vault:
+		return config.VaultTransitShardEncryption()
+	case encrypt:
+		return passphraseShardEncryption(cmd.ErrOrStderr(), true)
+	default:
+		return nil, nil
+	}
+}
+
+// writeCosignerEd25519Shards writes the key shards to the cosigner directories in the --out directory,
+// encrypted with enc if it is not nil.
+func writeCosignerEd25519Shards(cmd *cobra.Command, chainID string, csKeys []signer.CosignerEd25519Key,
+	enc signer.ShardEncryption) error {
+	if enc != nil {
+		for i := range csKeys {
+			if err := csKeys[i].Encrypt(enc); err != nil {
+				return err
+			}
+		}
+	}
+
+	out, _ := cmd.Flags().GetString(flagOutputDir)
+	if out != "" {
+		if err := os.MkdirAll(out, 0700); err != nil {
+			return err
+		}
+	}
+
+	// silence usage after all input has been validated
+	cmd.SilenceUsage = true
+
+	for _, c := range csKeys {
+		dir, err := createCosignerDirectoryIfNecessary(out, c.ID)
+		if err != nil {
+			return err
+		}
+		filename := filepath.Join(dir, fmt.Sprintf("%s_shard.json", chainID))
+		if err = signer.WriteCosignerEd25519ShardFile(c, filename); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Created Ed25519 Shard %s\n", filename)
+	}
+	return nil
+}
+
 // createCosignerECIESShardsCmd is a cobra command for creating cosigner-to-cosigner encryption secp256k1 keys.
 func createCosignerECIESShardsCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -204,6 +272,62 @@ func createCosignerECIESShardsCmd() *cobra.Command {
 	return cmd
 }
 
+// createCosignerTLSCertsCmd is a cobra command for creating a CA and certificates for cosigner-to-cosigner mutual TLS.
+func createCosignerTLSCertsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-cosigner-tls-certs",
+		Args:  cobra.NoArgs,
+		Short: "Create a self-signed CA and cosigner certificates for mutual TLS",
+		Long: `Create a self-signed CA and a certificate for each cosigner, for clusters without a PKI.
+Each cosigner directory gets the CA certificate ca.crt, and the certificate cosigner.crt and key cosigner.key
+of the cosigner, valid for the host of its p2p address and for ` + signer.CosignerTLSServerName + `.`,
+		Example: `horcrux create-cosigner-tls-certs --cosigner tcp://10.168.1.1:2222 --cosigner tcp://10.168.1.2:2222 \
+--cosigner tcp://10.168.1.3:2222`,
+
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			cosignersFlag, _ := cmd.Flags().GetStringSlice(flagCosigner)
+			cosigners, err := signer.CosignersFromFlag(cosignersFlag)
+			if err != nil {
+				return err
+			}
+
+			caPEM, certs, err := signer.CreateCosignerTLSCerts(cosigners)
+			if err != nil {
+				return err
+			}
+
+			out, _ := cmd.Flags().GetString(flagOutputDir)
+			if out != "" {
+				if err := os.MkdirAll(out, 0700); err != nil {
+					return err
+				}
+			}
+
+			// silence usage after all input has been validated
+			cmd.SilenceUsage = true
+
+			for _, c := range certs {
+				dir, err := createCosignerDirectoryIfNecessary(out, c.ID)
+				if err != nil {
+					return err
+				}
+				for name, bz := range map[string][]byte{"ca.crt": caPEM, "cosigner.crt": c.Cert, "cosigner.key": c.Key} {
+					if err := os.WriteFile(filepath.Join(dir, name), bz, 0600); err != nil {
+						return err
+					}
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Created TLS certificate %s\n", filepath.Join(dir, "cosigner.crt"))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceP(flagCosigner, "c", []string{},
+		"cosigner p2p addresses in format tcp://{p2p-addr}:{port}, with an optional |{shard-id}")
+	_ = cmd.MarkFlagRequired(flagCosigner)
+	addOutputDirFlag(cmd)
+	return cmd
+}
+
 // createCosignerRSAShardsCmd is a cobra command for creating cosigner-to-cosigner encryption RSA keys.
 func createCosignerRSAShardsCmd() *cobra.Command {
 	cmd := &cobra.Command{
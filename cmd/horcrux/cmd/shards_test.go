@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/privval"
+	"github.com/strangelove-ventures/horcrux/signer"
 	"github.com/stretchr/testify/require"
 )
 
@@ -104,6 +111,156 @@ func TestEd25519Shards(t *testing.T) {
 	}
 }
 
+func TestEd25519ShardsUnsafeSeed(t *testing.T) {
+	tmp := t.TempDir()
+
+	createShards := func(out string, args ...string) error {
+		cmd := rootCmd()
+		cmd.SetOutput(io.Discard)
+		cmd.SetArgs(append([]string{
+			"create-ed25519-shards", "--home", tmp, "--out", out,
+			"--chain-id", testChainID,
+			"--threshold", "2",
+			"--shards", "3",
+		}, args...))
+		return cmd.Execute()
+	}
+
+	run1, run2 := filepath.Join(tmp, "run1"), filepath.Join(tmp, "run2")
+	require.NoError(t, createShards(run1, "--unsafe-seed", "horcrux-test-seed"))
+	require.NoError(t, createShards(run2, "--unsafe-seed", "horcrux-test-seed"))
+
+	for i := 1; i <= 3; i++ {
+		file := filepath.Join(fmt.Sprintf("cosigner_%d", i), testChainID+"_shard.json")
+		shard1, err := os.ReadFile(filepath.Join(run1, file))
+		require.NoError(t, err)
+		shard2, err := os.ReadFile(filepath.Join(run2, file))
+		require.NoError(t, err)
+		require.Equal(t, shard1, shard2)
+	}
+
+	err := createShards(filepath.Join(tmp, "run3"),
+		"--unsafe-seed", "horcrux-test-seed", "--key-file", filepath.Join(tmp, "priv_validator_key.json"))
+	require.ErrorContains(t, err, "mutually exclusive")
+}
+
+func TestEd25519ShardsEncrypt(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv(envShardPassphrase, "correct horse battery staple")
+
+	cmd := rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{
+		"create-ed25519-shards", "--home", tmp, "--out", tmp,
+		"--chain-id", testChainID,
+		"--threshold", "2",
+		"--shards", "3",
+		"--unsafe-seed", "horcrux-test-seed",
+		"--encrypt",
+	})
+	require.NoError(t, cmd.Execute())
+
+	expected := signer.CreateCosignerEd25519ShardsFromSeed([]byte("horcrux-test-seed"), 2, 3)
+
+	cosignerDir := filepath.Join(tmp, "cosigner_1")
+	key, err := signer.LoadCosignerEd25519Key(filepath.Join(cosignerDir, testChainID+"_shard.json"))
+	require.NoError(t, err)
+	require.True(t, key.Encrypted())
+	require.Equal(t, expected[0].PubKey, key.PubKey)
+
+	prevConfig := config
+	t.Cleanup(func() { config = prevConfig })
+	config = signer.RuntimeConfig{HomeDir: cosignerDir}
+
+	enc, err := loadShardEncryption(io.Discard)
+	require.NoError(t, err)
+	require.NoError(t, key.Decrypt(enc))
+	require.Equal(t, expected[0].PrivateShard, key.PrivateShard)
+
+	t.Setenv(envShardPassphrase, "wrong")
+	_, err = loadShardEncryption(io.Discard)
+	require.ErrorContains(t, err, "passphrase is wrong")
+
+	// shards that are not encrypted don't require a passphrase.
+	config = signer.RuntimeConfig{HomeDir: t.TempDir()}
+	require.NoError(t, signer.WriteCosignerEd25519ShardFile(expected[0], config.KeyFilePathCosigner(testChainID)))
+	enc, err = loadShardEncryption(io.Discard)
+	require.NoError(t, err)
+	require.Nil(t, enc)
+}
+
+func TestEd25519ShardsVault(t *testing.T) {
+	// fake Vault transit key, the ciphertext is the base64 plaintext with the Vault prefix.
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/horcrux":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]string{"ciphertext": "vault:v1:" + req["plaintext"]},
+			})
+		case "/v1/transit/decrypt/horcrux":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]string{"plaintext": strings.TrimPrefix(req["ciphertext"], "vault:v1:")},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(vault.Close)
+	t.Setenv("VAULT_TOKEN", "test-token")
+
+	tmp := t.TempDir()
+	vaultConfig := &signer.VaultTransitConfig{Address: vault.URL, KeyName: "horcrux"}
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "config.yaml"),
+		[]byte("vaultTransit:\n  address: "+vault.URL+"\n  keyName: horcrux\n"), 0600))
+
+	cmd := rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{
+		"create-ed25519-shards", "--home", tmp, "--out", tmp,
+		"--chain-id", testChainID,
+		"--threshold", "2",
+		"--shards", "3",
+		"--unsafe-seed", "horcrux-test-seed",
+		"--vault",
+	})
+	require.NoError(t, cmd.Execute())
+
+	expected := signer.CreateCosignerEd25519ShardsFromSeed([]byte("horcrux-test-seed"), 2, 3)
+
+	cosignerDir := filepath.Join(tmp, "cosigner_1")
+	key, err := signer.LoadCosignerEd25519Key(filepath.Join(cosignerDir, testChainID+"_shard.json"))
+	require.NoError(t, err)
+	require.True(t, key.Encrypted())
+	require.Equal(t, signer.ShardEncryptionSchemeVaultTransit, key.EncryptedShard.Scheme)
+
+	prevConfig := config
+	t.Cleanup(func() { config = prevConfig })
+	config = signer.RuntimeConfig{HomeDir: cosignerDir, Config: signer.Config{VaultTransit: vaultConfig}}
+
+	enc, err := loadShardEncryption(io.Discard)
+	require.NoError(t, err)
+	require.NoError(t, key.Decrypt(enc))
+	require.Equal(t, expected[0].PrivateShard, key.PrivateShard)
+
+	// the cosigner does not start if Vault is unreachable.
+	vault.Close()
+	_, err = loadShardEncryption(io.Discard)
+	require.Error(t, err)
+
+	cmd = rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{
+		"create-ed25519-shards", "--home", tmp, "--out", t.TempDir(),
+		"--chain-id", testChainID,
+		"--threshold", "2",
+		"--shards", "3",
+		"--vault", "--encrypt",
+	})
+	require.ErrorContains(t, cmd.Execute(), "mutually exclusive")
+}
+
 func TestRSAShards(t *testing.T) {
 	tmp := t.TempDir()
 
@@ -139,3 +296,22 @@ func TestRSAShards(t *testing.T) {
 		})
 	}
 }
+
+func TestCosignerTLSCerts(t *testing.T) {
+	tmp := t.TempDir()
+
+	cmd := rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{
+		"create-cosigner-tls-certs", "--home", tmp, "--out", tmp,
+		"-c", "tcp://10.168.1.1:2222|2", "-c", "tcp://cosigner-3:2222|3",
+	})
+	require.NoError(t, cmd.Execute())
+
+	for _, id := range []int{2, 3} {
+		for _, file := range []string{"ca.crt", "cosigner.crt", "cosigner.key"} {
+			require.FileExists(t, filepath.Join(tmp, fmt.Sprintf("cosigner_%d", id), file))
+		}
+	}
+	require.NoDirExists(t, filepath.Join(tmp, "cosigner_1"))
+}
@@ -4,12 +4,13 @@ import (
 	"fmt"
 	"os"
 
-	cometlog "github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/libs/service"
 	"github.com/spf13/cobra"
 	"github.com/strangelove-ventures/horcrux/signer"
 )
 
+const flagMetricsAddr = "metrics-addr"
+
 func startCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "start",
@@ -28,19 +29,55 @@ func startCmd() *cobra.Command {
 				return fmt.Errorf("this is a legacy config. run `horcrux config migrate` to migrate to the latest format")
 			}
 
-			logger := cometlog.NewTMLogger(cometlog.NewSyncWriter(out)).With("module", "validator")
+			logger := newLogger(out, "validator")
 
 			// create all directories up to the state directory
 			if err = os.MkdirAll(config.StateDir, 0700); err != nil {
 				return err
 			}
 
+			if err := config.CheckHomeDirPermissions(); err != nil {
+				if config.Config.HomeDirPermissions == signer.HomeDirPermissionsRefuse {
+					return err
+				}
+				logger.Error("Warning: insecure home directory permissions", "error", err)
+			}
+
+			if config.Config.DNSResolver != "" {
+				dnsResolver := config.Config.DNSResolverAddr()
+				if err := signer.SetDNSResolver(dnsResolver); err != nil {
+					return err
+				}
+				logger.Info("Resolving hostnames with DNS resolver", "dns_resolver", dnsResolver)
+			}
+
 			logger.Info(
 				"Horcrux Validator",
 				"mode", config.Config.SignMode,
 				"priv-state-dir", config.StateDir,
 			)
 
+			metricsAddr, _ := cmd.Flags().GetString(flagMetricsAddr)
+
+			if config.Config.MetricsBackend == signer.MetricsBackendStatsd {
+				if metricsAddr != "" {
+					return fmt.Errorf("--%s requires the %s metrics backend", flagMetricsAddr, signer.MetricsBackendPrometheus)
+				}
+				if err := EnableStatsdMetrics(out); err != nil {
+					return err
+				}
+			}
+
+			diskSpace := signer.NewDiskSpaceMonitor(logger, config.StateDir, config.Config.StateDirMinFreeBytes())
+			if err := diskSpace.Start(); err != nil {
+				return err
+			}
+
+			armed, err := signer.NewArmedFile(logger, &config)
+			if err != nil {
+				return err
+			}
+
 			acceptRisk, _ := cmd.Flags().GetBool(flagAcceptRisk)
 
 			var val signer.PrivValidator
@@ -48,7 +85,7 @@ func startCmd() *cobra.Command {
 
 			switch config.Config.SignMode {
 			case signer.SignModeThreshold:
-				services, val, err = NewThresholdValidator(logger)
+				services, val, err = NewThresholdValidator(logger, armed)
 				if err != nil {
 					return err
 				}
@@ -61,9 +98,37 @@ func startCmd() *cobra.Command {
 				panic(fmt.Errorf("unexpected sign mode: %s", config.Config.SignMode))
 			}
 
+			if armed != nil {
+				val = signer.NewArmedPrivValidator(val, armed)
+			}
+
+			if len(config.Config.BlockProtocolVersions) > 0 {
+				val = signer.NewProtocolVersionPrivValidator(logger, val, config.Config)
+			}
+
+			services = append(services, diskSpace)
+
+			if config.Config.Tracing != nil {
+				tracing := signer.NewTracingService(logger, *config.Config.Tracing)
+				if err := tracing.Start(); err != nil {
+					return err
+				}
+				services = append(services, tracing)
+			}
+
 			go EnableDebugAndMetrics(cmd.Context(), out)
+			if metricsAddr != "" {
+				EnableMetricsServer(cmd.Context(), out, metricsAddr)
+			}
+
+			chainNodeTLS, err := config.ChainNodeTLSConfig()
+			if err != nil {
+				return err
+			}
 
-			services, err = signer.StartRemoteSigners(services, logger, val, config.Config.Nodes())
+			services, err = signer.StartRemoteSigners(
+				services, logger, val, config.Config.Nodes(), config.Config.BackupNodes(),
+				config.Config.BackupChainNodesOrdered, chainNodeTLS)
 			if err != nil {
 				return fmt.Errorf("failed to start remote signer(s): %w", err)
 			}
@@ -75,6 +140,8 @@ func startCmd() *cobra.Command {
 	}
 
 	cmd.Flags().Bool(flagAcceptRisk, false, "Single-signer-mode unsupported. Required to accept risk and proceed.")
+	cmd.Flags().String(flagMetricsAddr, "",
+		"address to serve only the prometheus metrics on, e.g. 0.0.0.0:9100, separately from the debug server")
 
 	return cmd
 }
@@ -2,26 +2,37 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/horcrux/client"
 	"github.com/strangelove-ventures/horcrux/signer"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"google.golang.org/grpc"
 
+	cometbytes "github.com/cometbft/cometbft/libs/bytes"
 	cometjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/tempfile"
 )
 
 // Snippet Taken from https://raw.githubusercontent.com/cometbft/cometbft/main/privval/file.go
 // FilePVLastSignState stores the mutable part of PrivValidator.
 type FilePVLastSignState struct {
-	Height int64 `json:"height"`
-	Round  int32 `json:"round"`
-	Step   int8  `json:"step"`
+	Height    int64               `json:"height"`
+	Round     int32               `json:"round"`
+	Step      int8                `json:"step"`
+	Signature []byte              `json:"signature,omitempty"`
+	SignBytes cometbytes.HexBytes `json:"signbytes,omitempty"`
 }
 
 func stateCmd() *cobra.Command {
@@ -33,6 +44,9 @@ func stateCmd() *cobra.Command {
 	cmd.AddCommand(showStateCmd())
 	cmd.AddCommand(setStateCmd())
 	cmd.AddCommand(importStateCmd())
+	cmd.AddCommand(exportStateCmd())
+	cmd.AddCommand(recoverStateFromRaftCmd())
+	cmd.AddCommand(compareStateCmd())
 
 	return cmd
 }
@@ -121,13 +135,11 @@ func setStateCmd() *cobra.Command {
 			}
 			err = pv.Save(signState, nil)
 			if err != nil {
-				fmt.Printf("error saving privval sign state")
-				return err
+				return fmt.Errorf("error saving privval sign state: %w", err)
 			}
 			err = cs.Save(signState, nil)
 			if err != nil {
-				fmt.Printf("error saving share sign state")
-				return err
+				return fmt.Errorf("error saving share sign state: %w", err)
 			}
 			return nil
 		},
@@ -136,11 +148,15 @@ func setStateCmd() *cobra.Command {
 
 func importStateCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:     "import chain-id",
+		Use:     "import chain-id [priv_validator_state.json]",
 		Aliases: []string{"i"},
 		Short: "Read the old priv_validator_state.json and set the height, round and step" +
 			"(good for migrations but NOT shared state update)",
-		Args:         cobra.ExactArgs(1),
+		Long: `Read the old priv_validator_state.json and set the height, round and step of the sign state.
+Reads the file if a path is given, otherwise the JSON is pasted on stdin.
+Refuses to lower an existing sign state, or to import the state of another chain-id.`,
+		Example:      `horcrux state import cosmoshub-4 ~/.gaia/data/priv_validator_state.json`,
+		Args:         cobra.RangeArgs(1, 2),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			chainID := args[0]
@@ -156,75 +172,401 @@ func importStateCmd() *cobra.Command {
 				return err
 			}
 
-			// Recreate privValStateFile if necessary
-			pv, err := signer.LoadOrCreateSignState(config.PrivValStateFile(chainID))
-			if err != nil {
-				return err
-			}
+			out := cmd.OutOrStdout()
 
-			// shareStateFile does not exist during default config init, so create if necessary
-			cs, err := signer.LoadOrCreateSignState(config.CosignerStateFile(chainID))
-			if err != nil {
-				return err
+			if len(args) == 2 {
+				pvStateJSON, err := os.ReadFile(args[1])
+				if err != nil {
+					return fmt.Errorf("error reading priv_validator_state.json: %w", err)
+				}
+				return importSignState(out, chainID, pvStateJSON)
 			}
 
 			// Allow user to paste in priv_validator_state.json
 
-			fmt.Println("IMPORTANT: Your validator should already be STOPPED.  You must copy the latest state..")
+			fmt.Fprintln(out, "IMPORTANT: Your validator should already be STOPPED.  You must copy the latest state..")
 			<-time.After(2 * time.Second)
-			fmt.Println("")
-			fmt.Println("Paste your old priv_validator_state.json.  Input a blank line after the pasted JSON to continue.")
-			fmt.Println("")
+			fmt.Fprintln(out, "")
+			fmt.Fprintln(out, "Paste your old priv_validator_state.json.  Input a blank line after the pasted JSON to continue.")
+			fmt.Fprintln(out, "")
 
 			var textBuffer strings.Builder
 
-			scanner := bufio.NewScanner(os.Stdin)
+			scanner := bufio.NewScanner(cmd.InOrStdin())
 			for scanner.Scan() {
 				if len(scanner.Text()) == 0 {
 					break
 				}
 				textBuffer.WriteString(scanner.Text())
 			}
-			finalJSON := textBuffer.String()
 
-			pvState := &FilePVLastSignState{}
+			return importSignState(out, chainID, []byte(textBuffer.String()))
+		},
+	}
+}
+
+// importSignState sets the privval and share sign states of the chain to the height, round and step
+// of a CometBFT priv_validator_state.json. It refuses to lower an existing sign state, which could
+// double sign, and to import the state of another chain, as told by its sign bytes.
+func importSignState(out io.Writer, chainID string, pvStateJSON []byte) error {
+	pvState := &FilePVLastSignState{}
+	if err := cometjson.Unmarshal(pvStateJSON, pvState); err != nil {
+		return fmt.Errorf("error parsing priv_validator_state.json: %w", err)
+	}
+
+	// the sign bytes are empty if the validator has not signed yet.
+	if len(pvState.SignBytes) > 0 {
+		_, signBytesChainID, err := signer.UnpackHRSTAndChainID(pvState.SignBytes)
+		if err != nil {
+			return fmt.Errorf("error parsing the sign bytes of priv_validator_state.json: %w", err)
+		}
+		if signBytesChainID != chainID {
+			return fmt.Errorf("priv_validator_state.json is for chain-id %s, not %s", signBytesChainID, chainID)
+		}
+	}
+
+	// Recreate privValStateFile if necessary
+	pv, err := signer.LoadOrCreateSignState(config.PrivValStateFile(chainID))
+	if err != nil {
+		return err
+	}
+
+	// shareStateFile does not exist during default config init, so create if necessary
+	cs, err := signer.LoadOrCreateSignState(config.CosignerStateFile(chainID))
+	if err != nil {
+		return err
+	}
+
+	signState := signer.SignStateConsensus{
+		Height:    pvState.Height,
+		Round:     int64(pvState.Round),
+		Step:      pvState.Step,
+		Signature: nil,
+		SignBytes: nil,
+	}
+
+	// check both sign states before saving either, so that they are not left inconsistent.
+	for _, ss := range []*signer.SignState{pv, cs} {
+		err := ss.GetErrorIfLessOrEqual(signState.Height, signState.Round, signState.Step)
+		var regressionErr *signer.RegressionError
+		if errors.As(err, &regressionErr) {
+			hrs := ss.HRSKey()
+			return fmt.Errorf("refusing to lower the sign state of %s from height %d round %d step %d "+
+				"to height %d round %d step %d", chainID, hrs.Height, hrs.Round, hrs.Step,
+				signState.Height, signState.Round, signState.Step)
+		}
+	}
+
+	fmt.Fprintf(out, "Saving New Sign State: \n"+
+		"  Height:    %v\n"+
+		"  Round:     %v\n"+
+		"  Step:      %v\n",
+		signState.Height, signState.Round, signState.Step)
+
+	pv.NoncePublic = nil
+	if err := saveImportedSignState(pv, signState); err != nil {
+		return fmt.Errorf("error saving privval sign state: %w", err)
+	}
+	if err := saveImportedSignState(cs, signState); err != nil {
+		return fmt.Errorf("error saving share sign state: %w", err)
+	}
+	fmt.Fprintf(out, "Update Successful\n")
+	return nil
+}
+
+// saveImportedSignState saves the sign state, a state that is already imported is left as is.
+func saveImportedSignState(ss *signer.SignState, signState signer.SignStateConsensus) error {
+	err := ss.Save(signState, nil)
+	var sameHRSErr *signer.SameHRSError
+	if errors.As(err, &sameHRSErr) {
+		return nil
+	}
+	return err
+}
+
+const flagShare = "share"
+
+func exportStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "export chain-id",
+		Aliases: []string{"e"},
+		Short:   "Export the sign state of a specific chain-id in the priv_validator_state.json format",
+		Long: `Export the sign state of a specific chain-id in the priv_validator_state.json format, for backups
+or to migrate it with horcrux state import.
+The sign state is validated before it is written to stdout, or to the --output path.
+It is safe to export while the signer is running, the sign state files are always replaced atomically.`,
+		Example: `horcrux state export cosmoshub-4
+horcrux state export cosmoshub-4 --output ~/backup/priv_validator_state.json
+horcrux state export cosmoshub-4 --share`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainID := args[0]
+
+			if _, err := os.Stat(config.HomeDir); os.IsNotExist(err) {
+				cmd.SilenceUsage = false
+				return fmt.Errorf("%s does not exist, initialize config with horcrux config init and try again", config.HomeDir)
+			}
+
+			stateFile := config.PrivValStateFile(chainID)
+			if share, _ := cmd.Flags().GetBool(flagShare); share {
+				stateFile = config.CosignerStateFile(chainID)
+			}
 
-			err = cometjson.Unmarshal([]byte(finalJSON), &pvState)
+			pvStateJSON, err := exportSignState(chainID, stateFile)
 			if err != nil {
-				fmt.Println("Error parsing priv_validator_state.json")
 				return err
 			}
 
-			pv.NoncePublic = nil
-			signState := signer.SignStateConsensus{
-				Height:    pvState.Height,
-				Round:     int64(pvState.Round),
-				Step:      pvState.Step,
-				Signature: nil,
-				SignBytes: nil,
+			output, _ := cmd.Flags().GetString(flagOutput)
+			if output == "" {
+				_, err := cmd.OutOrStdout().Write(pvStateJSON)
+				return err
 			}
-			fmt.Printf("Saving New Sign State: \n"+
-				"  Height:    %v\n"+
-				"  Round:     %v\n"+
-				"  Step:      %v\n",
-				signState.Height, signState.Round, signState.Step)
 
-			err = pv.Save(signState, nil)
-			if err != nil {
-				fmt.Printf("error saving privval sign state")
+			if err := tempfile.WriteFileAtomic(output, pvStateJSON, 0600); err != nil {
+				return fmt.Errorf("error writing %s: %w", output, err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Exported %s to %s\n", stateFile, output)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringP(flagOutput, "o", "", "path to write the sign state to, instead of stdout")
+	f.Bool(flagShare, false, "export the share sign state of the cosigner instead of the privval sign state")
+	return cmd
+}
+
+// exportSignState reads the sign state file of the chain, validates it and returns it
+// in the CometBFT priv_validator_state.json format.
+func exportSignState(chainID, stateFile string) ([]byte, error) {
+	ss, err := signer.LoadSignState(stateFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading sign state: %w", err)
+	}
+
+	// the sign bytes are empty if the cosigner has not signed yet.
+	if len(ss.SignBytes) > 0 {
+		hrst, signBytesChainID, err := signer.UnpackHRSTAndChainID(ss.SignBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the sign bytes of %s: %w", stateFile, err)
+		}
+		if signBytesChainID != chainID {
+			return nil, fmt.Errorf("%s has sign bytes for chain-id %s, not %s", stateFile, signBytesChainID, chainID)
+		}
+		if hrs := ss.HRSKey(); hrst.HRSKey() != hrs {
+			return nil, fmt.Errorf("%s is at height %d round %d step %d, but has sign bytes for "+
+				"height %d round %d step %d", stateFile, hrs.Height, hrs.Round, hrs.Step,
+				hrst.Height, hrst.Round, hrst.Step)
+		}
+		if len(ss.Signature) == 0 {
+			return nil, fmt.Errorf("%s has sign bytes but no signature", stateFile)
+		}
+	}
+
+	pvStateJSON, err := cometjson.MarshalIndent(FilePVLastSignState{
+		Height:    ss.Height,
+		Round:     int32(ss.Round),
+		Step:      ss.Step,
+		Signature: ss.Signature,
+		SignBytes: ss.SignBytes,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(pvStateJSON, '\n'), nil
+}
+
+func recoverStateFromRaftCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "recover-from-raft chain-id",
+		Short: "Recover the sign state for a specific chain-id from the local raft log",
+		Long: `Recover the sign state for a specific chain-id from the last sign state replicated in the local raft log.
+This is a recovery path for a lost or corrupted sign state file when the raft data survives.
+The sign state is only moved forward: a state file that is already at or above the recovered height, round
+and step is left untouched, and the highest state of the raft log and any readable state file is used.
+A corrupted state file is moved aside to {file}.corrupt before it is replaced.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainID := args[0]
+
+			if _, err := os.Stat(config.HomeDir); os.IsNotExist(err) {
+				cmd.SilenceUsage = false
+				return fmt.Errorf("%s does not exist, initialize config with horcrux config init and try again", config.HomeDir)
+			}
+
+			// The raft log can only be read while the signer is not running.
+			if err := signer.RequireNotRunning(config.PidFile); err != nil {
 				return err
 			}
-			err = cs.Save(signState, nil)
+
+			out := cmd.OutOrStdout()
+
+			recovered, err := signer.LastSignStateFromRaftLog(filepath.Join(config.HomeDir, "raft"), chainID)
 			if err != nil {
-				fmt.Printf("error saving share sign state")
 				return err
 			}
-			fmt.Printf("Update Successful\n")
+
+			fmt.Fprintln(out, "Raft Log Sign State:")
+			fmt.Fprintf(out, "  Height:    %v\n"+
+				"  Round:     %v\n"+
+				"  Step:      %v\n",
+				recovered.Height, recovered.Round, recovered.Step)
+
+			stateFiles := []string{config.PrivValStateFile(chainID), config.CosignerStateFile(chainID)}
+			states := make([]*signer.SignState, len(stateFiles))
+			for i, file := range stateFiles {
+				ss, err := signer.LoadOrCreateSignState(file)
+				if err != nil {
+					corruptFile := file + ".corrupt"
+					fmt.Fprintf(out, "Failed to load %s, moving it to %s: %v\n", file, corruptFile, err)
+					if err := os.Rename(file, corruptFile); err != nil {
+						return err
+					}
+					if ss, err = signer.LoadOrCreateSignState(file); err != nil {
+						return err
+					}
+				}
+				states[i] = ss
+
+				// never produce a lower sign state than any source
+				if ss.HRSKey().GreaterThan(recovered.HRSKey()) {
+					recovered = &signer.SignStateConsensus{
+						Height:    ss.Height,
+						Round:     ss.Round,
+						Step:      ss.Step,
+						Signature: ss.Signature,
+						SignBytes: ss.SignBytes,
+					}
+				}
+			}
+
+			for i, ss := range states {
+				if !ss.HRSKey().LessThan(recovered.HRSKey()) {
+					fmt.Fprintf(out, "%s is already at the recovered sign state\n", stateFiles[i])
+					continue
+				}
+				if err := ss.Save(*recovered, nil); err != nil {
+					return fmt.Errorf("error saving sign state %s: %w", stateFiles[i], err)
+				}
+				fmt.Fprintf(out, "Recovered %s to height %d, round %d, step %d\n",
+					stateFiles[i], recovered.Height, recovered.Round, recovered.Step)
+			}
+
 			return nil
 		},
 	}
 }
 
+// maxSignStateHeightLag is how many blocks a cosigner's sign state can be behind
+// the most recent sign state of the cluster before it is flagged.
+const maxSignStateHeightLag = 1
+
+// cosignerSignState is the sign state reported by a cosigner, or the error retrieving it.
+type cosignerSignState struct {
+	shardID int
+	hrs     signer.HRSKey
+	err     error
+}
+
+func compareStateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "compare chain-id",
+		Aliases: []string{"c"},
+		Short:   "Compare the share sign state of all cosigners for a specific chain-id",
+		Long: fmt.Sprintf(`Compare the share sign state of all cosigners for a specific chain-id.
+Queries each cosigner in the config for its last signed height, round and step, and flags
+cosigners that are more than %d block(s) behind the most recent sign state or are unreachable.`,
+			maxSignStateHeightLag),
+		Example:      `horcrux state compare cosmoshub-4`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			thresholdCfg := config.Config.ThresholdModeConfig
+			if thresholdCfg == nil {
+				return fmt.Errorf("threshold mode configuration is not present in config file")
+			}
+
+			chainID := args[0]
+
+			states := make([]cosignerSignState, len(thresholdCfg.Cosigners))
+			for i, c := range thresholdCfg.Cosigners {
+				states[i] = cosignerSignState{shardID: c.ShardID}
+				states[i].hrs, states[i].err = getCosignerSignState(cmd.Context(), c.P2PAddr, chainID)
+			}
+
+			flagged := printSignStateComparison(cmd.OutOrStdout(), states)
+			if flagged > 0 {
+				return fmt.Errorf("%d of %d cosigners are behind or unreachable", flagged, len(states))
+			}
+			return nil
+		},
+	}
+}
+
+func getCosignerSignState(ctx context.Context, p2pAddr, chainID string) (signer.HRSKey, error) {
+	grpcAddress, err := client.SanitizeAddress(p2pAddr)
+	if err != nil {
+		return signer.HRSKey{}, err
+	}
+
+	creds, err := cosignerCredentials()
+	if err != nil {
+		return signer.HRSKey{}, err
+	}
+
+	conn, err := grpc.Dial(grpcAddress, creds)
+	if err != nil {
+		return signer.HRSKey{}, fmt.Errorf("dialing failed: %w", err)
+	}
+	defer conn.Close()
+
+	ctx, cancelFunc := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelFunc()
+
+	res, err := proto.NewCosignerGRPCClient(conn).GetSignState(ctx, &proto.CosignerGRPCGetSignStateRequest{
+		ChainID: chainID,
+	})
+	if err != nil {
+		return signer.HRSKey{}, err
+	}
+
+	return signer.HRSKey{Height: res.Height, Round: res.Round, Step: int8(res.Step)}, nil
+}
+
+// printSignStateComparison prints a table of the cosigner sign states and returns the number of
+// cosigners that are flagged for being behind or unreachable.
+func printSignStateComparison(out io.Writer, states []cosignerSignState) (flagged int) {
+	var maxHeight int64
+	for _, s := range states {
+		if s.err == nil && s.hrs.Height > maxHeight {
+			maxHeight = s.hrs.Height
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SHARD ID\tHEIGHT\tROUND\tSTEP\tSTATUS")
+	for _, s := range states {
+		if s.err != nil {
+			flagged++
+			fmt.Fprintf(w, "%d\t-\t-\t-\tUNREACHABLE: %v\n", s.shardID, s.err)
+			continue
+		}
+
+		status := "OK"
+		if behind := maxHeight - s.hrs.Height; behind > maxSignStateHeightLag {
+			flagged++
+			status = fmt.Sprintf("BEHIND by %d blocks", behind)
+		}
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%s\n", s.shardID, s.hrs.Height, s.hrs.Round, s.hrs.Step, status)
+	}
+	_ = w.Flush()
+
+	return flagged
+}
+
 func printSignState(out io.Writer, ss *signer.SignState) {
 	fmt.Fprintf(out, "  Height:    %v\n"+
 		"  Round:     %v\n"+
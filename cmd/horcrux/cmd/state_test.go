@@ -1,12 +1,22 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	cometjson "github.com/cometbft/cometbft/libs/json"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	comet "github.com/cometbft/cometbft/types"
+	"github.com/hashicorp/raft"
+	boltdb "github.com/hashicorp/raft-boltdb/v2"
 	"github.com/strangelove-ventures/horcrux/signer"
 	"github.com/stretchr/testify/require"
 )
@@ -85,3 +95,276 @@ func TestStateSetCmd(t *testing.T) {
 		})
 	}
 }
+
+// writeTestPrivValState writes a CometBFT priv_validator_state.json for a precommit at the height and round.
+func writeTestPrivValState(t *testing.T, dir, chainID string, height int64, round int32) string {
+	signBytes := comet.VoteSignBytes(chainID, &cometproto.Vote{
+		Type:   cometproto.PrecommitType,
+		Height: height,
+		Round:  round,
+	})
+	file := filepath.Join(dir, fmt.Sprintf("%s_%d_priv_validator_state.json", chainID, height))
+	require.NoError(t, os.WriteFile(file, []byte(fmt.Sprintf(
+		`{"height": "%d", "round": %d, "step": 3, "signature": "AAAA", "signbytes": "%X"}`,
+		height, round, signBytes,
+	)), 0600))
+	return file
+}
+
+func TestStateImportCmd(t *testing.T) {
+	tmpHome := t.TempDir()
+	tmpConfig := filepath.Join(tmpHome, ".horcrux")
+	stateDir := filepath.Join(tmpConfig, "state")
+
+	const chainID = "horcrux-1"
+
+	execute := func(args ...string) error {
+		cmd := rootCmd()
+		cmd.SetOutput(io.Discard)
+		cmd.SetArgs(append([]string{"--home", tmpConfig}, args...))
+		return cmd.Execute()
+	}
+
+	requireSignState := func(height, round int64) {
+		for _, file := range []string{chainID + "_priv_validator_state.json", chainID + "_share_sign_state.json"} {
+			ss, err := signer.LoadSignState(filepath.Join(stateDir, file))
+			require.NoError(t, err)
+			require.Equal(t, signer.HRSKey{Height: height, Round: round, Step: 3}, ss.HRSKey(), file)
+			require.Nil(t, ss.Signature)
+			require.Nil(t, ss.SignBytes)
+		}
+	}
+
+	// the state of the migrated validator is imported by config init.
+	require.NoError(t, execute(
+		"config", "init",
+		"-n", "tcp://10.168.0.1:1234",
+		"-t", "2",
+		"-c", "tcp://10.168.1.1:2222,tcp://10.168.1.2:2222,tcp://10.168.1.3:2222",
+		"--import-state", writeTestPrivValState(t, tmpHome, chainID, 100, 1),
+		"--chain-id", chainID,
+	))
+	requireSignState(100, 1)
+
+	// importing the same state again is a no-op.
+	require.NoError(t, execute("state", "import", chainID, writeTestPrivValState(t, tmpHome, chainID, 100, 1)))
+	requireSignState(100, 1)
+
+	require.NoError(t, execute("state", "import", chainID, writeTestPrivValState(t, tmpHome, chainID, 120, 0)))
+	requireSignState(120, 0)
+
+	err := execute("state", "import", chainID, writeTestPrivValState(t, tmpHome, chainID, 110, 0))
+	require.ErrorContains(t, err, "refusing to lower the sign state of horcrux-1 from height 120 round 0 step 3 "+
+		"to height 110 round 0 step 3")
+	requireSignState(120, 0)
+
+	err = execute("state", "import", chainID, writeTestPrivValState(t, tmpHome, "horcrux-2", 130, 0))
+	require.EqualError(t, err, "priv_validator_state.json is for chain-id horcrux-2, not horcrux-1")
+	requireSignState(120, 0)
+
+	err = execute("config", "init", "-o", "--import-state", writeTestPrivValState(t, tmpHome, chainID, 130, 0))
+	require.EqualError(t, err, "--import-state requires --chain-id")
+}
+
+func TestStateExportCmd(t *testing.T) {
+	tmpHome := t.TempDir()
+	tmpConfig := filepath.Join(tmpHome, ".horcrux")
+	stateDir := filepath.Join(tmpConfig, "state")
+
+	const chainID = "horcrux-1"
+
+	execute := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := rootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs(append([]string{"--home", tmpConfig}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	_, err := execute(
+		"config", "init",
+		"-n", "tcp://10.168.0.1:1234",
+		"-t", "2",
+		"-c", "tcp://10.168.1.1:2222,tcp://10.168.1.2:2222,tcp://10.168.1.3:2222",
+	)
+	require.NoError(t, err)
+
+	saveSignState := func(file, signBytesChainID string, round int64, signature []byte) {
+		ss, err := signer.LoadOrCreateSignState(filepath.Join(stateDir, file))
+		require.NoError(t, err)
+		require.NoError(t, ss.Save(signer.SignStateConsensus{
+			Height: 100,
+			Round:  round,
+			Step:   3,
+			SignBytes: comet.VoteSignBytes(signBytesChainID, &cometproto.Vote{
+				Type:   cometproto.PrecommitType,
+				Height: 100,
+				Round:  1,
+			}),
+			Signature: signature,
+		}, nil))
+	}
+	saveSignState(chainID+"_priv_validator_state.json", chainID, 1, []byte("privval signature"))
+	saveSignState(chainID+"_share_sign_state.json", chainID, 1, []byte("share signature"))
+
+	requireExported := func(pvStateJSON string, signature string) {
+		pvState := &FilePVLastSignState{}
+		require.NoError(t, cometjson.Unmarshal([]byte(pvStateJSON), pvState))
+		require.Equal(t, int64(100), pvState.Height)
+		require.Equal(t, int32(1), pvState.Round)
+		require.Equal(t, int8(3), pvState.Step)
+		require.Equal(t, signature, string(pvState.Signature))
+		require.NotEmpty(t, pvState.SignBytes)
+	}
+
+	out, err := execute("state", "export", chainID)
+	require.NoError(t, err)
+	require.Contains(t, out, `"height": "100"`)
+	requireExported(out, "privval signature")
+
+	out, err = execute("state", "export", chainID, "--share")
+	require.NoError(t, err)
+	requireExported(out, "share signature")
+
+	backup := filepath.Join(tmpHome, "priv_validator_state.json")
+	out, err = execute("state", "export", chainID, "--output", backup)
+	require.NoError(t, err)
+	require.Empty(t, out)
+	pvStateJSON, err := os.ReadFile(backup)
+	require.NoError(t, err)
+	requireExported(string(pvStateJSON), "privval signature")
+
+	// the exported state can be imported back.
+	_, err = execute("state", "import", chainID, backup)
+	require.NoError(t, err)
+
+	_, err = execute("state", "export", "horcrux-2")
+	require.ErrorContains(t, err, "error loading sign state")
+
+	// the sign state files are only written by the signer, but validate them anyway.
+	const otherChainID = "horcrux-2"
+	saveSignState(otherChainID+"_priv_validator_state.json", chainID, 1, []byte("signature"))
+	_, err = execute("state", "export", otherChainID)
+	require.ErrorContains(t, err, "has sign bytes for chain-id horcrux-1, not horcrux-2")
+
+	const otherChainID3 = "horcrux-3"
+	saveSignState(otherChainID3+"_priv_validator_state.json", otherChainID3, 2, []byte("signature"))
+	_, err = execute("state", "export", otherChainID3)
+	require.ErrorContains(t, err, "is at height 100 round 2 step 3, but has sign bytes for height 100 round 1 step 3")
+
+	const otherChainID4 = "horcrux-4"
+	saveSignState(otherChainID4+"_priv_validator_state.json", otherChainID4, 1, nil)
+	_, err = execute("state", "export", otherChainID4)
+	require.ErrorContains(t, err, "has sign bytes but no signature")
+}
+
+func writeTestRaftLog(t *testing.T, raftDir string, lss ...signer.ChainSignStateConsensus) {
+	require.NoError(t, os.MkdirAll(raftDir, 0700))
+
+	logStore, err := boltdb.NewBoltStore(filepath.Join(raftDir, "logs.dat"))
+	require.NoError(t, err)
+	defer logStore.Close()
+
+	logs := make([]*raft.Log, len(lss))
+	for i, l := range lss {
+		value, err := json.Marshal(l)
+		require.NoError(t, err)
+		data, err := json.Marshal(map[string]string{"op": "set", "key": "LSS", "value": string(value)})
+		require.NoError(t, err)
+		logs[i] = &raft.Log{Index: uint64(i + 1), Term: 1, Type: raft.LogCommand, Data: data}
+	}
+	require.NoError(t, logStore.StoreLogs(logs))
+}
+
+func TestStateRecoverFromRaftCmd(t *testing.T) {
+	tmpHome := t.TempDir()
+	tmpConfig := filepath.Join(tmpHome, ".horcrux")
+	stateDir := filepath.Join(tmpConfig, "state")
+
+	chainID := "horcrux-1"
+
+	cmd := rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{
+		"--home", tmpConfig,
+		"config", "init",
+		"-n", "tcp://10.168.0.1:1234",
+		"-t", "2",
+		"-c", "tcp://10.168.1.1:2222,tcp://10.168.1.2:2222,tcp://10.168.1.3:2222",
+	})
+	require.NoError(t, cmd.Execute())
+
+	writeTestRaftLog(t, filepath.Join(tmpConfig, "raft"),
+		signer.ChainSignStateConsensus{ChainID: chainID, SignStateConsensus: signer.NewSignStateConsensus(10, 0, 3)},
+		signer.ChainSignStateConsensus{ChainID: chainID, SignStateConsensus: signer.SignStateConsensus{
+			Height: 11, Round: 1, Step: 2, Signature: []byte{1, 2, 3}, SignBytes: []byte{4, 5, 6},
+		}},
+		signer.ChainSignStateConsensus{ChainID: chainID, SignStateConsensus: signer.NewSignStateConsensus(11, 0, 3)},
+		signer.ChainSignStateConsensus{ChainID: "other-1", SignStateConsensus: signer.NewSignStateConsensus(50, 0, 3)},
+	)
+
+	pvStateFile := filepath.Join(stateDir, chainID+"_priv_validator_state.json")
+	csStateFile := filepath.Join(stateDir, chainID+"_share_sign_state.json")
+
+	// the privval state file is corrupted, and the share sign state is ahead of the raft log.
+	require.NoError(t, os.WriteFile(pvStateFile, []byte("{corrupt"), 0600))
+	cs, err := signer.LoadOrCreateSignState(csStateFile)
+	require.NoError(t, err)
+	require.NoError(t, cs.Save(signer.NewSignStateConsensus(12, 0, 1), nil))
+
+	cmd = rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{"--home", tmpConfig, "state", "recover-from-raft", chainID})
+	require.NoError(t, cmd.Execute())
+
+	for _, file := range []string{pvStateFile, csStateFile} {
+		ss, err := signer.LoadSignState(file)
+		require.NoError(t, err)
+		require.Equal(t, signer.HRSKey{Height: 12, Round: 0, Step: 1}, ss.HRSKey())
+	}
+
+	_, err = os.Stat(pvStateFile + ".corrupt")
+	require.NoError(t, err)
+
+	// without a share sign state ahead, the highest sign state of the chain in the raft log is recovered.
+	require.NoError(t, os.Remove(pvStateFile))
+	require.NoError(t, os.Remove(csStateFile))
+
+	cmd = rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{"--home", tmpConfig, "state", "recover-from-raft", chainID})
+	require.NoError(t, cmd.Execute())
+
+	for _, file := range []string{pvStateFile, csStateFile} {
+		ss, err := signer.LoadSignState(file)
+		require.NoError(t, err)
+		require.Equal(t, signer.HRSKey{Height: 11, Round: 1, Step: 2}, ss.HRSKey())
+		require.Equal(t, []byte{1, 2, 3}, ss.Signature)
+	}
+
+	cmd = rootCmd()
+	cmd.SetOutput(io.Discard)
+	cmd.SetArgs([]string{"--home", tmpConfig, "state", "recover-from-raft", "unknown-1"})
+	require.ErrorContains(t, cmd.Execute(), "no sign state found for chain unknown-1 in raft log")
+}
+
+func TestPrintSignStateComparison(t *testing.T) {
+	var out bytes.Buffer
+	flagged := printSignStateComparison(&out, []cosignerSignState{
+		{shardID: 1, hrs: signer.HRSKey{Height: 100, Round: 0, Step: 3}},
+		{shardID: 2, hrs: signer.HRSKey{Height: 99, Round: 1, Step: 2}},
+		{shardID: 3, hrs: signer.HRSKey{Height: 97, Round: 0, Step: 3}},
+		{shardID: 4, err: fmt.Errorf("connection refused")},
+	})
+	require.Equal(t, 2, flagged)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 5)
+	require.Equal(t, []string{"SHARD", "ID", "HEIGHT", "ROUND", "STEP", "STATUS"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"1", "100", "0", "3", "OK"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"2", "99", "1", "2", "OK"}, strings.Fields(lines[2]))
+	require.Equal(t, []string{"3", "97", "0", "3", "BEHIND", "by", "3", "blocks"}, strings.Fields(lines[3]))
+	require.Contains(t, lines[4], "UNREACHABLE: connection refused")
+}
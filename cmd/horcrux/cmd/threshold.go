@@ -15,6 +15,7 @@ const maxWaitForSameBlockAttempts = 3
 
 func NewThresholdValidator(
 	logger cometlog.Logger,
+	armed *signer.ArmedFile,
 ) ([]cometservice.Service, *signer.ThresholdValidator, error) {
 	if err := config.Config.ValidateThresholdModeConfig(); err != nil {
 		return nil, nil, err
@@ -37,12 +38,26 @@ func NewThresholdValidator(
 		}
 	}
 
+	shardEncryption, err := loadShardEncryption(os.Stderr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load key shard encryption: %w", err)
+	}
+	config.ShardEncryption = shardEncryption
+
+	creds, err := config.CosignerTransportCredentials()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var peers signer.CosignersConfig
 	for _, c := range thresholdCfg.Cosigners {
 		if c.ShardID != security.GetID() {
-			remoteCosigners = append(
-				remoteCosigners,
-				signer.NewRemoteCosigner(c.ShardID, c.P2PAddr),
-			)
+			peers = append(peers, c)
+			remoteCosigner := signer.NewRemoteCosigner(c.ShardID, c.P2PAddr)
+			remoteCosigner.SetTransportCredentials(creds)
+			remoteCosigner.SetKeepalive(thresholdCfg.Keepalive)
+			remoteCosigner.SetRetry(thresholdCfg.Retry)
+			remoteCosigners = append(remoteCosigners, remoteCosigner)
 		} else {
 			p2pListen = c.P2PAddr
 		}
@@ -52,12 +67,18 @@ func NewThresholdValidator(
 		return nil, nil, fmt.Errorf("cosigner config does not exist for our shard ID %d", security.GetID())
 	}
 
+	// two cosigners claiming the same shard would produce invalid threshold signatures
+	if err := signer.ValidateCosignerPeers(security.GetID(), peers); err != nil {
+		return nil, nil, err
+	}
+
 	localCosigner := signer.NewLocalCosigner(
 		logger,
 		&config,
 		security,
 		p2pListen,
 	)
+	localCosigner.SetArmedFile(armed)
 
 	// Validated prior in ValidateThresholdModeConfig
 	grpcTimeout, _ := time.ParseDuration(thresholdCfg.GRPCTimeout)
@@ -69,16 +90,24 @@ func NewThresholdValidator(
 	}
 
 	// RAFT node ID is the cosigner ID
-	nodeID := fmt.Sprint(security.GetID())
+	nodeID := string(signer.RaftServerID(security.GetID()))
 
 	// Start RAFT store listener
 	raftStore := signer.NewRaftStore(nodeID,
 		raftDir, p2pListen, raftTimeout, logger, localCosigner, remoteCosigners)
+	raftStore.SetTransportCredentials(creds)
+	raftStore.SetJSONLogs(logFormat == logFormatJSON)
 	if err := raftStore.Start(); err != nil {
 		return nil, nil, fmt.Errorf("error starting raft store: %w", err)
 	}
 	services := []cometservice.Service{raftStore}
 
+	clockSkew := signer.NewClockSkewMonitor(logger, thresholdCfg, remoteCosigners)
+	if err := clockSkew.Start(); err != nil {
+		return nil, nil, fmt.Errorf("error starting clock skew monitor: %w", err)
+	}
+	services = append(services, clockSkew)
+
 	val := signer.NewThresholdValidator(
 		logger,
 		&config,
@@ -92,5 +121,11 @@ func NewThresholdValidator(
 
 	raftStore.SetThresholdValidator(val)
 
+	liveness := signer.NewPeerLivenessMonitor(logger, val)
+	if err := liveness.Start(); err != nil {
+		return nil, nil, fmt.Errorf("error starting peer liveness monitor: %w", err)
+	}
+	services = append(services, liveness)
+
 	return services, val, nil
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"runtime"
 	"runtime/debug"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -29,19 +30,33 @@ var (
 	Version = ""
 	// commit
 	Commit = ""
+	// build date, in RFC 3339
+	BuildDate = ""
 	// sdk version
 	SDKVersion = ""
 	// tendermint version
 	CBFTVersion = ""
+
+	// features are the key types and integrations supported by this build.
+	features = []string{
+		"threshold-ed25519",
+		"single-signer-ed25519",
+		"cosigner-security-ecies",
+		"cosigner-security-rsa",
+		"vault-transit",
+		"statsd",
+	}
 )
 
 // Info defines the application version information.
 type Info struct {
-	Version          string `json:"version" yaml:"version"`
-	GitCommit        string `json:"commit" yaml:"commit"`
-	GoVersion        string `json:"go_version" yaml:"go_version"`
-	CosmosSdkVersion string `json:"cosmos_sdk_version" yaml:"cosmos_sdk_version"`
-	CometBFTVersion  string `json:"cometbft_version" yaml:"cometbft_version"`
+	Version          string   `json:"version" yaml:"version"`
+	GitCommit        string   `json:"commit" yaml:"commit"`
+	BuildDate        string   `json:"build_date" yaml:"build_date"`
+	GoVersion        string   `json:"go_version" yaml:"go_version"`
+	CosmosSdkVersion string   `json:"cosmos_sdk_version" yaml:"cosmos_sdk_version"`
+	CometBFTVersion  string   `json:"cometbft_version" yaml:"cometbft_version"`
+	Features         []string `json:"features" yaml:"features"`
 }
 
 func NewInfo() Info {
@@ -56,25 +71,53 @@ func NewInfo() Info {
 	return Info{
 		Version:          Version,
 		GitCommit:        Commit,
+		BuildDate:        BuildDate,
 		GoVersion:        fmt.Sprintf("%s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH),
 		CosmosSdkVersion: dependencyVersions["github.com/cosmos/cosmos-sdk"],
 		CometBFTVersion:  dependencyVersions["github.com/cometbft/cometbft"],
+		Features:         features,
 	}
 }
 
+// String returns the version information as text, one field per line.
+func (i Info) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "version: %s\n", i.Version)
+	fmt.Fprintf(&b, "commit: %s\n", i.GitCommit)
+	fmt.Fprintf(&b, "build date: %s\n", i.BuildDate)
+	fmt.Fprintf(&b, "go version: %s\n", i.GoVersion)
+	fmt.Fprintf(&b, "cosmos sdk version: %s\n", i.CosmosSdkVersion)
+	fmt.Fprintf(&b, "cometbft version: %s\n", i.CometBFTVersion)
+	fmt.Fprintf(&b, "features: %s\n", strings.Join(i.Features, ", "))
+	return b.String()
+}
+
 // versionCmd represents the version command
 func versionCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:          "version",
 		Short:        "Version information for horcrux",
+		Args:         cobra.NoArgs,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			bz, err := json.MarshalIndent(NewInfo(), "", "  ")
-			if err != nil {
-				return err
+			output, _ := cmd.Flags().GetString(flagOutput)
+			switch output {
+			case outputJSON:
+				bz, err := json.MarshalIndent(NewInfo(), "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bz))
+			case outputText:
+				cmd.Print(NewInfo().String())
+			default:
+				return fmt.Errorf("invalid %s %q, must be one of: %s, %s", flagOutput, output, outputText, outputJSON)
 			}
-			cmd.Println(string(bz))
 			return nil
 		},
 	}
+
+	cmd.Flags().StringP(flagOutput, "o", outputJSON, "output format, text or json")
+
+	return cmd
 }
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionCmd(t *testing.T) {
+	var out bytes.Buffer
+	cmd := rootCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"version"})
+	require.NoError(t, cmd.Execute())
+
+	var info Info
+	require.NoError(t, json.Unmarshal(out.Bytes(), &info))
+	require.Equal(t, features, info.Features)
+	require.NotEmpty(t, info.GoVersion)
+
+	out.Reset()
+	cmd = rootCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"version", "--output", "text"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), "features: threshold-ed25519")
+
+	out.Reset()
+	cmd = rootCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--version"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, NewInfo().String(), out.String())
+}
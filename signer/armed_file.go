@@ -0,0 +1,112 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+const defaultArmedFileName = "ARMED"
+
+var errSignerDisarmed = errors.New("signer is disarmed")
+
+// ArmedFile checks for the presence of the armed file before each signature.
+type ArmedFile struct {
+	logger cometlog.Logger
+	path   string
+
+	mu     sync.Mutex
+	armed  bool
+	logged bool
+}
+
+// NewArmedFile returns the armed file check for the config, or nil if no armed file is configured.
+// If the default state is armed, the armed file is created when it does not exist.
+func NewArmedFile(logger cometlog.Logger, config *RuntimeConfig) (*ArmedFile, error) {
+	c := config.Config.ArmedFile
+	if c == nil {
+		return nil, nil
+	}
+
+	path := c.Path
+	if path == "" {
+		path = filepath.Join(config.StateDir, defaultArmedFileName)
+	}
+
+	if c.Default == ArmedStateArmed {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if err := os.WriteFile(path, nil, 0600); err != nil {
+				return nil, fmt.Errorf("failed to create armed file: %w", err)
+			}
+			logger.Info("Created armed file", "path", path)
+		}
+	}
+
+	return &ArmedFile{logger: logger, path: path}, nil
+}
+
+// Check returns an error if the armed file does not exist. A nil ArmedFile is always armed.
+func (a *ArmedFile) Check() error {
+	if a == nil {
+		return nil
+	}
+
+	_, err := os.Stat(a.path)
+	armed := err == nil
+
+	a.mu.Lock()
+	if !a.logged || armed != a.armed {
+		if armed {
+			a.logger.Info("Signer armed", "path", a.path)
+		} else {
+			a.logger.Error("Signer disarmed, rejecting sign requests", "path", a.path)
+		}
+		a.armed = armed
+		a.logged = true
+		if armed {
+			signerArmed.Set(1)
+		} else {
+			signerArmed.Set(0)
+		}
+	}
+	a.mu.Unlock()
+
+	if !armed {
+		return fmt.Errorf("%w, create %s to resume signing", errSignerDisarmed, a.path)
+	}
+	return nil
+}
+
+var _ PrivValidator = &ArmedPrivValidator{}
+
+// ArmedPrivValidator rejects sign requests from the chain nodes while the signer is disarmed.
+// The public key can still be retrieved.
+type ArmedPrivValidator struct {
+	PrivValidator
+	armed *ArmedFile
+}
+
+func NewArmedPrivValidator(pv PrivValidator, armed *ArmedFile) *ArmedPrivValidator {
+	return &ArmedPrivValidator{PrivValidator: pv, armed: armed}
+}
+
+// SignVote implements types.PrivValidator
+func (pv *ArmedPrivValidator) SignVote(chainID string, vote *cometproto.Vote) error {
+	if err := pv.armed.Check(); err != nil {
+		return err
+	}
+	return pv.PrivValidator.SignVote(chainID, vote)
+}
+
+// SignProposal implements types.PrivValidator
+func (pv *ArmedPrivValidator) SignProposal(chainID string, proposal *cometproto.Proposal) error {
+	if err := pv.armed.Check(); err != nil {
+		return err
+	}
+	return pv.PrivValidator.SignProposal(chainID, proposal)
+}
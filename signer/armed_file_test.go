@@ -0,0 +1,89 @@
+package signer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	cometcrypto "github.com/cometbft/cometbft/crypto"
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+)
+
+type countingPrivValidator struct {
+	signs int
+}
+
+func (pv *countingPrivValidator) SignVote(string, *cometproto.Vote) error {
+	pv.signs++
+	return nil
+}
+
+func (pv *countingPrivValidator) SignProposal(string, *cometproto.Proposal) error {
+	pv.signs++
+	return nil
+}
+
+func (pv *countingPrivValidator) GetPubKey(string) (cometcrypto.PubKey, error) {
+	return nil, nil
+}
+
+func (pv *countingPrivValidator) Stop() {}
+
+func TestArmedPrivValidator(t *testing.T) {
+	stateDir := t.TempDir()
+	config := &RuntimeConfig{
+		StateDir: stateDir,
+		Config: Config{
+			ArmedFile: &ArmedFileConfig{},
+		},
+	}
+
+	armed, err := NewArmedFile(cometlog.NewNopLogger(), config)
+	require.NoError(t, err)
+
+	inner := new(countingPrivValidator)
+	pv := NewArmedPrivValidator(inner, armed)
+
+	// disarmed by default
+	require.ErrorContains(t, pv.SignVote(testChainID, new(cometproto.Vote)), "signer is disarmed")
+	require.ErrorContains(t, pv.SignProposal(testChainID, new(cometproto.Proposal)), "signer is disarmed")
+	_, err = pv.GetPubKey(testChainID)
+	require.NoError(t, err)
+	require.Zero(t, inner.signs)
+
+	armedFile := filepath.Join(stateDir, defaultArmedFileName)
+	require.NoError(t, os.WriteFile(armedFile, nil, 0600))
+
+	require.NoError(t, pv.SignVote(testChainID, new(cometproto.Vote)))
+	require.NoError(t, pv.SignProposal(testChainID, new(cometproto.Proposal)))
+	require.Equal(t, 2, inner.signs)
+
+	require.NoError(t, os.Remove(armedFile))
+	require.ErrorContains(t, pv.SignVote(testChainID, new(cometproto.Vote)), "signer is disarmed")
+	require.Equal(t, 2, inner.signs)
+}
+
+func TestArmedFileDefaultArmed(t *testing.T) {
+	armedFile := filepath.Join(t.TempDir(), "armed")
+	config := &RuntimeConfig{
+		Config: Config{
+			ArmedFile: &ArmedFileConfig{
+				Path:    armedFile,
+				Default: ArmedStateArmed,
+			},
+		},
+	}
+
+	armed, err := NewArmedFile(cometlog.NewNopLogger(), config)
+	require.NoError(t, err)
+	require.FileExists(t, armedFile)
+	require.NoError(t, armed.Check())
+
+	// no armed file configured
+	armed, err = NewArmedFile(cometlog.NewNopLogger(), &RuntimeConfig{})
+	require.NoError(t, err)
+	require.Nil(t, armed)
+	require.NoError(t, armed.Check())
+}
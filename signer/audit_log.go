@@ -0,0 +1,117 @@
+package signer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// auditRecord is the record of a sign decision in the audit log, one JSON object per line.
+type auditRecord struct {
+	Time    time.Time `json:"time"`
+	ChainID string    `json:"chain_id"`
+	Height  int64     `json:"height"`
+	Round   int64     `json:"round"`
+	Step    int8      `json:"step"`
+	// Timestamp is the timestamp of the block.
+	Timestamp time.Time `json:"timestamp"`
+	// Leader is false when the request was proxied to the raft leader, which records the shards.
+	Leader bool `json:"leader"`
+	// Shards are the shard IDs of the cosigners that signed, if the signature parts were combined.
+	Shards   []int  `json:"shards,omitempty"`
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func newAuditRecord(chainID string, block *Block) auditRecord {
+	return auditRecord{
+		ChainID:   chainID,
+		Height:    block.Height,
+		Round:     block.Round,
+		Step:      block.Step,
+		Timestamp: block.Timestamp,
+	}
+}
+
+// auditLog appends a record of every sign decision to a file, synced to disk before the decision is returned.
+// The file is opened for each record, so that it can be rotated by renaming it at any time.
+type auditLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// newAuditLog returns nil if the path is empty, in which case nothing is recorded.
+func newAuditLog(path string) *auditLog {
+	if path == "" {
+		return nil
+	}
+	return &auditLog{path: path}
+}
+
+// record writes the record with the outcome of err. A record that can not be written is logged as an error,
+// the sign decision is returned either way.
+func (l *auditLog) record(logger log.Logger, rec auditRecord, err error) {
+	if l == nil {
+		return
+	}
+
+	rec.Time = time.Now()
+	rec.Approved = err == nil
+	if err != nil {
+		rec.Reason = err.Error()
+	}
+
+	if err := l.write(rec); err != nil {
+		totalAuditLogErrors.Inc()
+		logger.Error(
+			"Failed to write sign decision to the audit log",
+			"file", l.path,
+			"chain_id", rec.ChainID,
+			"height", rec.Height,
+			"round", rec.Round,
+			"step", rec.Step,
+			"approved", rec.Approved,
+			"error", err,
+		)
+	}
+}
+
+func (l *auditLog) write(rec auditRecord) error {
+	bz, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(bz, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// AuditLogPath returns the path of the sign audit log, empty if it is disabled.
+func (c RuntimeConfig) AuditLogPath() string {
+	tc := c.Config.ThresholdModeConfig
+	if tc == nil || tc.AuditLogFile == "" {
+		return ""
+	}
+	if filepath.IsAbs(tc.AuditLogFile) {
+		return tc.AuditLogFile
+	}
+	return filepath.Join(c.HomeDir, tc.AuditLogFile)
+}
@@ -0,0 +1,115 @@
+package signer
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func readAuditLog(t *testing.T, file string) []auditRecord {
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var records []auditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec auditRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &rec))
+		records = append(records, rec)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func TestThresholdValidatorAuditLog(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	cosigners[0].config.Config.ThresholdModeConfig.AuditLogFile = "audit.log"
+	cosigners[0].config.Config.ThresholdModeConfig.BlockTimestampWindow = "10s"
+	auditFile := cosigners[0].config.AuditLogPath()
+	require.Equal(t, filepath.Join(cosigners[0].config.HomeDir, "audit.log"), auditFile)
+
+	leader := &MockLeader{id: 1}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{cosigners[1]},
+		leader,
+	)
+	defer validator.Stop()
+	defer cosigners[1].waitForSignStatesToFlushToDisk()
+
+	leader.leader = validator
+
+	err := validator.LoadSignStateIfNecessary(testChainID)
+	require.NoError(t, err)
+
+	timestamp := time.Now().UTC().Round(0)
+	proposal := cometproto.Proposal{Height: 2, Round: 1, Type: cometproto.ProposalType, Timestamp: timestamp}
+	require.NoError(t, validator.SignProposal(testChainID, &proposal))
+
+	// regresses the sign state.
+	proposal = cometproto.Proposal{Height: 1, Round: 0, Type: cometproto.ProposalType, Timestamp: timestamp}
+	require.Error(t, validator.SignProposal(testChainID, &proposal))
+
+	proposal = cometproto.Proposal{
+		Height: 3, Round: 0, Type: cometproto.ProposalType, Timestamp: timestamp.Add(-time.Hour),
+	}
+	require.ErrorIs(t, validator.SignProposal(testChainID, &proposal), errBlockTimestampOutOfWindow)
+
+	records := readAuditLog(t, auditFile)
+	require.Len(t, records, 3)
+
+	require.Equal(t, testChainID, records[0].ChainID)
+	require.Equal(t, int64(2), records[0].Height)
+	require.Equal(t, int64(1), records[0].Round)
+	require.Equal(t, stepPropose, records[0].Step)
+	require.True(t, timestamp.Equal(records[0].Timestamp))
+	require.True(t, records[0].Leader)
+	require.Equal(t, []int{1, 2}, records[0].Shards)
+	require.True(t, records[0].Approved)
+	require.Empty(t, records[0].Reason)
+
+	require.Equal(t, int64(1), records[1].Height)
+	require.False(t, records[1].Approved)
+	require.Contains(t, records[1].Reason, "Progress already started on block 2.1.1")
+	require.Empty(t, records[1].Shards)
+
+	require.Equal(t, int64(3), records[2].Height)
+	require.False(t, records[2].Approved)
+	require.Contains(t, records[2].Reason, errBlockTimestampOutOfWindow.Error())
+
+	// the audit log can be rotated by renaming it.
+	require.NoError(t, os.Rename(auditFile, auditFile+".1"))
+	proposal = cometproto.Proposal{Height: 4, Round: 0, Type: cometproto.ProposalType, Timestamp: timestamp}
+	require.NoError(t, validator.SignProposal(testChainID, &proposal))
+
+	records = readAuditLog(t, auditFile)
+	require.Len(t, records, 1)
+	require.Equal(t, int64(4), records[0].Height)
+	require.Len(t, readAuditLog(t, auditFile+".1"), 3)
+}
+
+func TestAuditLogWriteError(t *testing.T) {
+	audit := newAuditLog(filepath.Join(t.TempDir(), "missing", "audit.log"))
+
+	writeErrors := testutil.ToFloat64(totalAuditLogErrors.vec.prom.WithLabelValues())
+	audit.record(cometlog.NewNopLogger(), auditRecord{ChainID: testChainID}, nil)
+	require.Equal(t, writeErrors+1, testutil.ToFloat64(totalAuditLogErrors.vec.prom.WithLabelValues()))
+
+	// disabled.
+	newAuditLog("").record(cometlog.NewNopLogger(), auditRecord{ChainID: testChainID}, nil)
+}
@@ -0,0 +1,224 @@
+package signer
+
+import (
+	"crypto/tls"
+	"sync"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	cometservice "github.com/cometbft/cometbft/libs/service"
+)
+
+const (
+	// backupChainNodeFailoverDelay is how long all primary chain nodes must be unreachable
+	// before the backup chain nodes are connected to.
+	backupChainNodeFailoverDelay = 10 * time.Second
+
+	chainNodeFailoverCheckInterval = time.Second
+)
+
+var _ cometservice.Service = &ChainNodeFailover{}
+
+// ChainNodeFailover connects to the backup chain nodes only while none of the primary chain nodes are connected,
+// so that the signer does not normally split its traffic across high latency links to the backups.
+type ChainNodeFailover struct {
+	cometservice.BaseService
+
+	privVal     PrivValidator
+	primaries   []*ReconnRemoteSigner
+	backupNodes []string
+	tlsConfig   *tls.Config
+
+	// ordered connects to one backup chain node at a time, in the configured order.
+	ordered bool
+
+	mu                   sync.Mutex
+	backups              []*ReconnRemoteSigner
+	lastPrimaryConnected time.Time
+
+	// backupIndex and lastBackupConnected are the backup chain node in use and when it was last connected,
+	// if ordered.
+	backupIndex         int
+	lastBackupConnected time.Time
+
+	quit chan struct{}
+}
+
+func NewChainNodeFailover(
+	logger cometlog.Logger,
+	privVal PrivValidator,
+	primaries []*ReconnRemoteSigner,
+	backupNodes []string,
+) *ChainNodeFailover {
+	f := &ChainNodeFailover{
+		// the backup remote signers are stopped on failback, which must not stop the shared privVal.
+		privVal:     noStopPrivValidator{privVal},
+		primaries:   primaries,
+		backupNodes: backupNodes,
+		quit:        make(chan struct{}),
+	}
+	f.BaseService = *cometservice.NewBaseService(logger, "ChainNodeFailover", f)
+	return f
+}
+
+// SetTLSConfig wraps the tcp connections to the backup chain nodes in TLS. It must be called before
+// the failover is started.
+func (f *ChainNodeFailover) SetTLSConfig(tlsConfig *tls.Config) {
+	f.tlsConfig = tlsConfig
+}
+
+// SetOrdered makes the failover connect to a single backup chain node at a time, starting with the first,
+// and move on to the next one once it has been unreachable for the failover delay. It must be called
+// before the failover is started.
+func (f *ChainNodeFailover) SetOrdered(ordered bool) {
+	f.ordered = ordered
+}
+
+// OnStart gives the primary chain nodes the failover delay to connect before failing over.
+func (f *ChainNodeFailover) OnStart() error {
+	f.lastPrimaryConnected = time.Now()
+
+	go func() {
+		ticker := time.NewTicker(chainNodeFailoverCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-f.quit:
+				return
+			case now := <-ticker.C:
+				f.check(now)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (f *ChainNodeFailover) OnStop() {
+	close(f.quit)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopBackups()
+}
+
+// FailedOver returns true while the backup chain nodes are in use.
+func (f *ChainNodeFailover) FailedOver() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.backups != nil
+}
+
+func (f *ChainNodeFailover) primaryConnected() bool {
+	for _, p := range f.primaries {
+		if p.connected.Load() {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *ChainNodeFailover) check(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.primaryConnected() {
+		f.lastPrimaryConnected = now
+		if f.backups != nil {
+			f.Logger.Info("Primary chain node reconnected, disconnecting from backup chain nodes")
+			f.stopBackups()
+		}
+		return
+	}
+
+	if f.backups != nil {
+		if f.ordered {
+			f.checkOrderedBackup(now)
+		}
+		return
+	}
+
+	if now.Sub(f.lastPrimaryConnected) < backupChainNodeFailoverDelay {
+		return
+	}
+
+	f.Logger.Error(
+		"All primary chain nodes are unreachable, failing over to backup chain nodes",
+		"unreachable_for", now.Sub(f.lastPrimaryConnected).Round(time.Second),
+		"backup_nodes", f.backupNodes,
+	)
+	totalChainNodeFailovers.Inc()
+
+	if f.ordered {
+		f.backupIndex = 0
+		f.startOrderedBackup(now)
+		return
+	}
+
+	f.backups = make([]*ReconnRemoteSigner, 0, len(f.backupNodes))
+	for _, node := range f.backupNodes {
+		s := newChainNodeRemoteSigner(node, f.Logger, f.privVal, f.tlsConfig)
+		if err := s.Start(); err != nil {
+			f.Logger.Error("Failed to start remote signer for backup chain node", "address", node, "err", err)
+			continue
+		}
+		f.backups = append(f.backups, s)
+	}
+}
+
+// checkOrderedBackup moves on to the next backup chain node once the one in use has been unreachable for the
+// failover delay. It must be called with the mutex held.
+func (f *ChainNodeFailover) checkOrderedBackup(now time.Time) {
+	if len(f.backups) > 0 && f.backups[0].connected.Load() {
+		f.lastBackupConnected = now
+		return
+	}
+
+	if now.Sub(f.lastBackupConnected) < backupChainNodeFailoverDelay {
+		return
+	}
+
+	next := (f.backupIndex + 1) % len(f.backupNodes)
+	f.Logger.Error(
+		"Backup chain node is unreachable, failing over to the next backup chain node",
+		"address", f.backupNodes[f.backupIndex],
+		"unreachable_for", now.Sub(f.lastBackupConnected).Round(time.Second),
+		"next", f.backupNodes[next],
+	)
+	f.stopBackups()
+	f.backupIndex = next
+	f.startOrderedBackup(now)
+}
+
+// startOrderedBackup connects to the backup chain node at the backup index, giving it the failover delay
+// to connect. It must be called with the mutex held.
+func (f *ChainNodeFailover) startOrderedBackup(now time.Time) {
+	node := f.backupNodes[f.backupIndex]
+	f.lastBackupConnected = now
+	f.backups = make([]*ReconnRemoteSigner, 0, 1)
+
+	f.Logger.Info("Connecting to backup chain node", "address", node)
+	s := newChainNodeRemoteSigner(node, f.Logger, f.privVal, f.tlsConfig)
+	if err := s.Start(); err != nil {
+		f.Logger.Error("Failed to start remote signer for backup chain node", "address", node, "err", err)
+		return
+	}
+	f.backups = append(f.backups, s)
+}
+
+// stopBackups must be called with the mutex held.
+func (f *ChainNodeFailover) stopBackups() {
+	for _, s := range f.backups {
+		if err := s.Stop(); err != nil {
+			f.Logger.Error("Failed to stop remote signer for backup chain node", "address", s.address, "err", err)
+		}
+	}
+	f.backups = nil
+}
+
+// noStopPrivValidator leaves the underlying PrivValidator running when stopped.
+type noStopPrivValidator struct {
+	PrivValidator
+}
+
+func (noStopPrivValidator) Stop() {}
@@ -0,0 +1,99 @@
+package signer
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainNodeFailover(t *testing.T) {
+	logger := cometlog.NewNopLogger()
+	privVal := new(countingPrivValidator)
+
+	primary := NewReconnRemoteSigner("tcp://127.0.0.1:1", logger, privVal, net.Dialer{})
+	// nothing is listening on the backup address, the backup remote signer keeps retrying until stopped.
+	f := NewChainNodeFailover(logger, privVal, []*ReconnRemoteSigner{primary}, []string{"tcp://127.0.0.1:1"})
+
+	start := time.Now()
+	f.lastPrimaryConnected = start
+
+	// the primary is given the failover delay to connect.
+	f.check(start.Add(backupChainNodeFailoverDelay - time.Second))
+	require.False(t, f.FailedOver())
+
+	f.check(start.Add(backupChainNodeFailoverDelay))
+	require.True(t, f.FailedOver())
+	require.Len(t, f.backups, 1)
+	backup := f.backups[0]
+	require.True(t, backup.IsRunning())
+
+	// failing over again is a no-op while the backups are in use.
+	f.check(start.Add(2 * backupChainNodeFailoverDelay))
+	require.Equal(t, []*ReconnRemoteSigner{backup}, f.backups)
+
+	primary.connected.Store(true)
+	f.check(start.Add(3 * backupChainNodeFailoverDelay))
+	require.False(t, f.FailedOver())
+	require.False(t, backup.IsRunning())
+
+	// the primary disconnecting again restarts the failover delay.
+	primary.connected.Store(false)
+	f.check(start.Add(4*backupChainNodeFailoverDelay - time.Second))
+	require.False(t, f.FailedOver())
+}
+
+func TestChainNodeFailoverOrdered(t *testing.T) {
+	logger := cometlog.NewNopLogger()
+	privVal := new(countingPrivValidator)
+
+	primary := NewReconnRemoteSigner("tcp://127.0.0.1:1", logger, privVal, net.Dialer{})
+	backupNodes := []string{"tcp://127.0.0.1:2", "tcp://127.0.0.1:3"}
+	f := NewChainNodeFailover(logger, privVal, []*ReconnRemoteSigner{primary}, backupNodes)
+	f.SetOrdered(true)
+	defer f.stopBackups()
+
+	start := time.Now()
+	f.lastPrimaryConnected = start
+
+	// only the first backup is connected to.
+	f.check(start.Add(backupChainNodeFailoverDelay))
+	require.Len(t, f.backups, 1)
+	first := f.backups[0]
+	require.Equal(t, backupNodes[0], first.address)
+
+	// the backup in use is given the failover delay to connect.
+	f.check(start.Add(2*backupChainNodeFailoverDelay - time.Second))
+	require.Equal(t, []*ReconnRemoteSigner{first}, f.backups)
+
+	first.setConnected(true)
+	f.check(start.Add(3 * backupChainNodeFailoverDelay))
+	require.Equal(t, []*ReconnRemoteSigner{first}, f.backups)
+	require.Equal(t, 1.0, testutil.ToFloat64(chainNodeConnected.prom.WithLabelValues(backupNodes[0])))
+
+	// once it has been unreachable for the failover delay, the next backup is connected to, wrapping around.
+	first.setConnected(false)
+	f.check(start.Add(4 * backupChainNodeFailoverDelay))
+	require.Len(t, f.backups, 1)
+	require.Equal(t, backupNodes[1], f.backups[0].address)
+	require.False(t, first.IsRunning())
+
+	// a primary reconnecting stops the backups, and failing over again starts with the first backup.
+	primary.setConnected(true)
+	f.check(start.Add(5 * backupChainNodeFailoverDelay))
+	require.False(t, f.FailedOver())
+
+	primary.setConnected(false)
+	f.check(start.Add(6 * backupChainNodeFailoverDelay))
+	require.Len(t, f.backups, 1)
+	require.Equal(t, backupNodes[0], f.backups[0].address)
+
+	// the next backup wraps around to the first.
+	f.check(start.Add(7 * backupChainNodeFailoverDelay))
+	require.Equal(t, backupNodes[1], f.backups[0].address)
+	f.check(start.Add(8 * backupChainNodeFailoverDelay))
+	require.Equal(t, backupNodes[0], f.backups[0].address)
+}
@@ -0,0 +1,63 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cometrpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	cometrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+)
+
+// ChainNodeHeight queries the RPC endpoints of the chain nodes and returns the highest
+// latest block height reported by a node running chainID.
+func ChainNodeHeight(ctx context.Context, nodes ChainNodes, chainID string) (int64, error) {
+	var (
+		height int64
+		found  bool
+		errs   []error
+	)
+	for _, node := range nodes {
+		if node.RPCAddr == "" {
+			continue
+		}
+
+		status, err := queryChainNodeStatus(ctx, node.RPCAddr)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if status.NodeInfo.Network != chainID {
+			continue
+		}
+
+		found = true
+		if status.SyncInfo.LatestBlockHeight > height {
+			height = status.SyncInfo.LatestBlockHeight
+		}
+	}
+
+	if !found {
+		if len(errs) > 0 {
+			return 0, fmt.Errorf("no chain node reported a height for chain %s: %w", chainID, errors.Join(errs...))
+		}
+		return 0, fmt.Errorf("no chain node with rpcAddr configured is running chain %s", chainID)
+	}
+
+	return height, nil
+}
+
+// queryChainNodeStatus queries the status of the chain node at the CometBFT RPC address.
+func queryChainNodeStatus(ctx context.Context, rpcAddr string) (*cometrpctypes.ResultStatus, error) {
+	client, err := cometrpchttp.New(rpcAddr, "/websocket")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc client for %s: %w", rpcAddr, err)
+	}
+
+	status, err := client.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status of %s: %w", rpcAddr, err)
+	}
+	return status, nil
+}
@@ -0,0 +1,69 @@
+package signer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ChainNodeTLSConfig wraps the tcp privval connections to the chain nodes in TLS, for chain nodes that are
+// reached through a TLS terminating proxy in front of their privval listener, e.g. across an untrusted network.
+// The CometBFT secret connection is still made inside of TLS. Relative paths are relative to the home directory.
+type ChainNodeTLSConfig struct {
+	// CAFile is the CA that the certificates of the chain nodes must be signed by.
+	CAFile string `yaml:"caFile"`
+	// CertFile and KeyFile are the optional client certificate presented to the chain nodes.
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+	// ServerName is the name verified in the certificates of the chain nodes.
+	// Defaults to the host of their privValAddr.
+	ServerName string `yaml:"serverName,omitempty"`
+}
+
+func (c *ChainNodeTLSConfig) Validate() error {
+	var errs []error
+	if c.CAFile == "" {
+		errs = append(errs, fmt.Errorf("chainNodeTLS caFile is required"))
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		errs = append(errs, fmt.Errorf("chainNodeTLS certFile and keyFile must be configured together"))
+	}
+	return errors.Join(errs...)
+}
+
+// ChainNodeTLSConfig returns the TLS config of the privval connections to the chain nodes,
+// or nil if chainNodeTLS is not configured.
+func (c RuntimeConfig) ChainNodeTLSConfig() (*tls.Config, error) {
+	tc := c.Config.ChainNodeTLS
+	if tc == nil {
+		return nil, nil
+	}
+
+	caFile := c.cosignerTLSPath(tc.CAFile)
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain node tls ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in chain node tls ca %s", caFile)
+	}
+
+	cfg := &tls.Config{
+		RootCAs:    pool,
+		ServerName: tc.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if tc.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.cosignerTLSPath(tc.CertFile), c.cosignerTLSPath(tc.KeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load chain node tls certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,153 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+const (
+	// defaultClockSkewThreshold is used when the clockSkewThreshold is not configured.
+	defaultClockSkewThreshold = 500 * time.Millisecond
+
+	clockSkewCheckInterval = 30 * time.Second
+)
+
+// clockPeer is a cosigner that reports how far its clock is from ours.
+type clockPeer interface {
+	GetID() int
+	GetAddress() string
+	ClockOffset(ctx context.Context) (time.Duration, error)
+}
+
+var _ service.Service = &ClockSkewMonitor{}
+
+// ClockSkewMonitor periodically compares the clocks of the cosigners, since threshold signing is
+// sensitive to clock skew, e.g. with proposer-based timestamps. The skew is reported before it
+// causes sign requests to fail.
+type ClockSkewMonitor struct {
+	service.BaseService
+
+	peers     []clockPeer
+	threshold time.Duration
+
+	quit chan struct{}
+}
+
+// NewClockSkewMonitor returns a monitor of the clock skew between this cosigner and its remote peers.
+func NewClockSkewMonitor(logger log.Logger, config *ThresholdModeConfig, peers []Cosigner) *ClockSkewMonitor {
+	threshold := defaultClockSkewThreshold
+	if config != nil && config.ClockSkewThreshold != "" {
+		// Validated prior in ValidateThresholdModeConfig
+		if d, err := time.ParseDuration(config.ClockSkewThreshold); err == nil {
+			threshold = d
+		}
+	}
+
+	m := &ClockSkewMonitor{
+		threshold: threshold,
+		quit:      make(chan struct{}),
+	}
+	for _, p := range peers {
+		if cp, ok := p.(clockPeer); ok {
+			m.peers = append(m.peers, cp)
+		}
+	}
+	m.BaseService = *service.NewBaseService(logger, "ClockSkewMonitor", m)
+	return m
+}
+
+func (m *ClockSkewMonitor) OnStart() error {
+	go m.loop()
+	return nil
+}
+
+func (m *ClockSkewMonitor) OnStop() {
+	close(m.quit)
+}
+
+func (m *ClockSkewMonitor) loop() {
+	ticker := time.NewTicker(clockSkewCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.quit:
+			return
+		case <-ticker.C:
+			m.check(context.Background())
+		}
+	}
+}
+
+// check returns the largest skew between the clocks of any two cosigners that respond, including ours.
+func (m *ClockSkewMonitor) check(ctx context.Context) time.Duration {
+	// the offsets are relative to our clock.
+	offsets := map[int]time.Duration{}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, p := range m.peers {
+		wg.Add(1)
+		go func(p clockPeer) {
+			defer wg.Done()
+			offset, err := p.ClockOffset(ctx)
+			if err != nil {
+				m.Logger.Debug("Failed to get cosigner clock", "cosigner", p.GetID(), "error", err)
+				return
+			}
+			cosignerClockOffset.WithLabelValues(p.GetAddress()).Set(offset.Seconds())
+			mu.Lock()
+			offsets[p.GetID()] = offset
+			mu.Unlock()
+		}(p)
+	}
+	wg.Wait()
+
+	var minOffset, maxOffset time.Duration
+	for _, offset := range offsets {
+		if offset < minOffset {
+			minOffset = offset
+		}
+		if offset > maxOffset {
+			maxOffset = offset
+		}
+	}
+	skew := maxOffset - minOffset
+	cosignerClockSkew.Set(skew.Seconds())
+
+	if skew > m.threshold {
+		m.Logger.Error(
+			"Clock skew between cosigners exceeds threshold, check that their clocks are synchronized with NTP",
+			"skew", skew,
+			"threshold", m.threshold,
+			"offsets", formatClockOffsets(offsets),
+		)
+	}
+	return skew
+}
+
+// formatClockOffsets formats the clock offsets of the peers by shard ID, e.g. "2:+120ms 3:-1.5s".
+func formatClockOffsets(offsets map[int]time.Duration) string {
+	ids := make([]int, 0, len(offsets))
+	for id := range offsets {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		sign := "+"
+		if offsets[id] < 0 {
+			sign = ""
+		}
+		s[i] = fmt.Sprintf("%d:%s%s", id, sign, offsets[id])
+	}
+	return strings.Join(s, " ")
+}
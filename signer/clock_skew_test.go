@@ -0,0 +1,88 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type clockTestCosigner struct {
+	Cosigner
+	id     int
+	offset time.Duration
+	err    error
+}
+
+func (c *clockTestCosigner) GetID() int {
+	return c.id
+}
+
+func (c *clockTestCosigner) GetAddress() string {
+	return "tcp://cosigner"
+}
+
+func (c *clockTestCosigner) ClockOffset(context.Context) (time.Duration, error) {
+	return c.offset, c.err
+}
+
+func TestClockSkewMonitorCheck(t *testing.T) {
+	peers := []Cosigner{
+		&clockTestCosigner{id: 2, offset: 100 * time.Millisecond},
+		&clockTestCosigner{id: 3, offset: -300 * time.Millisecond},
+		&clockTestCosigner{id: 4, err: errors.New("unreachable")},
+	}
+
+	m := NewClockSkewMonitor(cometlog.NewNopLogger(), &ThresholdModeConfig{ClockSkewThreshold: "1s"}, peers)
+	require.Equal(t, time.Second, m.threshold)
+	require.Len(t, m.peers, 3)
+
+	// the skew is between the peers, not only against our clock.
+	require.Equal(t, 400*time.Millisecond, m.check(context.Background()))
+
+	// our clock counts as well when all peers are ahead.
+	m.peers = m.peers[:1]
+	require.Equal(t, 100*time.Millisecond, m.check(context.Background()))
+
+	// unreachable peers are ignored.
+	m.peers = []clockPeer{&clockTestCosigner{id: 4, err: errors.New("unreachable")}}
+	require.Zero(t, m.check(context.Background()))
+}
+
+func TestNewClockSkewMonitorDefaultThreshold(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+
+	// local cosigners can not report their clock.
+	m := NewClockSkewMonitor(cometlog.NewNopLogger(), nil, []Cosigner{cosigners[1], NewRemoteCosigner(3, "tcp://x:2222")})
+	require.Equal(t, defaultClockSkewThreshold, m.threshold)
+	require.Len(t, m.peers, 1)
+}
+
+func TestFormatClockOffsets(t *testing.T) {
+	require.Equal(t, "2:+120ms 3:-1.5s", formatClockOffsets(map[int]time.Duration{
+		3: -1500 * time.Millisecond,
+		2: 120 * time.Millisecond,
+	}))
+}
+
+func TestRemoteCosignerClockOffset(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	proto.RegisterCosignerGRPCServer(server, NewGRPCServer(cosigners[1], nil, nil))
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	offset, err := NewRemoteCosigner(2, "tcp://"+lis.Addr().String()).ClockOffset(context.Background())
+	require.NoError(t, err)
+	// both run on the same clock.
+	require.Less(t, offset.Abs(), 100*time.Millisecond)
+}
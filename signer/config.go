@@ -1,11 +1,14 @@
 package signer
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cometbft/cometbft/crypto"
@@ -33,7 +36,105 @@ type Config struct {
 	SignMode            SignMode             `yaml:"signMode"`
 	ThresholdModeConfig *ThresholdModeConfig `yaml:"thresholdMode,omitempty"`
 	ChainNodes          ChainNodes           `yaml:"chainNodes"`
-	DebugAddr           string               `yaml:"debugAddr"`
+	// BackupChainNodes are only connected to while none of the ChainNodes are reachable.
+	BackupChainNodes ChainNodes `yaml:"backupChainNodes,omitempty"`
+	// BackupChainNodesOrdered connects to one backup chain node at a time, in order, instead of all of them.
+	BackupChainNodesOrdered bool `yaml:"backupChainNodesOrdered,omitempty"`
+	// ChainNodeTLS is disabled by default, in which case the tcp privval connections to the chain nodes
+	// are only secured by the CometBFT secret connection.
+	ChainNodeTLS *ChainNodeTLSConfig `yaml:"chainNodeTLS,omitempty"`
+	DebugAddr    string              `yaml:"debugAddr"`
+	Tracing      *TracingConfig      `yaml:"tracing,omitempty"`
+	// MetricsBackend defaults to MetricsBackendPrometheus, served on the DebugAddr.
+	MetricsBackend MetricsBackend `yaml:"metricsBackend,omitempty"`
+	StatsdAddr     string         `yaml:"statsdAddr,omitempty"`
+	// HomeDirPermissions defaults to HomeDirPermissionsWarn.
+	HomeDirPermissions HomeDirPermissionsAction `yaml:"homeDirPermissions,omitempty"`
+	// StateDirMinFreeMB is the free space of the state directory below which the signer reports
+	// that it is not ready. Defaults to 100.
+	StateDirMinFreeMB int `yaml:"stateDirMinFreeMB,omitempty"`
+	// ArmedFile is disabled by default, in which case the signer is always armed.
+	ArmedFile *ArmedFileConfig `yaml:"armedFile,omitempty"`
+	// DNSResolver is the IP address, with an optional port, of the DNS server used to resolve
+	// the cosigner and chain node hostnames. Defaults to the system resolver.
+	DNSResolver string `yaml:"dnsResolver,omitempty"`
+	// BlockProtocolVersions are the CometBFT block protocol versions the signer signs for. If set, sign requests
+	// are refused while a chain node reports another version on its rpcAddr. Disabled by default.
+	BlockProtocolVersions []uint64 `yaml:"blockProtocolVersions,omitempty"`
+	// VaultTransit is only used for key shards encrypted with Vault transit.
+	VaultTransit *VaultTransitConfig `yaml:"vaultTransit,omitempty"`
+}
+
+// StateDirMinFreeBytes returns the configured minimum free space of the state directory.
+func (c *Config) StateDirMinFreeBytes() uint64 {
+	mb := c.StateDirMinFreeMB
+	if mb <= 0 {
+		mb = defaultStateDirMinFreeMB
+	}
+	return uint64(mb) * 1e6
+}
+
+// DNSResolverAddr returns the configured DNS resolver as host:port, using port 53 if none is configured.
+func (c *Config) DNSResolverAddr() string {
+	if _, _, err := net.SplitHostPort(c.DNSResolver); err == nil {
+		return c.DNSResolver
+	}
+	return net.JoinHostPort(c.DNSResolver, defaultDNSPort)
+}
+
+// HomeDirPermissionsAction is the action taken at startup when the home directory is accessible by group or other.
+type HomeDirPermissionsAction string
+
+const (
+	// HomeDirPermissionsWarn logs a warning and starts anyway.
+	HomeDirPermissionsWarn HomeDirPermissionsAction = "warn"
+	// HomeDirPermissionsRefuse refuses to start.
+	HomeDirPermissionsRefuse HomeDirPermissionsAction = "refuse"
+)
+
+// ArmedFileConfig requires a file to be present for the signer to sign. Removing the file
+// disarms the signer as a break-glass measure, without stopping the process.
+type ArmedFileConfig struct {
+	// Path defaults to ARMED in the state directory.
+	Path string `yaml:"path,omitempty"`
+	// Default defaults to ArmedStateDisarmed.
+	Default ArmedState `yaml:"default,omitempty"`
+}
+
+// ArmedState is the state of the signer when the armed file does not exist at startup.
+type ArmedState string
+
+const (
+	// ArmedStateArmed creates the armed file at startup if it does not exist.
+	ArmedStateArmed ArmedState = "armed"
+	// ArmedStateDisarmed leaves the signer disarmed until the armed file is created.
+	ArmedStateDisarmed ArmedState = "disarmed"
+)
+
+func (c *ArmedFileConfig) Validate() error {
+	switch c.Default {
+	case "", ArmedStateArmed, ArmedStateDisarmed:
+		return nil
+	default:
+		return fmt.Errorf("invalid armedFile default %q, must be one of: %s, %s",
+			c.Default, ArmedStateArmed, ArmedStateDisarmed)
+	}
+}
+
+// TracingConfig enables exporting OpenTelemetry spans for the sign pipeline to an OTLP (gRPC) collector.
+type TracingConfig struct {
+	OTLPEndpoint string `yaml:"otlpEndpoint"`
+	Insecure     bool   `yaml:"insecure,omitempty"`
+}
+
+func (c *TracingConfig) Validate() error {
+	if c.OTLPEndpoint == "" {
+		return fmt.Errorf("tracing otlpEndpoint is required when tracing is configured")
+	}
+	if _, _, err := net.SplitHostPort(c.OTLPEndpoint); err != nil {
+		return fmt.Errorf("invalid tracing otlpEndpoint (%s): %w", c.OTLPEndpoint, err)
+	}
+	return nil
 }
 
 func (c *Config) Nodes() (out []string) {
@@ -43,6 +144,13 @@ func (c *Config) Nodes() (out []string) {
 	return out
 }
 
+func (c *Config) BackupNodes() (out []string) {
+	for _, n := range c.BackupChainNodes {
+		out = append(out, n.PrivValAddr)
+	}
+	return out
+}
+
 func (c *Config) MustMarshalYaml() []byte {
 	out, err := yaml.Marshal(c)
 	if err != nil {
@@ -52,50 +160,303 @@ func (c *Config) MustMarshalYaml() []byte {
 }
 
 func (c *Config) ValidateSingleSignerConfig() error {
+	return firstError(c.singleSignerConfigErrors())
+}
+
+func (c *Config) ValidateThresholdModeConfig() error {
+	return firstError(c.thresholdModeConfigErrors())
+}
+
+// ValidateAll checks the config against every validation rule for its sign mode,
+// rather than stopping at the first failure. All failures are joined in the returned error.
+func (c *Config) ValidateAll() error {
+	switch c.SignMode {
+	case SignModeThreshold:
+		return errors.Join(c.thresholdModeConfigErrors()...)
+	case SignModeSingle:
+		return errors.Join(c.singleSignerConfigErrors()...)
+	default:
+		return fmt.Errorf("invalid signMode %q, must be one of: %s, %s", c.SignMode, SignModeThreshold, SignModeSingle)
+	}
+}
+
+func (c *Config) singleSignerConfigErrors() (errs []error) {
 	if len(c.ChainNodes) == 0 {
-		return fmt.Errorf("need to have chainNodes configured for priv-val connection")
+		errs = append(errs, fmt.Errorf("need to have chainNodes configured for priv-val connection"))
 	}
-	if err := c.ChainNodes.Validate(); err != nil {
-		return err
+	errs = append(errs, c.ChainNodes.validationErrors()...)
+	errs = append(errs, c.backupChainNodesErrors()...)
+
+	switch c.HomeDirPermissions {
+	case "", HomeDirPermissionsWarn, HomeDirPermissionsRefuse:
+	default:
+		errs = append(errs, fmt.Errorf("invalid homeDirPermissions %q, must be one of: %s, %s",
+			c.HomeDirPermissions, HomeDirPermissionsWarn, HomeDirPermissionsRefuse))
+	}
+
+	if c.StateDirMinFreeMB < 0 {
+		errs = append(errs, fmt.Errorf("stateDirMinFreeMB (%d) must be 0 or greater", c.StateDirMinFreeMB))
+	}
+
+	if c.ArmedFile != nil {
+		if err := c.ArmedFile.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.ChainNodeTLS != nil {
+		if err := c.ChainNodeTLS.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.VaultTransit != nil {
+		if err := c.VaultTransit.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.DNSResolver != "" {
+		host, _, err := net.SplitHostPort(c.DNSResolverAddr())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid dnsResolver (%s): %w", c.DNSResolver, err))
+		} else if net.ParseIP(host) == nil {
+			errs = append(errs, fmt.Errorf("invalid dnsResolver (%s): must be an IP address", c.DNSResolver))
+		}
+	}
+
+	if len(c.BlockProtocolVersions) > 0 && !c.hasChainNodeRPCAddr() {
+		errs = append(errs, fmt.Errorf("blockProtocolVersions requires a chain node with rpcAddr configured"))
 	}
-	return c.ChainNodes.Validate()
+
+	return append(errs, c.metricsConfigErrors()...)
 }
 
-func (c *Config) ValidateThresholdModeConfig() error {
-	if err := c.ValidateSingleSignerConfig(); err != nil {
-		return err
+func (c *Config) hasChainNodeRPCAddr() bool {
+	for _, cn := range append(append(ChainNodes{}, c.ChainNodes...), c.BackupChainNodes...) {
+		if cn.RPCAddr != "" {
+			return true
+		}
 	}
+	return false
+}
+
+func (c *Config) backupChainNodesErrors() (errs []error) {
+	for _, cn := range c.BackupChainNodes {
+		if err := cn.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid backup chain node: %w", err))
+		}
+	}
+
+	primaries := make(map[string]bool, len(c.ChainNodes))
+	for _, cn := range c.ChainNodes {
+		primaries[normalizeChainNodeAddr(cn.PrivValAddr)] = true
+	}
+	for _, cn := range c.BackupChainNodes {
+		if primaries[normalizeChainNodeAddr(cn.PrivValAddr)] {
+			errs = append(errs, fmt.Errorf("chain node %s is configured as both a primary and a backup", cn.PrivValAddr))
+		}
+	}
+
+	return errs
+}
+
+func (c *Config) metricsConfigErrors() (errs []error) {
+	switch c.MetricsBackend {
+	case "", MetricsBackendPrometheus:
+	case MetricsBackendStatsd:
+		if _, _, err := net.SplitHostPort(c.StatsdAddr); err != nil {
+			errs = append(errs, fmt.Errorf("invalid statsdAddr (%s): %w", c.StatsdAddr, err))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid metricsBackend %q, must be one of: %s, %s",
+			c.MetricsBackend, MetricsBackendPrometheus, MetricsBackendStatsd))
+	}
+	return errs
+}
+
+func (c *Config) thresholdModeConfigErrors() []error {
+	errs := c.singleSignerConfigErrors()
 
 	if c.ThresholdModeConfig == nil {
-		return fmt.Errorf("cosigner config can't be empty")
 		// the rest of the checks depend on non-nil c.ThresholdModeConfig
+		return append(errs, fmt.Errorf("cosigner config can't be empty"))
+	}
+
+	if c.Tracing != nil {
+		if err := c.Tracing.Validate(); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
 	numShards := len(c.ThresholdModeConfig.Cosigners)
 
 	if c.ThresholdModeConfig.Threshold <= numShards/2 {
-		return fmt.Errorf("threshold (%d) must be greater than number of shards (%d) / 2",
-			c.ThresholdModeConfig.Threshold, numShards)
+		errs = append(errs, fmt.Errorf("threshold (%d) must be greater than number of shards (%d) / 2",
+			c.ThresholdModeConfig.Threshold, numShards))
 	}
 
 	if numShards < c.ThresholdModeConfig.Threshold {
-		return fmt.Errorf("number of shards (%d) must be greater or equal to threshold (%d)",
-			numShards, c.ThresholdModeConfig.Threshold)
+		errs = append(errs, fmt.Errorf("number of shards (%d) must be greater or equal to threshold (%d)",
+			numShards, c.ThresholdModeConfig.Threshold))
 	}
 
 	if _, err := time.ParseDuration(c.ThresholdModeConfig.RaftTimeout); err != nil {
-		return fmt.Errorf("invalid raftTimeout: %w", err)
+		errs = append(errs, fmt.Errorf("invalid raftTimeout: %w", err))
 	}
 
 	if _, err := time.ParseDuration(c.ThresholdModeConfig.GRPCTimeout); err != nil {
-		return fmt.Errorf("invalid grpcTimeout: %w", err)
+		errs = append(errs, fmt.Errorf("invalid grpcTimeout: %w", err))
 	}
 
-	if err := c.ThresholdModeConfig.Cosigners.Validate(); err != nil {
-		return err
+	if c.ThresholdModeConfig.PeerClockTolerance != "" {
+		if _, err := time.ParseDuration(c.ThresholdModeConfig.PeerClockTolerance); err != nil {
+			errs = append(errs, fmt.Errorf("invalid peerClockTolerance: %w", err))
+		}
+	}
+
+	if c.ThresholdModeConfig.NonceExpiration != "" {
+		if d, err := time.ParseDuration(c.ThresholdModeConfig.NonceExpiration); err != nil {
+			errs = append(errs, fmt.Errorf("invalid nonceExpiration: %w", err))
+		} else if d <= 0 {
+			errs = append(errs, fmt.Errorf("nonceExpiration (%s) must be greater than 0", d))
+		}
+	}
+
+	if c.ThresholdModeConfig.NonceCacheSize < 0 {
+		errs = append(errs, fmt.Errorf("nonceCacheSize (%d) must not be negative", c.ThresholdModeConfig.NonceCacheSize))
+	}
+
+	if c.ThresholdModeConfig.NoncePoolSize < 0 {
+		errs = append(errs, fmt.Errorf("noncePoolSize (%d) must not be negative", c.ThresholdModeConfig.NoncePoolSize))
+	}
+
+	if c.ThresholdModeConfig.PeerTimeout != "" {
+		if d, err := time.ParseDuration(c.ThresholdModeConfig.PeerTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("invalid peerTimeout: %w", err))
+		} else if d <= 0 {
+			errs = append(errs, fmt.Errorf("peerTimeout (%s) must be greater than 0", d))
+		}
+	}
+
+	if c.ThresholdModeConfig.RaftApplyLatencyThreshold != "" {
+		if d, err := time.ParseDuration(c.ThresholdModeConfig.RaftApplyLatencyThreshold); err != nil {
+			errs = append(errs, fmt.Errorf("invalid raftApplyLatencyThreshold: %w", err))
+		} else if d <= 0 {
+			errs = append(errs, fmt.Errorf("raftApplyLatencyThreshold (%s) must be greater than 0", d))
+		}
+	}
+
+	if c.ThresholdModeConfig.BlockTimestampWindow != "" {
+		if d, err := time.ParseDuration(c.ThresholdModeConfig.BlockTimestampWindow); err != nil {
+			errs = append(errs, fmt.Errorf("invalid blockTimestampWindow: %w", err))
+		} else if d <= 0 {
+			errs = append(errs, fmt.Errorf("blockTimestampWindow (%s) must be greater than 0", d))
+		}
+	}
+
+	if c.ThresholdModeConfig.SignDeadline != "" {
+		if d, err := time.ParseDuration(c.ThresholdModeConfig.SignDeadline); err != nil {
+			errs = append(errs, fmt.Errorf("invalid signDeadline: %w", err))
+		} else if d <= 0 {
+			errs = append(errs, fmt.Errorf("signDeadline (%s) must be greater than 0", d))
+		}
+	}
+
+	if c.ThresholdModeConfig.ClockSkewThreshold != "" {
+		if d, err := time.ParseDuration(c.ThresholdModeConfig.ClockSkewThreshold); err != nil {
+			errs = append(errs, fmt.Errorf("invalid clockSkewThreshold: %w", err))
+		} else if d <= 0 {
+			errs = append(errs, fmt.Errorf("clockSkewThreshold (%s) must be greater than 0", d))
+		}
+	}
+
+	if c.ThresholdModeConfig.ShutdownDrainTimeout != "" {
+		if d, err := time.ParseDuration(c.ThresholdModeConfig.ShutdownDrainTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("invalid shutdownDrainTimeout: %w", err))
+		} else if d <= 0 {
+			errs = append(errs, fmt.Errorf("shutdownDrainTimeout (%s) must be greater than 0", d))
+		}
+	}
+
+	if c.ThresholdModeConfig.LeaderRebalance != nil {
+		if err := c.ThresholdModeConfig.LeaderRebalance.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.ThresholdModeConfig.TLS != nil {
+		if err := c.ThresholdModeConfig.TLS.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.ThresholdModeConfig.Retry != nil {
+		if err := c.ThresholdModeConfig.Retry.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.ThresholdModeConfig.RateLimit != nil {
+		if err := c.ThresholdModeConfig.RateLimit.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.ThresholdModeConfig.Keepalive != nil {
+		if err := c.ThresholdModeConfig.Keepalive.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.ThresholdModeConfig.Raft != nil {
+		if err := c.ThresholdModeConfig.Raft.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.ThresholdModeConfig.Notifier != nil {
+		if err := c.ThresholdModeConfig.Notifier.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.ThresholdModeConfig.SignConcurrency != nil {
+		if err := c.ThresholdModeConfig.SignConcurrency.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, c.ThresholdModeConfig.Cosigners.validationErrors()...)
+
+	switch c.ThresholdModeConfig.MissingSignState {
+	case "", MissingSignStateFail, MissingSignStatePeers:
+	case MissingSignStateFloor:
+		if !c.ChainNodes.HaveRPCAddr() {
+			errs = append(errs,
+				fmt.Errorf("missingSignState %q requires a chain node with rpcAddr configured", MissingSignStateFloor))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid missingSignState %q, must be one of: %s, %s, %s",
+			c.ThresholdModeConfig.MissingSignState, MissingSignStateFail, MissingSignStateFloor, MissingSignStatePeers))
+	}
+
+	switch c.ThresholdModeConfig.SignBytesVerification {
+	case "", SignBytesVerificationOff, SignBytesVerificationStrict:
+	default:
+		errs = append(errs, fmt.Errorf("invalid signBytesVerification %q, must be one of: %s, %s",
+			c.ThresholdModeConfig.SignBytesVerification, SignBytesVerificationOff, SignBytesVerificationStrict))
 	}
 
-	return c.ThresholdModeConfig.Cosigners.Validate()
+	return errs
+}
+
+// firstError returns the first of errs, or nil if there are none.
+func firstError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[0]
 }
 
 type RuntimeConfig struct {
@@ -104,6 +465,9 @@ type RuntimeConfig struct {
 	StateDir   string
 	PidFile    string
 	Config     Config
+
+	// ShardEncryption decrypts the key shard files encrypted at rest, nil if they are not encrypted.
+	ShardEncryption ShardEncryption
 }
 
 func (c RuntimeConfig) CosignerSecurityECIES() (*CosignerSecurityECIES, error) {
@@ -181,8 +545,33 @@ func (c RuntimeConfig) CosignerStateFile(chainID string) string {
 	return filepath.Join(c.StateDir, fmt.Sprintf("%s_share_sign_state.json", chainID))
 }
 
+// SignWALFile is the write-ahead entry of the block that the cosigner is signing as the leader.
+func (c RuntimeConfig) SignWALFile(chainID string) string {
+	return filepath.Join(c.StateDir, fmt.Sprintf("%s_sign_wal.json", chainID))
+}
+
+// CheckHomeDirPermissions returns an error if the home directory, which holds the key shards and config,
+// is accessible by group or other.
+func (c RuntimeConfig) CheckHomeDirPermissions() error {
+	stat, err := os.Stat(c.HomeDir)
+	if err != nil {
+		return fmt.Errorf("failed to check home directory permissions: %w", err)
+	}
+	if perm := stat.Mode().Perm(); perm&0077 != 0 {
+		return fmt.Errorf("home directory %s is accessible by group or other (mode %04o), fix with: chmod 700 %s",
+			c.HomeDir, perm, c.HomeDir)
+	}
+	return nil
+}
+
+// WriteConfigFile writes the config to a temporary file before renaming it over the config file,
+// so that the config file is never left partially written.
 func (c RuntimeConfig) WriteConfigFile() error {
-	return os.WriteFile(c.ConfigFile, c.Config.MustMarshalYaml(), 0600)
+	tmpFile := c.ConfigFile + ".tmp"
+	if err := os.WriteFile(tmpFile, c.Config.MustMarshalYaml(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, c.ConfigFile)
 }
 
 func fileExists(file string) error {
@@ -220,12 +609,122 @@ func (c RuntimeConfig) KeyFileExistsCosignerECIES() (string, error) {
 	return keyFile, fileExists(keyFile)
 }
 
+// MissingSignStateAction is the action taken when a sign request is received
+// for a chain that does not have a sign state file yet.
+type MissingSignStateAction string
+
+const (
+	// MissingSignStateFail refuses to sign until the sign state is created by the operator.
+	MissingSignStateFail MissingSignStateAction = "fail"
+	// MissingSignStateFloor initializes the sign state above the current height reported by the chain nodes.
+	MissingSignStateFloor MissingSignStateAction = "floor"
+	// MissingSignStatePeers initializes the sign state at the highest sign state reported by the peer cosigners.
+	MissingSignStatePeers MissingSignStateAction = "peers"
+)
+
+// SignBytesVerificationMode is how the sign bytes are checked by a cosigner before it signs them.
+type SignBytesVerificationMode string
+
+const (
+	// SignBytesVerificationOff only decodes the height, round and step from the sign bytes.
+	SignBytesVerificationOff SignBytesVerificationMode = "off"
+	// SignBytesVerificationStrict refuses to sign bytes for a different chain-id,
+	// or for a different height, round, step and timestamp than those of the request.
+	SignBytesVerificationStrict SignBytesVerificationMode = "strict"
+)
+
 // ThresholdModeConfig is the on disk config format for threshold sign mode.
 type ThresholdModeConfig struct {
 	Threshold   int             `yaml:"threshold"`
 	Cosigners   CosignersConfig `yaml:"cosigners"`
 	GRPCTimeout string          `yaml:"grpcTimeout"`
 	RaftTimeout string          `yaml:"raftTimeout"`
+	// MissingSignState defaults to MissingSignStateFail.
+	MissingSignState MissingSignStateAction `yaml:"missingSignState,omitempty"`
+	// PeerClockTolerance is how far a peer's clock may be ahead of the leader's before it is excluded.
+	// Defaults to 1s.
+	PeerClockTolerance string `yaml:"peerClockTolerance,omitempty"`
+	// NonceExpiration is the maximum age of cached nonces before they are discarded. Defaults to 10s.
+	NonceExpiration string `yaml:"nonceExpiration,omitempty"`
+	// NonceCacheSize is the maximum number of heights, rounds and steps that nonces are cached for, for each
+	// chain. Defaults to 1000.
+	NonceCacheSize int `yaml:"nonceCacheSize,omitempty"`
+	// NoncePoolSize is the number of nonces for each chain that the cosigner generates ahead of the
+	// nonce requests. Disabled by default.
+	NoncePoolSize int `yaml:"noncePoolSize,omitempty"`
+	// PeerTimeout bounds each nonce and sign request to a single peer cosigner, so that a slow peer
+	// is abandoned in favor of the others. By default, requests to a peer are only bounded by the grpcTimeout.
+	PeerTimeout string `yaml:"peerTimeout,omitempty"`
+	// RaftApplyLatencyThreshold is the time taken for raft to commit and apply a sign state entry
+	// above which a warning is logged. Defaults to 100ms.
+	RaftApplyLatencyThreshold string `yaml:"raftApplyLatencyThreshold,omitempty"`
+	// ShutdownDrainTimeout bounds how long a stopping cosigner waits for leadership to be transferred away
+	// and for the requests in flight to finish. Defaults to 5s.
+	ShutdownDrainTimeout string `yaml:"shutdownDrainTimeout,omitempty"`
+	// LeaderRebalance is disabled by default.
+	LeaderRebalance *LeaderRebalanceConfig `yaml:"leaderRebalance,omitempty"`
+	// LeaderTransferByLatency makes `horcrux elect` without a shard ID transfer leadership to the peer
+	// with the lowest round trip time from the leader, rather than to the next raft candidate.
+	LeaderTransferByLatency bool `yaml:"leaderTransferByLatency,omitempty"`
+	// EnableDrills allows `horcrux drill` commands to take this cosigner down temporarily.
+	EnableDrills bool `yaml:"enableDrills,omitempty"`
+	// SignBytesVerification defaults to SignBytesVerificationOff.
+	SignBytesVerification SignBytesVerificationMode `yaml:"signBytesVerification,omitempty"`
+	// TLS is disabled by default, in which case the cosigner gRPC connections are not authenticated.
+	TLS *CosignerTLSConfig `yaml:"tls,omitempty"`
+	// Keepalive defaults to pinging idle cosigner gRPC connections every 30s.
+	Keepalive *CosignerKeepaliveConfig `yaml:"keepalive,omitempty"`
+	// Retry defaults to 3 attempts of each nonce and sign request to a cosigner within 250ms.
+	Retry *CosignerRetryConfig `yaml:"retry,omitempty"`
+	// RateLimit defaults to 50 requests per second with a burst of 100 for each chain and peer.
+	RateLimit *CosignerRateLimitConfig `yaml:"rateLimit,omitempty"`
+	// Raft defaults to the hashicorp/raft timeouts.
+	Raft *RaftConfig `yaml:"raft,omitempty"`
+	// BlockTimestampWindow is how far the timestamp of a block may be from the cosigner's clock,
+	// in either direction, for the block to be signed. Disabled by default.
+	BlockTimestampWindow string `yaml:"blockTimestampWindow,omitempty"`
+	// SignDeadline is how long after the timestamp of a vote or proposal from a chain node its signature
+	// is no longer useful, e.g. the consensus timeout_propose of the chain. It bounds the sign requests of
+	// the chain nodes rather than the grpcTimeout alone. Disabled by default.
+	SignDeadline string `yaml:"signDeadline,omitempty"`
+	// ClockSkewThreshold is the clock skew between any two cosigners above which an error is logged.
+	// Defaults to 500ms.
+	ClockSkewThreshold string `yaml:"clockSkewThreshold,omitempty"`
+	// SkipSignatureVerification skips verifying the combined signature against the public key before it is
+	// returned. The combined signature is verified by default.
+	SkipSignatureVerification bool `yaml:"skipSignatureVerification,omitempty"`
+	// AuditLogFile is the file that every sign decision is appended to, relative to the home directory.
+	// Disabled by default.
+	AuditLogFile string `yaml:"auditLogFile,omitempty"`
+	// Notifier is disabled by default.
+	Notifier *NotifierConfig `yaml:"notifier,omitempty"`
+	// SignConcurrency is disabled by default, in which case any number of blocks are signed at the same time.
+	SignConcurrency *SignConcurrencyConfig `yaml:"signConcurrency,omitempty"`
+}
+
+// LeaderRebalanceConfig transfers leadership to the fastest peer when the leader is slow to sign.
+type LeaderRebalanceConfig struct {
+	// SignLatencyThreshold is the average time taken by the leader to sign a block above which
+	// leadership is transferred.
+	SignLatencyThreshold string `yaml:"signLatencyThreshold"`
+	// Cooldown is how long a cosigner leads before it can transfer leadership again. Defaults to 10m.
+	Cooldown string `yaml:"cooldown,omitempty"`
+}
+
+func (c *LeaderRebalanceConfig) Validate() error {
+	if d, err := time.ParseDuration(c.SignLatencyThreshold); err != nil {
+		return fmt.Errorf("invalid leaderRebalance signLatencyThreshold: %w", err)
+	} else if d <= 0 {
+		return fmt.Errorf("leaderRebalance signLatencyThreshold (%s) must be greater than 0", d)
+	}
+	if c.Cooldown != "" {
+		if d, err := time.ParseDuration(c.Cooldown); err != nil {
+			return fmt.Errorf("invalid leaderRebalance cooldown: %w", err)
+		} else if d < 0 {
+			return fmt.Errorf("leaderRebalance cooldown (%s) must be 0 or greater", d)
+		}
+	}
+	return nil
 }
 
 func (cfg *ThresholdModeConfig) LeaderElectMultiAddress() (string, error) {
@@ -240,14 +739,21 @@ func (cfg *ThresholdModeConfig) LeaderElectMultiAddress() (string, error) {
 type CosignerConfig struct {
 	ShardID int    `yaml:"shardID"`
 	P2PAddr string `yaml:"p2pAddr"`
+	// Priority orders the nonce requests made by the leader. Lower values are preferred,
+	// cosigners with a higher value are only asked for nonces as backups.
+	Priority int `yaml:"priority,omitempty"`
 }
 
 type CosignersConfig []CosignerConfig
 
 func (cosigners CosignersConfig) Validate() error {
+	return firstError(cosigners.validationErrors())
+}
+
+func (cosigners CosignersConfig) validationErrors() (errs []error) {
 	// Check IDs to make sure none are duplicated
 	if dupl := duplicateCosigners(cosigners); len(dupl) != 0 {
-		return fmt.Errorf("found duplicate cosigner shard ID(s) in args: %v", dupl)
+		errs = append(errs, fmt.Errorf("found duplicate cosigner shard ID(s) in args: %v", dupl))
 	}
 
 	shards := len(cosigners)
@@ -255,32 +761,81 @@ func (cosigners CosignersConfig) Validate() error {
 	// Make sure that the cosigner IDs match the number of cosigners.
 	for _, cosigner := range cosigners {
 		if cosigner.ShardID < 1 || cosigner.ShardID > shards {
-			return fmt.Errorf("cosigner shard ID %d in args is out of range, must be between 1 and %d, inclusive",
-				cosigner.ShardID, shards)
+			errs = append(errs, fmt.Errorf("cosigner shard ID %d in args is out of range, must be between 1 and %d, inclusive",
+				cosigner.ShardID, shards))
 		}
 
-		url, err := url.Parse(cosigner.P2PAddr)
-		if err != nil {
-			return fmt.Errorf("failed to parse cosigner (shard ID: %d) p2p address: %w", cosigner.ShardID, err)
+		if cosigner.Priority < 0 {
+			errs = append(errs, fmt.Errorf("cosigner (shard ID: %d) priority %d is invalid, must be 0 or greater",
+				cosigner.ShardID, cosigner.Priority))
 		}
 
-		host, _, err := net.SplitHostPort(url.Host)
+		address, err := client.SanitizeAddress(cosigner.P2PAddr)
 		if err != nil {
-			return fmt.Errorf("failed to parse cosigner (shard ID: %d) host port: %w", cosigner.ShardID, err)
+			errs = append(errs, fmt.Errorf("failed to parse cosigner (shard ID: %d) p2p address: %w", cosigner.ShardID, err))
+			continue
 		}
 
-		if host == "0.0.0.0" {
-			return fmt.Errorf("host cannot be 0.0.0.0, must be reachable from other cosigners")
+		host, _, _ := net.SplitHostPort(address)
+		if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+			errs = append(errs, fmt.Errorf("host cannot be %s, must be reachable from other cosigners", host))
 		}
 	}
 
 	// Check that exactly {num-shards} cosigners are in the list
 	if len(cosigners) != shards {
-		return fmt.Errorf("incorrect number of cosigners. expected (%d shards = %d cosigners)",
-			shards, shards)
+		errs = append(errs, fmt.Errorf("incorrect number of cosigners. expected (%d shards = %d cosigners)",
+			shards, shards))
 	}
 
-	return nil
+	return errs
+}
+
+// ValidateCosignerPeers checks that the shard IDs of the peers, together with the local shard ID,
+// are each claimed once and form the contiguous range 1..n, so that the local shard is the only one missing.
+func ValidateCosignerPeers(localShardID int, peers CosignersConfig) error {
+	shards := len(peers) + 1
+	claimed := map[int]string{localShardID: "local cosigner"}
+
+	var errs []error
+	if localShardID < 1 || localShardID > shards {
+		errs = append(errs, fmt.Errorf("local shard ID %d is out of range, must be between 1 and %d, inclusive",
+			localShardID, shards))
+	}
+	for _, peer := range peers {
+		if other, ok := claimed[peer.ShardID]; ok {
+			errs = append(errs, fmt.Errorf("shard ID %d of cosigner %s is already claimed by %s",
+				peer.ShardID, peer.P2PAddr, other))
+			continue
+		}
+		claimed[peer.ShardID] = "cosigner " + peer.P2PAddr
+		if peer.ShardID < 1 || peer.ShardID > shards {
+			errs = append(errs, fmt.Errorf("cosigner %s shard ID %d is out of range, must be between 1 and %d, inclusive",
+				peer.P2PAddr, peer.ShardID, shards))
+		}
+	}
+
+	return firstError(errs)
+}
+
+// DuplicateCosignerAddrs returns the shard IDs of each p2p address that is assigned to more than one cosigner.
+func DuplicateCosignerAddrs(cosigners []CosignerConfig) (duplicates map[string][]int) {
+	addrIDs := make(map[string][]int)
+	for _, cosigner := range cosigners {
+		addrIDs[cosigner.P2PAddr] = append(addrIDs[cosigner.P2PAddr], cosigner.ShardID)
+	}
+
+	for addr, ids := range addrIDs {
+		if len(ids) == 1 {
+			delete(addrIDs, addr)
+		}
+	}
+
+	if len(addrIDs) == 0 {
+		return nil
+	}
+
+	return addrIDs
 }
 
 func duplicateCosigners(cosigners []CosignerConfig) (duplicates map[int][]string) {
@@ -306,49 +861,163 @@ func duplicateCosigners(cosigners []CosignerConfig) (duplicates map[int][]string
 	return idAddrs
 }
 
+// CosignersFromFlag parses cosigners in the format tcp://{p2p-addr}:{port}, optionally suffixed with |{shard-id}.
+// Cosigners without a shard ID are assigned the shard ID matching their position in the list.
 func CosignersFromFlag(cosigners []string) (out []CosignerConfig, err error) {
 	var errs []error
 	for i, c := range cosigners {
-		out = append(out, CosignerConfig{ShardID: i + 1, P2PAddr: c})
+		addr, id, hasID := strings.Cut(c, "|")
+		shardID := i + 1
+		if hasID {
+			shardID, err = strconv.Atoi(id)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid shard ID %q for cosigner %s: %w", id, addr, err))
+				continue
+			}
+		}
+		out = append(out, CosignerConfig{ShardID: shardID, P2PAddr: addr})
 	}
 	if len(errs) > 0 {
-		return nil, nil
+		return nil, errors.Join(errs...)
 	}
 	return out, nil
 }
 
 type ChainNode struct {
 	PrivValAddr string `json:"privValAddr" yaml:"privValAddr"`
+	// RPCAddr is the optional CometBFT RPC address of the chain node, e.g. tcp://localhost:26657
+	RPCAddr string `json:"rpcAddr,omitempty" yaml:"rpcAddr,omitempty"`
 }
 
 func (cn ChainNode) Validate() error {
-	_, err := url.Parse(cn.PrivValAddr)
-	return err
+	if cn.PrivValAddr == "" {
+		return fmt.Errorf("chain node privval address is empty")
+	}
+	u, err := url.Parse(cn.PrivValAddr)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "tcp":
+		host, port, err := net.SplitHostPort(u.Host)
+		if err != nil || host == "" || port == "" {
+			return fmt.Errorf("chain node privval address %s must have a host and port, e.g. tcp://sentry-1:1234",
+				cn.PrivValAddr)
+		}
+	case "unix":
+		if u.Host+u.Path == "" {
+			return fmt.Errorf("chain node privval address %s must have a socket path, e.g. unix:///run/privval.sock",
+				cn.PrivValAddr)
+		}
+		if u.Host != "" || !filepath.IsAbs(u.Path) {
+			return fmt.Errorf("chain node privval address %s must have an absolute socket path, e.g. unix:///run/privval.sock",
+				cn.PrivValAddr)
+		}
+	default:
+		return fmt.Errorf("chain node privval address %s must use the tcp or unix scheme", cn.PrivValAddr)
+	}
+	if cn.RPCAddr != "" {
+		if _, err := url.Parse(cn.RPCAddr); err != nil {
+			return fmt.Errorf("failed to parse chain node rpc address: %w", err)
+		}
+	}
+	return nil
 }
 
 type ChainNodes []ChainNode
 
 func (cns ChainNodes) Validate() error {
+	return firstError(cns.validationErrors())
+}
+
+func (cns ChainNodes) validationErrors() (errs []error) {
 	for _, cn := range cns {
 		if err := cn.Validate(); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return errs
 }
 
+// HaveRPCAddr returns true if at least one chain node has an RPC address configured.
+func (cns ChainNodes) HaveRPCAddr() bool {
+	for _, cn := range cns {
+		if cn.RPCAddr != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ChainNodesFromFlag parses the chain node privval addresses provided by flag.
+// Addresses are normalized and duplicates are collapsed so that only one connection is made to each node.
 func ChainNodesFromFlag(nodes []string) (ChainNodes, error) {
-	out := make(ChainNodes, len(nodes))
-	for i, n := range nodes {
-		cn := ChainNode{PrivValAddr: n}
-		out[i] = cn
+	out := make(ChainNodes, 0, len(nodes))
+	seen := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		addr := normalizeChainNodeAddr(n)
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		out = append(out, ChainNode{PrivValAddr: addr})
 	}
-	if err := out.Validate(); err != nil {
-		return nil, err
+	if errs := out.validationErrors(); len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
 	return out, nil
 }
 
+// ReadChainNodesFile reads newline delimited chain node privval addresses from a file.
+// Blank lines and lines starting with # are skipped. Malformed addresses are reported with their line number.
+func ReadChainNodesFile(file string) ([]string, error) {
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain nodes file: %w", err)
+	}
+
+	var nodes []string
+	var errs []error
+	for i, line := range strings.Split(string(bz), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := (ChainNode{PrivValAddr: normalizeChainNodeAddr(line)}).Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s line %d: invalid chain node %q: %w", file, i+1, line, err))
+			continue
+		}
+		nodes = append(nodes, line)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return nodes, nil
+}
+
+// DuplicateChainNodes returns the normalized chain node addresses that are provided more than once.
+func DuplicateChainNodes(nodes []string) (duplicates []string) {
+	count := make(map[string]int, len(nodes))
+	for _, n := range nodes {
+		addr := normalizeChainNodeAddr(n)
+		count[addr]++
+		if count[addr] == 2 {
+			duplicates = append(duplicates, addr)
+		}
+	}
+	return duplicates
+}
+
+// normalizeChainNodeAddr trims whitespace and lowercases the scheme of a chain node address,
+// e.g. " TCP://sentry-1:1234" becomes "tcp://sentry-1:1234".
+func normalizeChainNodeAddr(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if scheme, rest, ok := strings.Cut(addr, "://"); ok {
+		addr = strings.ToLower(scheme) + "://" + rest
+	}
+	return addr
+}
+
 func PubKey(bech32BasePrefix string, pubKey crypto.PubKey) (string, error) {
 	if bech32BasePrefix != "" {
 		pubkey, err := cryptocodec.FromTmPubKeyInterface(pubKey)
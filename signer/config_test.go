@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/strangelove-ventures/horcrux/signer"
@@ -66,6 +67,117 @@ func TestValidateSingleSignerConfig(t *testing.T) {
 			},
 			expectErr: &url.Error{Op: "parse", URL: "abc://\\invalid_addr", Err: url.InvalidHostError("\\")},
 		},
+		{
+			name: "statsd metrics backend",
+			config: signer.Config{
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+				MetricsBackend: signer.MetricsBackendStatsd,
+				StatsdAddr:     "127.0.0.1:8125",
+			},
+			expectErr: nil,
+		},
+		{
+			name: "statsd metrics backend without address",
+			config: signer.Config{
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+				MetricsBackend: signer.MetricsBackendStatsd,
+			},
+			expectErr: fmt.Errorf("invalid statsdAddr (): missing port in address"),
+		},
+		{
+			name: "invalid metrics backend",
+			config: signer.Config{
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+				MetricsBackend: "graphite",
+			},
+			expectErr: fmt.Errorf(`invalid metricsBackend "graphite", must be one of: prometheus, statsd`),
+		},
+		{
+			name: "invalid home dir permissions action",
+			config: signer.Config{
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+				HomeDirPermissions: "ignore",
+			},
+			expectErr: fmt.Errorf(`invalid homeDirPermissions "ignore", must be one of: warn, refuse`),
+		},
+		{
+			name: "negative state dir min free space",
+			config: signer.Config{
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+				StateDirMinFreeMB: -1,
+			},
+			expectErr: fmt.Errorf("stateDirMinFreeMB (-1) must be 0 or greater"),
+		},
+		{
+			name: "invalid armed file default",
+			config: signer.Config{
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+				ArmedFile: &signer.ArmedFileConfig{
+					Default: "on",
+				},
+			},
+			expectErr: fmt.Errorf(`invalid armedFile default "on", must be one of: armed, disarmed`),
+		},
+		{
+			name: "valid dns resolver without port",
+			config: signer.Config{
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+				DNSResolver: "10.0.0.53",
+			},
+			expectErr: nil,
+		},
+		{
+			name: "dns resolver hostname",
+			config: signer.Config{
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+				DNSResolver: "dns.internal:53",
+			},
+			expectErr: fmt.Errorf("invalid dnsResolver (dns.internal:53): must be an IP address"),
+		},
+		{
+			name: "block protocol versions without rpc addr",
+			config: signer.Config{
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+				BlockProtocolVersions: []uint64{11},
+			},
+			expectErr: fmt.Errorf("blockProtocolVersions requires a chain node with rpcAddr configured"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -88,16 +200,514 @@ func TestValidateThresholdModeConfig(t *testing.T) {
 
 	testCases := []testCase{
 		{
-			name: "valid config",
+			name: "valid config",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:   2,
+					RaftTimeout: "1000ms",
+					GRPCTimeout: "1000ms",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:2345",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:3456",
+					},
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			name: "no cosigner config",
+			config: signer.Config{
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:2345",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:3456",
+					},
+				},
+			},
+			expectErr: fmt.Errorf("cosigner config can't be empty"),
+		},
+		{
+			name: "invalid p2p listen",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:   2,
+					RaftTimeout: "1000ms",
+					GRPCTimeout: "1000ms",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: ":2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:2345",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:3456",
+					},
+				},
+			},
+			expectErr: fmt.Errorf("failed to parse cosigner (shard ID: 1) p2p address: address :2222 is missing a host"),
+		},
+		{
+			name: "not enough cosigners",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:   3,
+					RaftTimeout: "1000ms",
+					GRPCTimeout: "1000ms",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:2345",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:3456",
+					},
+				},
+			},
+			expectErr: fmt.Errorf("number of shards (2) must be greater or equal to threshold (3)"),
+		},
+		{
+			name: "invalid raft timeout",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:   2,
+					GRPCTimeout: "1000ms",
+					RaftTimeout: "1000",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:2345",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:3456",
+					},
+				},
+			},
+			expectErr: fmt.Errorf("invalid raftTimeout: %w", fmt.Errorf("time: missing unit in duration \"1000\"")),
+		},
+		{
+			name: "invalid grpc timeout",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:   2,
+					GRPCTimeout: "1000",
+					RaftTimeout: "1000ms",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:2345",
+					},
+					{
+						PrivValAddr: "tcp://127.0.0.1:3456",
+					},
+				},
+			},
+			expectErr: fmt.Errorf("invalid grpcTimeout: %w", fmt.Errorf("time: missing unit in duration \"1000\"")),
+		},
+		{
+			name: "no nodes configured",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:   2,
+					RaftTimeout: "1000ms",
+					GRPCTimeout: "1000ms",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: nil,
+			},
+			expectErr: fmt.Errorf("need to have chainNodes configured for priv-val connection"),
+		},
+		{
+			name: "invalid node address",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:   2,
+					RaftTimeout: "1000ms",
+					GRPCTimeout: "1000ms",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "abc://\\invalid_addr",
+					},
+				},
+			},
+			expectErr: &url.Error{Op: "parse", URL: "abc://\\invalid_addr", Err: url.InvalidHostError("\\")},
+		},
+		{
+			name: "invalid missing sign state action",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:        2,
+					RaftTimeout:      "1000ms",
+					GRPCTimeout:      "1000ms",
+					MissingSignState: "create",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+			},
+			expectErr: fmt.Errorf(`invalid missingSignState "create", must be one of: fail, floor, peers`),
+		},
+		{
+			name: "invalid sign bytes verification mode",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:             2,
+					RaftTimeout:           "1000ms",
+					GRPCTimeout:           "1000ms",
+					SignBytesVerification: "lenient",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+			},
+			expectErr: fmt.Errorf(`invalid signBytesVerification "lenient", must be one of: off, strict`),
+		},
+		{
+			name: "invalid peer clock tolerance",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:          2,
+					RaftTimeout:        "1000ms",
+					GRPCTimeout:        "1000ms",
+					PeerClockTolerance: "1",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+			},
+			expectErr: fmt.Errorf(`invalid peerClockTolerance: time: missing unit in duration "1"`),
+		},
+		{
+			name: "invalid nonce expiration",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:       2,
+					RaftTimeout:     "1000ms",
+					GRPCTimeout:     "1000ms",
+					NonceExpiration: "-1s",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+			},
+			expectErr: fmt.Errorf("nonceExpiration (-1s) must be greater than 0"),
+		},
+		{
+			name: "negative nonce cache size",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:      2,
+					RaftTimeout:    "1000ms",
+					GRPCTimeout:    "1000ms",
+					NonceCacheSize: -1,
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+			},
+			expectErr: fmt.Errorf("nonceCacheSize (-1) must not be negative"),
+		},
+		{
+			name: "negative nonce pool size",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:     2,
+					RaftTimeout:   "1000ms",
+					GRPCTimeout:   "1000ms",
+					NoncePoolSize: -1,
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+			},
+			expectErr: fmt.Errorf("noncePoolSize (-1) must not be negative"),
+		},
+		{
+			name: "invalid peer timeout",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:   2,
+					RaftTimeout: "1000ms",
+					GRPCTimeout: "1000ms",
+					PeerTimeout: "0s",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+			},
+			expectErr: fmt.Errorf("peerTimeout (0s) must be greater than 0"),
+		},
+		{
+			name: "invalid block timestamp window",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:            2,
+					RaftTimeout:          "1000ms",
+					GRPCTimeout:          "1000ms",
+					BlockTimestampWindow: "30",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
+			},
+			expectErr: fmt.Errorf(`invalid blockTimestampWindow: time: missing unit in duration "30"`),
+		},
+		{
+			name: "invalid sign deadline",
 			config: signer.Config{
 				ThresholdModeConfig: &signer.ThresholdModeConfig{
-					Threshold:   2,
-					RaftTimeout: "1000ms",
-					GRPCTimeout: "1000ms",
+					Threshold:    2,
+					RaftTimeout:  "1000ms",
+					GRPCTimeout:  "1000ms",
+					SignDeadline: "-1s",
 					Cosigners: signer.CosignersConfig{
 						{
 							ShardID: 1,
-							P2PAddr: "tcp://127.0.0.1:2223",
+							P2PAddr: "tcp://127.0.0.1:2222",
 						},
 						{
 							ShardID: 2,
@@ -113,44 +723,53 @@ func TestValidateThresholdModeConfig(t *testing.T) {
 					{
 						PrivValAddr: "tcp://127.0.0.1:1234",
 					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:2345",
-					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:3456",
-					},
 				},
 			},
-			expectErr: nil,
+			expectErr: fmt.Errorf("signDeadline (-1s) must be greater than 0"),
 		},
 		{
-			name: "no cosigner config",
+			name: "invalid clock skew threshold",
 			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:          2,
+					RaftTimeout:        "1000ms",
+					GRPCTimeout:        "1000ms",
+					ClockSkewThreshold: "-1s",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
+					},
+				},
 				ChainNodes: []signer.ChainNode{
 					{
 						PrivValAddr: "tcp://127.0.0.1:1234",
 					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:2345",
-					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:3456",
-					},
 				},
 			},
-			expectErr: fmt.Errorf("cosigner config can't be empty"),
+			expectErr: fmt.Errorf("clockSkewThreshold (-1s) must be greater than 0"),
 		},
 		{
-			name: "invalid p2p listen",
+			name: "invalid raft apply latency threshold",
 			config: signer.Config{
 				ThresholdModeConfig: &signer.ThresholdModeConfig{
-					Threshold:   2,
-					RaftTimeout: "1000ms",
-					GRPCTimeout: "1000ms",
+					Threshold:                 2,
+					RaftTimeout:               "1000ms",
+					GRPCTimeout:               "1000ms",
+					RaftApplyLatencyThreshold: "0s",
 					Cosigners: signer.CosignersConfig{
 						{
 							ShardID: 1,
-							P2PAddr: ":2222",
+							P2PAddr: "tcp://127.0.0.1:2222",
 						},
 						{
 							ShardID: 2,
@@ -166,27 +785,51 @@ func TestValidateThresholdModeConfig(t *testing.T) {
 					{
 						PrivValAddr: "tcp://127.0.0.1:1234",
 					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:2345",
+				},
+			},
+			expectErr: fmt.Errorf("raftApplyLatencyThreshold (0s) must be greater than 0"),
+		},
+		{
+			name: "invalid shutdown drain timeout",
+			config: signer.Config{
+				ThresholdModeConfig: &signer.ThresholdModeConfig{
+					Threshold:            2,
+					RaftTimeout:          "1000ms",
+					GRPCTimeout:          "1000ms",
+					ShutdownDrainTimeout: "5",
+					Cosigners: signer.CosignersConfig{
+						{
+							ShardID: 1,
+							P2PAddr: "tcp://127.0.0.1:2222",
+						},
+						{
+							ShardID: 2,
+							P2PAddr: "tcp://127.0.0.1:2223",
+						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
 					},
+				},
+				ChainNodes: []signer.ChainNode{
 					{
-						PrivValAddr: "tcp://127.0.0.1:3456",
+						PrivValAddr: "tcp://127.0.0.1:1234",
 					},
 				},
 			},
-			expectErr: fmt.Errorf("failed to parse cosigner (shard ID: 1) p2p address: %w", &url.Error{
-				Op:  "parse",
-				URL: ":2222",
-				Err: fmt.Errorf("missing protocol scheme"),
-			}),
+			expectErr: fmt.Errorf(`invalid shutdownDrainTimeout: time: missing unit in duration "5"`),
 		},
 		{
-			name: "not enough cosigners",
+			name: "invalid leader rebalance threshold",
 			config: signer.Config{
 				ThresholdModeConfig: &signer.ThresholdModeConfig{
-					Threshold:   3,
+					Threshold:   2,
 					RaftTimeout: "1000ms",
 					GRPCTimeout: "1000ms",
+					LeaderRebalance: &signer.LeaderRebalanceConfig{
+						SignLatencyThreshold: "",
+					},
 					Cosigners: signer.CosignersConfig{
 						{
 							ShardID: 1,
@@ -196,29 +839,31 @@ func TestValidateThresholdModeConfig(t *testing.T) {
 							ShardID: 2,
 							P2PAddr: "tcp://127.0.0.1:2223",
 						},
+						{
+							ShardID: 3,
+							P2PAddr: "tcp://127.0.0.1:2224",
+						},
 					},
 				},
 				ChainNodes: []signer.ChainNode{
 					{
 						PrivValAddr: "tcp://127.0.0.1:1234",
 					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:2345",
-					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:3456",
-					},
 				},
 			},
-			expectErr: fmt.Errorf("number of shards (2) must be greater or equal to threshold (3)"),
+			expectErr: fmt.Errorf(`invalid leaderRebalance signLatencyThreshold: time: invalid duration ""`),
 		},
 		{
-			name: "invalid raft timeout",
+			name: "tls without ca",
 			config: signer.Config{
 				ThresholdModeConfig: &signer.ThresholdModeConfig{
 					Threshold:   2,
+					RaftTimeout: "1000ms",
 					GRPCTimeout: "1000ms",
-					RaftTimeout: "1000",
+					TLS: &signer.CosignerTLSConfig{
+						CertFile: "cosigner.crt",
+						KeyFile:  "cosigner.key",
+					},
 					Cosigners: signer.CosignersConfig{
 						{
 							ShardID: 1,
@@ -238,23 +883,20 @@ func TestValidateThresholdModeConfig(t *testing.T) {
 					{
 						PrivValAddr: "tcp://127.0.0.1:1234",
 					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:2345",
-					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:3456",
-					},
 				},
 			},
-			expectErr: fmt.Errorf("invalid raftTimeout: %w", fmt.Errorf("time: missing unit in duration \"1000\"")),
+			expectErr: fmt.Errorf("tls caFile is required"),
 		},
 		{
-			name: "invalid grpc timeout",
+			name: "keepalive time too short",
 			config: signer.Config{
 				ThresholdModeConfig: &signer.ThresholdModeConfig{
 					Threshold:   2,
-					GRPCTimeout: "1000",
 					RaftTimeout: "1000ms",
+					GRPCTimeout: "1000ms",
+					Keepalive: &signer.CosignerKeepaliveConfig{
+						Time: "5s",
+					},
 					Cosigners: signer.CosignersConfig{
 						{
 							ShardID: 1,
@@ -274,23 +916,18 @@ func TestValidateThresholdModeConfig(t *testing.T) {
 					{
 						PrivValAddr: "tcp://127.0.0.1:1234",
 					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:2345",
-					},
-					{
-						PrivValAddr: "tcp://127.0.0.1:3456",
-					},
 				},
 			},
-			expectErr: fmt.Errorf("invalid grpcTimeout: %w", fmt.Errorf("time: missing unit in duration \"1000\"")),
+			expectErr: fmt.Errorf("keepalive time (5s) must be at least 10s"),
 		},
 		{
-			name: "no nodes configured",
+			name: "missing sign state floor without rpc address",
 			config: signer.Config{
 				ThresholdModeConfig: &signer.ThresholdModeConfig{
-					Threshold:   2,
-					RaftTimeout: "1000ms",
-					GRPCTimeout: "1000ms",
+					Threshold:        2,
+					RaftTimeout:      "1000ms",
+					GRPCTimeout:      "1000ms",
+					MissingSignState: signer.MissingSignStateFloor,
 					Cosigners: signer.CosignersConfig{
 						{
 							ShardID: 1,
@@ -306,12 +943,16 @@ func TestValidateThresholdModeConfig(t *testing.T) {
 						},
 					},
 				},
-				ChainNodes: nil,
+				ChainNodes: []signer.ChainNode{
+					{
+						PrivValAddr: "tcp://127.0.0.1:1234",
+					},
+				},
 			},
-			expectErr: fmt.Errorf("need to have chainNodes configured for priv-val connection"),
+			expectErr: fmt.Errorf(`missingSignState "floor" requires a chain node with rpcAddr configured`),
 		},
 		{
-			name: "invalid node address",
+			name: "tracing endpoint without port",
 			config: signer.Config{
 				ThresholdModeConfig: &signer.ThresholdModeConfig{
 					Threshold:   2,
@@ -334,11 +975,16 @@ func TestValidateThresholdModeConfig(t *testing.T) {
 				},
 				ChainNodes: []signer.ChainNode{
 					{
-						PrivValAddr: "abc://\\invalid_addr",
+						PrivValAddr: "tcp://127.0.0.1:1234",
 					},
 				},
+				Tracing: &signer.TracingConfig{
+					OTLPEndpoint: "otel-collector",
+				},
 			},
-			expectErr: &url.Error{Op: "parse", URL: "abc://\\invalid_addr", Err: url.InvalidHostError("\\")},
+			expectErr: fmt.Errorf(
+				"invalid tracing otlpEndpoint (otel-collector): address otel-collector: missing port in address",
+			),
 		},
 	}
 
@@ -376,6 +1022,19 @@ func TestRuntimeConfigPrivValStateFile(t *testing.T) {
 	require.Equal(t, filepath.Join(dir, "chain-1_priv_validator_state.json"), c.PrivValStateFile("chain-1"))
 }
 
+func TestRuntimeConfigCheckHomeDirPermissions(t *testing.T) {
+	homeDir := filepath.Join(t.TempDir(), ".horcrux")
+	require.NoError(t, os.Mkdir(homeDir, 0700))
+
+	c := signer.RuntimeConfig{HomeDir: homeDir}
+	require.NoError(t, c.CheckHomeDirPermissions())
+
+	require.NoError(t, os.Chmod(homeDir, 0750))
+	require.EqualError(t, c.CheckHomeDirPermissions(), fmt.Sprintf(
+		"home directory %s is accessible by group or other (mode 0750), fix with: chmod 700 %s", homeDir, homeDir,
+	))
+}
+
 func TestRuntimeConfigWriteConfigFile(t *testing.T) {
 	dir := t.TempDir()
 	configFile := filepath.Join(dir, "config.yaml")
@@ -567,6 +1226,36 @@ func TestCosignerRSAPubKeysConfigValidate(t *testing.T) {
 				"found duplicate cosigner shard ID(s) in args: map[2:[tcp://127.0.0.1:2223 tcp://127.0.0.1:2223]]",
 			),
 		},
+		{
+			name: "valid priorities",
+			cosigners: signer.CosignersConfig{
+				{
+					ShardID: 1,
+					P2PAddr: "tcp://127.0.0.1:2222",
+				},
+				{
+					ShardID:  2,
+					P2PAddr:  "tcp://127.0.0.1:2223",
+					Priority: 1,
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			name: "negative priority",
+			cosigners: signer.CosignersConfig{
+				{
+					ShardID: 1,
+					P2PAddr: "tcp://127.0.0.1:2222",
+				},
+				{
+					ShardID:  2,
+					P2PAddr:  "tcp://127.0.0.1:2223",
+					Priority: -1,
+				},
+			},
+			expectErr: fmt.Errorf("cosigner (shard ID: 2) priority -1 is invalid, must be 0 or greater"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -593,6 +1282,18 @@ func TestCosignersFromFlag(t *testing.T) {
 			cosigners: []string{"tcp://127.0.0.1:2222", "tcp://127.0.0.1:2223"},
 			expectErr: nil,
 		},
+		{
+			name:      "valid cosigners flag with shard IDs",
+			cosigners: []string{"tcp://127.0.0.1:2223|2", "tcp://127.0.0.1:2222|1"},
+			expectErr: nil,
+		},
+		{
+			name:      "invalid shard ID",
+			cosigners: []string{"tcp://127.0.0.1:2222|a", "tcp://127.0.0.1:2223|2"},
+			expectErr: fmt.Errorf(
+				`invalid shard ID "a" for cosigner tcp://127.0.0.1:2222: strconv.Atoi: parsing "a": invalid syntax`,
+			),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -605,3 +1306,177 @@ func TestCosignersFromFlag(t *testing.T) {
 		}
 	}
 }
+
+func TestChainNodesFromFlag(t *testing.T) {
+	type testCase struct {
+		name       string
+		nodes      []string
+		expectOut  signer.ChainNodes
+		expectDupl []string
+	}
+
+	testCases := []testCase{
+		{
+			name:  "unique nodes",
+			nodes: []string{"tcp://127.0.0.1:1234", "tcp://127.0.0.1:1235"},
+			expectOut: signer.ChainNodes{
+				{PrivValAddr: "tcp://127.0.0.1:1234"},
+				{PrivValAddr: "tcp://127.0.0.1:1235"},
+			},
+		},
+		{
+			name:  "duplicate nodes after normalization",
+			nodes: []string{"tcp://127.0.0.1:1234", " TCP://127.0.0.1:1234", "tcp://127.0.0.1:1235", "tcp://127.0.0.1:1234 "},
+			expectOut: signer.ChainNodes{
+				{PrivValAddr: "tcp://127.0.0.1:1234"},
+				{PrivValAddr: "tcp://127.0.0.1:1235"},
+			},
+			expectDupl: []string{"tcp://127.0.0.1:1234"},
+		},
+	}
+
+	for _, tc := range testCases {
+		out, err := signer.ChainNodesFromFlag(tc.nodes)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expectOut, out, tc.name)
+		require.Equal(t, tc.expectDupl, signer.DuplicateChainNodes(tc.nodes), tc.name)
+	}
+}
+
+func TestChainNodesFromFlagInvalid(t *testing.T) {
+	_, err := signer.ChainNodesFromFlag(strings.Split("tcp://ok:1234, ,bad", ","))
+	require.EqualError(t, err, "chain node privval address is empty\n"+
+		"chain node privval address bad must use the tcp or unix scheme")
+}
+
+func TestChainNodeValidate(t *testing.T) {
+	type testCase struct {
+		name      string
+		addr      string
+		expectErr error
+	}
+
+	testCases := []testCase{
+		{
+			name: "tcp",
+			addr: "tcp://sentry-1:1234",
+		},
+		{
+			name: "unix",
+			addr: "unix:///run/privval.sock",
+		},
+		{
+			name:      "empty",
+			addr:      "",
+			expectErr: fmt.Errorf("chain node privval address is empty"),
+		},
+		{
+			name: "missing port",
+			addr: "tcp://sentry-1",
+			expectErr: fmt.Errorf(
+				"chain node privval address tcp://sentry-1 must have a host and port, e.g. tcp://sentry-1:1234"),
+		},
+		{
+			name: "missing host",
+			addr: "tcp://:1234",
+			expectErr: fmt.Errorf(
+				"chain node privval address tcp://:1234 must have a host and port, e.g. tcp://sentry-1:1234"),
+		},
+		{
+			name: "missing socket path",
+			addr: "unix://",
+			expectErr: fmt.Errorf(
+				"chain node privval address unix:// must have a socket path, e.g. unix:///run/privval.sock"),
+		},
+		{
+			name: "relative socket path",
+			addr: "unix://privval.sock",
+			expectErr: fmt.Errorf(
+				"chain node privval address unix://privval.sock must have an absolute socket path, e.g. unix:///run/privval.sock"),
+		},
+		{
+			name:      "unsupported scheme",
+			addr:      "udp://sentry-1:1234",
+			expectErr: fmt.Errorf("chain node privval address udp://sentry-1:1234 must use the tcp or unix scheme"),
+		},
+	}
+
+	for _, tc := range testCases {
+		err := signer.ChainNode{PrivValAddr: tc.addr}.Validate()
+		if tc.expectErr == nil {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.EqualError(t, err, tc.expectErr.Error(), tc.name)
+		}
+	}
+}
+
+func TestValidateCosignerPeers(t *testing.T) {
+	type testCase struct {
+		name         string
+		localShardID int
+		peers        signer.CosignersConfig
+		expectErr    error
+	}
+
+	testCases := []testCase{
+		{
+			name:         "valid peers",
+			localShardID: 2,
+			peers: signer.CosignersConfig{
+				{ShardID: 1, P2PAddr: "tcp://127.0.0.1:2222"},
+				{ShardID: 3, P2PAddr: "tcp://127.0.0.1:2224"},
+			},
+			expectErr: nil,
+		},
+		{
+			name:         "peer claims the local shard",
+			localShardID: 1,
+			peers: signer.CosignersConfig{
+				{ShardID: 1, P2PAddr: "tcp://127.0.0.1:2223"},
+				{ShardID: 2, P2PAddr: "tcp://127.0.0.1:2224"},
+			},
+			expectErr: fmt.Errorf("shard ID 1 of cosigner tcp://127.0.0.1:2223 is already claimed by local cosigner"),
+		},
+		{
+			name:         "duplicate peer shards",
+			localShardID: 1,
+			peers: signer.CosignersConfig{
+				{ShardID: 2, P2PAddr: "tcp://127.0.0.1:2223"},
+				{ShardID: 2, P2PAddr: "tcp://127.0.0.1:2224"},
+			},
+			expectErr: fmt.Errorf(
+				"shard ID 2 of cosigner tcp://127.0.0.1:2224 is already claimed by cosigner tcp://127.0.0.1:2223",
+			),
+		},
+		{
+			name:         "gap in peer shards",
+			localShardID: 1,
+			peers: signer.CosignersConfig{
+				{ShardID: 2, P2PAddr: "tcp://127.0.0.1:2223"},
+				{ShardID: 4, P2PAddr: "tcp://127.0.0.1:2224"},
+			},
+			expectErr: fmt.Errorf(
+				"cosigner tcp://127.0.0.1:2224 shard ID 4 is out of range, must be between 1 and 3, inclusive",
+			),
+		},
+		{
+			name:         "local shard out of range",
+			localShardID: 4,
+			peers: signer.CosignersConfig{
+				{ShardID: 1, P2PAddr: "tcp://127.0.0.1:2222"},
+				{ShardID: 2, P2PAddr: "tcp://127.0.0.1:2223"},
+			},
+			expectErr: fmt.Errorf("local shard ID 4 is out of range, must be between 1 and 3, inclusive"),
+		},
+	}
+
+	for _, tc := range testCases {
+		err := signer.ValidateCosignerPeers(tc.localShardID, tc.peers)
+		if tc.expectErr == nil {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.EqualError(t, err, tc.expectErr.Error(), tc.name)
+		}
+	}
+}
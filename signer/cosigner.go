@@ -1,6 +1,7 @@
 package signer
 
 import (
+	"context"
 	"time"
 
 	cometcrypto "github.com/cometbft/cometbft/crypto"
@@ -23,10 +24,10 @@ type Cosigner interface {
 	VerifySignature(chainID string, payload, signature []byte) bool
 
 	// Get nonces for all cosigner shards
-	GetNonces(chainID string, hrst HRSTKey) (*CosignerNoncesResponse, error)
+	GetNonces(ctx context.Context, chainID string, hrst HRSTKey) (*CosignerNoncesResponse, error)
 
 	// Sign the requested bytes
-	SetNoncesAndSign(req CosignerSetNoncesAndSignRequest) (*CosignerSignResponse, error)
+	SetNoncesAndSign(ctx context.Context, req CosignerSetNoncesAndSignRequest) (*CosignerSignResponse, error)
 }
 
 // CosignerSignRequest is sent to a co-signer to obtain their signature for the SignBytes
@@ -40,6 +41,9 @@ type CosignerSignResponse struct {
 	NoncePublic []byte
 	Timestamp   time.Time
 	Signature   []byte
+
+	// HRST is the round that the signature is for, as unpacked from the sign bytes.
+	HRST HRSTKey
 }
 
 type CosignerNonce struct {
@@ -0,0 +1,64 @@
+package signer
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const cosignerHealthReportInterval = time.Second
+
+// newCosignerHealthServer returns the standard gRPC health service of the cosigner,
+// NOT_SERVING until reportHealth finds the cosigner ready to sign.
+func newCosignerHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	hs.SetServingStatus(proto.CosignerGRPC_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	return hs
+}
+
+// reportHealth updates the health service of the cosigner until the store is stopped.
+func (s *RaftStore) reportHealth() {
+	ticker := time.NewTicker(cosignerHealthReportInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !s.IsRunning() {
+			return
+		}
+		status := s.servingStatus()
+		s.health.SetServingStatus("", status)
+		s.health.SetServingStatus(proto.CosignerGRPC_ServiceDesc.ServiceName, status)
+	}
+}
+
+// servingStatus is SERVING once raft has joined a cluster with a leader and the cosigner has a key shard.
+func (s *RaftStore) servingStatus() grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if s.GetLeader() == "" || s.cosigner == nil || !s.cosigner.hasKeyShard() {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+// hasKeyShard returns true if the cosigner has loaded the key shard of a chain,
+// or if one can be loaded from the key directory.
+func (cosigner *LocalCosigner) hasKeyShard() bool {
+	loaded := false
+	cosigner.chainState.Range(func(_, _ any) bool {
+		loaded = true
+		return false
+	})
+	if loaded {
+		return true
+	}
+
+	files, _ := filepath.Glob(cosigner.config.KeyFilePathCosigner("*"))
+	for _, file := range files {
+		if _, err := LoadCosignerEd25519Key(file); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package signer
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	defaultKeepaliveTime    = 30 * time.Second
+	defaultKeepaliveTimeout = 10 * time.Second
+
+	// minKeepaliveTime is the shortest keepalive time gRPC clients allow, which is also the shortest
+	// ping interval that the cosigner gRPC server permits before it closes the connection.
+	minKeepaliveTime = 10 * time.Second
+)
+
+// CosignerKeepaliveConfig configures the keepalive pings of the cosigner gRPC connections, so that idle
+// connections are not silently dropped by firewalls between the cosigners.
+type CosignerKeepaliveConfig struct {
+	// Time is how long a connection may be idle before it is pinged. Defaults to 30s, must be at least 10s.
+	Time string `yaml:"time,omitempty"`
+	// Timeout is how long to wait for a ping to be acknowledged before the connection is closed. Defaults to 10s.
+	Timeout string `yaml:"timeout,omitempty"`
+	// MaxConnectionAge is the age after which the server closes a connection, so that it is re-established.
+	// By default, connections are not closed because of their age.
+	MaxConnectionAge string `yaml:"maxConnectionAge,omitempty"`
+}
+
+func (c *CosignerKeepaliveConfig) Validate() error {
+	if c.Time != "" {
+		if d, err := time.ParseDuration(c.Time); err != nil {
+			return fmt.Errorf("invalid keepalive time: %w", err)
+		} else if d < minKeepaliveTime {
+			return fmt.Errorf("keepalive time (%s) must be at least %s", d, minKeepaliveTime)
+		}
+	}
+	if c.Timeout != "" {
+		if d, err := time.ParseDuration(c.Timeout); err != nil {
+			return fmt.Errorf("invalid keepalive timeout: %w", err)
+		} else if d <= 0 {
+			return fmt.Errorf("keepalive timeout (%s) must be greater than 0", d)
+		}
+	}
+	if c.MaxConnectionAge != "" {
+		if d, err := time.ParseDuration(c.MaxConnectionAge); err != nil {
+			return fmt.Errorf("invalid keepalive maxConnectionAge: %w", err)
+		} else if d <= 0 {
+			return fmt.Errorf("keepalive maxConnectionAge (%s) must be greater than 0", d)
+		}
+	}
+	return nil
+}
+
+// durations returns the configured durations, or the defaults for a nil config.
+// A maxConnectionAge of 0 means that connections are not closed because of their age.
+func (c *CosignerKeepaliveConfig) durations() (kaTime, timeout, maxConnectionAge time.Duration) {
+	kaTime, timeout = defaultKeepaliveTime, defaultKeepaliveTimeout
+	if c == nil {
+		return kaTime, timeout, 0
+	}
+
+	// Validated prior in ValidateThresholdModeConfig
+	if d, err := time.ParseDuration(c.Time); err == nil {
+		kaTime = d
+	}
+	if d, err := time.ParseDuration(c.Timeout); err == nil {
+		timeout = d
+	}
+	if d, err := time.ParseDuration(c.MaxConnectionAge); err == nil {
+		maxConnectionAge = d
+	}
+	return kaTime, timeout, maxConnectionAge
+}
+
+// serverOptions returns the keepalive options of the cosigner gRPC server.
+func (c *CosignerKeepaliveConfig) serverOptions() []grpc.ServerOption {
+	kaTime, timeout, maxConnectionAge := c.durations()
+	params := keepalive.ServerParameters{
+		Time:    kaTime,
+		Timeout: timeout,
+	}
+	if maxConnectionAge > 0 {
+		params.MaxConnectionAge = maxConnectionAge
+	}
+
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(params),
+		// the other cosigners ping idle connections, which the server would otherwise treat as abuse.
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             minKeepaliveTime,
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
+// dialOption returns the keepalive option of the connections to the other cosigners.
+func (c *CosignerKeepaliveConfig) dialOption() grpc.DialOption {
+	kaTime, timeout, _ := c.durations()
+	return grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:                kaTime,
+		Timeout:             timeout,
+		PermitWithoutStream: true,
+	})
+}
@@ -0,0 +1,35 @@
+package signer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosignerKeepaliveDurations(t *testing.T) {
+	// keepalive is enabled by default.
+	var c *CosignerKeepaliveConfig
+	kaTime, timeout, maxConnectionAge := c.durations()
+	require.Equal(t, 30*time.Second, kaTime)
+	require.Equal(t, 10*time.Second, timeout)
+	require.Zero(t, maxConnectionAge)
+
+	c = &CosignerKeepaliveConfig{Timeout: "5s"}
+	require.NoError(t, c.Validate())
+	kaTime, timeout, maxConnectionAge = c.durations()
+	require.Equal(t, 30*time.Second, kaTime)
+	require.Equal(t, 5*time.Second, timeout)
+	require.Zero(t, maxConnectionAge)
+
+	c = &CosignerKeepaliveConfig{Time: "1m", MaxConnectionAge: "1h"}
+	require.NoError(t, c.Validate())
+	kaTime, _, maxConnectionAge = c.durations()
+	require.Equal(t, time.Minute, kaTime)
+	require.Equal(t, time.Hour, maxConnectionAge)
+
+	require.EqualError(t, (&CosignerKeepaliveConfig{Timeout: "0s"}).Validate(),
+		"keepalive timeout (0s) must be greater than 0")
+	require.ErrorContains(t, (&CosignerKeepaliveConfig{MaxConnectionAge: "forever"}).Validate(),
+		"invalid keepalive maxConnectionAge")
+}
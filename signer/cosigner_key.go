@@ -14,8 +14,11 @@ import (
 // CosignerEd25519Key is a single Ed255219 key shard for an m-of-n threshold signer.
 type CosignerEd25519Key struct {
 	PubKey       cometcrypto.PubKey `json:"pubKey"`
-	PrivateShard []byte             `json:"privateShard"`
+	PrivateShard []byte             `json:"privateShard,omitempty"`
 	ID           int                `json:"id"`
+
+	// EncryptedShard is set instead of the PrivateShard in shard files encrypted at rest.
+	EncryptedShard *EncryptedShard `json:"encryptedShard,omitempty"`
 }
 
 func (key *CosignerEd25519Key) MarshalJSON() ([]byte, error) {
@@ -84,18 +87,23 @@ func (key *CosignerEd25519Key) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// LoadCosignerEd25519Key loads a CosignerEd25519Key from file.
+// LoadCosignerEd25519Key loads a CosignerEd25519Key from file, verifying the checksum of the shard file.
+// A shard file written before the checksum was added is rewritten with a checksum, if the file is writable.
 func LoadCosignerEd25519Key(file string) (CosignerEd25519Key, error) {
-	pvKey := CosignerEd25519Key{}
 	keyJSONBytes, err := os.ReadFile(file)
 	if err != nil {
-		return pvKey, err
+		return CosignerEd25519Key{}, err
 	}
 
-	err = json.Unmarshal(keyJSONBytes, &pvKey)
+	pvKey, legacy, err := unmarshalShardFile(file, keyJSONBytes)
 	if err != nil {
 		return pvKey, err
 	}
 
+	if legacy {
+		// the key is valid either way, a read-only key directory is migrated on a later load.
+		_ = WriteCosignerEd25519ShardFile(pvKey, file)
+	}
+
 	return pvKey, nil
 }
@@ -1,12 +1,18 @@
 package signer
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha512"
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"os"
 
+	cometcryptoed25519 "github.com/cometbft/cometbft/crypto/ed25519"
 	cometjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/tempfile"
 	"github.com/cometbft/cometbft/privval"
 	"github.com/ethereum/go-ethereum/crypto/ecies"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
@@ -37,6 +43,116 @@ func CreateCosignerEd25519Shards(pv privval.FilePVKey, threshold, shards uint8)
 	return out
 }
 
+// ReshareCosignerEd25519Keys deals new shards of the validator key of the given key shards, for a
+// new threshold and total number of shards. The public key stays the same, so the validator does not
+// need a consensus key change. At least the old threshold of key shards must be given; the validator
+// key is reconstructed in memory only, to deal the new shards.
+func ReshareCosignerEd25519Keys(keys []CosignerEd25519Key, threshold, shards uint8) ([]CosignerEd25519Key, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no key shards to reshare")
+	}
+
+	pubKey := keys[0].PubKey
+	ids := make([]int, len(keys))
+	privShards := make([][]byte, len(keys))
+	maxID := 0
+	seen := make(map[int]bool, len(keys))
+	for i, key := range keys {
+		if key.Encrypted() {
+			return nil, fmt.Errorf("key shard %d is encrypted, decrypt it before resharing", key.ID)
+		}
+		if !key.PubKey.Equals(pubKey) {
+			return nil, fmt.Errorf("key shard %d is for public key %X, expected %X",
+				key.ID, key.PubKey.Bytes(), pubKey.Bytes())
+		}
+		if key.ID <= 0 || key.ID > 255 {
+			return nil, fmt.Errorf("key shard has invalid ID %d", key.ID)
+		}
+		if seen[key.ID] {
+			return nil, fmt.Errorf("key shard %d is given more than once", key.ID)
+		}
+		seen[key.ID] = true
+		if key.ID > maxID {
+			maxID = key.ID
+		}
+		ids[i] = key.ID
+		privShards[i] = key.PrivateShard
+	}
+
+	secret := tsed25519.CombineShares(uint8(maxID), ids, privShards)
+	if !bytes.Equal(tsed25519.ScalarMultiplyBase(secret), pubKey.Bytes()) {
+		return nil, fmt.Errorf("the %d key shards do not combine to the validator key, "+
+			"at least the current threshold of shards is required", len(keys))
+	}
+
+	newShards := tsed25519.DealShares(secret, threshold, shards)
+	out := make([]CosignerEd25519Key, shards)
+	for i, shard := range newShards {
+		out[i] = CosignerEd25519Key{
+			PubKey:       pubKey,
+			PrivateShard: shard,
+			ID:           i + 1,
+		}
+	}
+	return out, nil
+}
+
+// CreateCosignerEd25519ShardsFromSeed creates CosignerEd25519Key objects for a validator key derived from the seed.
+// The shares are dealt deterministically, so the same seed, threshold and shards always produce the same key shards.
+// UNSAFE FOR PRODUCTION: the validator key can be recovered by anyone who knows the seed.
+func CreateCosignerEd25519ShardsFromSeed(seed []byte, threshold, shards uint8) []CosignerEd25519Key {
+	privKey := cometcryptoed25519.GenPrivKeyFromSecret(seed)
+	privShards := dealSharesFromSeed(tsed25519.ExpandSecret(privKey.Bytes()[:32]), seed, threshold, shards)
+	out := make([]CosignerEd25519Key, shards)
+	for i, shard := range privShards {
+		out[i] = CosignerEd25519Key{
+			PubKey:       privKey.PubKey(),
+			PrivateShard: shard,
+			ID:           i + 1,
+		}
+	}
+	return out
+}
+
+// ed25519OrderL is the order of the ed25519 base point, the shares are dealt modulo L.
+var ed25519OrderL, _ = new(big.Int).SetString(
+	"7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+
+// dealSharesFromSeed is tsed25519.DealShares with the polynomial coefficients derived from the seed
+// instead of read from crypto/rand.
+func dealSharesFromSeed(secret, seed []byte, threshold, total uint8) []tsed25519.Scalar {
+	coeffs := make([]*big.Int, threshold)
+	coeffs[0] = new(big.Int).SetBytes(reverseBytes(secret))
+	for i := uint8(1); i < threshold; i++ {
+		msg := append([]byte("horcrux-unsafe-shard-coefficient"), seed...)
+		h := sha512.Sum512(append(msg, i))
+		coeffs[i] = new(big.Int).Mod(new(big.Int).SetBytes(h[:]), ed25519OrderL)
+	}
+
+	shares := make([]tsed25519.Scalar, total)
+	for i := uint8(0); i < total; i++ {
+		// evaluate the polynomial at i+1 with horner's method
+		x := big.NewInt(int64(i) + 1)
+		share := new(big.Int).Set(coeffs[threshold-1])
+		for j := int(threshold) - 2; j >= 0; j-- {
+			share.Mul(share, x)
+			share.Add(share, coeffs[j])
+			share.Mod(share, ed25519OrderL)
+		}
+		shares[i] = reverseBytes(share.FillBytes(make([]byte, 32)))
+	}
+	return shares
+}
+
+// reverseBytes converts between the little endian scalars of tsed25519 and the big endian bytes of big.Int.
+func reverseBytes(bz []byte) []byte {
+	out := make([]byte, len(bz))
+	for i, b := range bz {
+		out[len(bz)-1-i] = b
+	}
+	return out
+}
+
 // CreateCosignerRSAShards generate  CosignerRSAKey objects.
 func CreateCosignerRSAShards(shards int) ([]CosignerRSAKey, error) {
 	rsaKeys, pubKeys, err := makeRSAKeys(shards)
@@ -66,13 +182,14 @@ func ReadPrivValidatorFile(priv string) (out privval.FilePVKey, err error) {
 	return
 }
 
-// WriteCosignerEd25519ShardFile writes a cosigner Ed25519 key to a given file name.
+// WriteCosignerEd25519ShardFile writes a cosigner Ed25519 key to a given file name, along with its checksum.
+// The file is written atomically so that a partial write can not leave a corrupt shard behind.
 func WriteCosignerEd25519ShardFile(cosigner CosignerEd25519Key, file string) error {
-	jsonBytes, err := json.Marshal(&cosigner)
+	jsonBytes, err := marshalShardFile(&cosigner)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(file, jsonBytes, 0600)
+	return tempfile.WriteFileAtomic(file, jsonBytes, 0600)
 }
 
 // WriteCosignerRSAShardFile writes a cosigner RSA key to a given file name.
@@ -0,0 +1,75 @@
+package signer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tsed25519 "gitlab.com/unit410/threshold-ed25519/pkg"
+)
+
+func TestCreateCosignerEd25519ShardsFromSeed(t *testing.T) {
+	seed := []byte("horcrux-test-seed")
+
+	keys := CreateCosignerEd25519ShardsFromSeed(seed, 2, 3)
+	require.Len(t, keys, 3)
+
+	// the same seed always produces the same key shards.
+	require.Equal(t, keys, CreateCosignerEd25519ShardsFromSeed(seed, 2, 3))
+	require.NotEqual(t, keys, CreateCosignerEd25519ShardsFromSeed([]byte("other-seed"), 2, 3))
+
+	// any threshold of shares combines to the secret of the public key.
+	for _, ids := range [][]int{{1, 2}, {1, 3}, {2, 3}} {
+		shares := make([][]byte, len(ids))
+		for i, id := range ids {
+			require.Equal(t, id, keys[id-1].ID)
+			require.Equal(t, keys[0].PubKey, keys[id-1].PubKey)
+			shares[i] = keys[id-1].PrivateShard
+		}
+		secret := tsed25519.CombineShares(3, ids, shares)
+		require.Equal(t, keys[0].PubKey.Bytes(), []byte(tsed25519.ScalarMultiplyBase(secret)))
+	}
+
+	// a single share does not.
+	require.NotEqual(t, keys[0].PubKey.Bytes(),
+		[]byte(tsed25519.ScalarMultiplyBase(tsed25519.CombineShares(3, []int{1}, [][]byte{keys[0].PrivateShard}))))
+}
+
+func TestReshareCosignerEd25519Keys(t *testing.T) {
+	keys := CreateCosignerEd25519ShardsFromSeed([]byte("horcrux-test-seed"), 2, 3)
+
+	// any threshold of the current shards deals new shards of the same key.
+	reshared, err := ReshareCosignerEd25519Keys([]CosignerEd25519Key{keys[2], keys[0]}, 3, 5)
+	require.NoError(t, err)
+	require.Len(t, reshared, 5)
+
+	for _, ids := range [][]int{{1, 2, 3}, {1, 3, 5}, {2, 4, 5}} {
+		shares := make([][]byte, len(ids))
+		for i, id := range ids {
+			require.Equal(t, id, reshared[id-1].ID)
+			require.Equal(t, keys[0].PubKey, reshared[id-1].PubKey)
+			shares[i] = reshared[id-1].PrivateShard
+		}
+		secret := tsed25519.CombineShares(5, ids, shares)
+		require.Equal(t, keys[0].PubKey.Bytes(), []byte(tsed25519.ScalarMultiplyBase(secret)))
+	}
+
+	// the new threshold is required.
+	secret := tsed25519.CombineShares(5, []int{1, 2}, [][]byte{reshared[0].PrivateShard, reshared[1].PrivateShard})
+	require.NotEqual(t, keys[0].PubKey.Bytes(), []byte(tsed25519.ScalarMultiplyBase(secret)))
+
+	// shrinking the cluster works the same way.
+	shrunk, err := ReshareCosignerEd25519Keys(reshared[2:], 2, 3)
+	require.NoError(t, err)
+	secret = tsed25519.CombineShares(3, []int{1, 3}, [][]byte{shrunk[0].PrivateShard, shrunk[2].PrivateShard})
+	require.Equal(t, keys[0].PubKey.Bytes(), []byte(tsed25519.ScalarMultiplyBase(secret)))
+
+	_, err = ReshareCosignerEd25519Keys(keys[:1], 3, 5)
+	require.ErrorContains(t, err, "at least the current threshold of shards is required")
+
+	_, err = ReshareCosignerEd25519Keys([]CosignerEd25519Key{keys[0], keys[0]}, 3, 5)
+	require.ErrorContains(t, err, "given more than once")
+
+	other := CreateCosignerEd25519ShardsFromSeed([]byte("other-seed"), 2, 3)
+	_, err = ReshareCosignerEd25519Keys([]CosignerEd25519Key{keys[0], other[1]}, 3, 5)
+	require.ErrorContains(t, err, "is for public key")
+}
@@ -0,0 +1,192 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// consensus takes a handful of requests per chain and block from each peer, so the defaults only
+	// throttle a runaway client.
+	defaultRateLimitRequestsPerSecond = 50
+	defaultRateLimitBurst             = 100
+
+	// maxRateLimitBuckets bounds the number of chain and peer pairs that are limited separately, so that
+	// requests for arbitrary chain IDs can not grow the limiter without bound.
+	maxRateLimitBuckets = 1024
+)
+
+// CosignerRateLimitConfig limits the rate of the cosigner gRPC requests from each peer for each chain,
+// so that a misbehaving peer can not starve consensus signing.
+type CosignerRateLimitConfig struct {
+	// RequestsPerSecond is the sustained rate of requests allowed per chain and peer. Defaults to 50.
+	RequestsPerSecond float64 `yaml:"requestsPerSecond,omitempty"`
+	// Burst is the number of requests per chain and peer allowed above the sustained rate. Defaults to 100.
+	Burst int `yaml:"burst,omitempty"`
+	// Disabled turns off rate limiting.
+	Disabled bool `yaml:"disabled,omitempty"`
+}
+
+func (c *CosignerRateLimitConfig) Validate() error {
+	if c.RequestsPerSecond < 0 {
+		return fmt.Errorf("rateLimit requestsPerSecond (%g) must not be negative", c.RequestsPerSecond)
+	}
+	if c.Burst < 0 {
+		return fmt.Errorf("rateLimit burst (%d) must not be negative", c.Burst)
+	}
+	return nil
+}
+
+// limits returns the configured limits, or the defaults for a nil config.
+func (c *CosignerRateLimitConfig) limits() (requestsPerSecond float64, burst int) {
+	requestsPerSecond, burst = defaultRateLimitRequestsPerSecond, defaultRateLimitBurst
+	if c == nil {
+		return requestsPerSecond, burst
+	}
+	if c.RequestsPerSecond > 0 {
+		requestsPerSecond = c.RequestsPerSecond
+	}
+	if c.Burst > 0 {
+		burst = c.Burst
+	}
+	return requestsPerSecond, burst
+}
+
+// serverOption returns the rate limiting interceptor of the cosigner gRPC server.
+func (c *CosignerRateLimitConfig) serverOption() grpc.ServerOption {
+	if c != nil && c.Disabled {
+		return grpc.EmptyServerOption{}
+	}
+	requestsPerSecond, burst := c.limits()
+	return grpc.ChainUnaryInterceptor(newGRPCRateLimiter(requestsPerSecond, burst).unaryServerInterceptor)
+}
+
+type rateLimitKey struct {
+	chainID string
+	peer    string
+}
+
+// tokenBucket holds up to burst tokens, refilled at the requests per second. A request takes a token.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// grpcRateLimiter limits the cosigner gRPC requests with a token bucket for each chain and peer.
+// The raft transport RPCs are not limited.
+type grpcRateLimiter struct {
+	requestsPerSecond float64
+	burst             float64
+
+	mu      sync.Mutex
+	buckets map[rateLimitKey]*tokenBucket
+
+	now func() time.Time
+}
+
+func newGRPCRateLimiter(requestsPerSecond float64, burst int) *grpcRateLimiter {
+	return &grpcRateLimiter{
+		requestsPerSecond: requestsPerSecond,
+		burst:             float64(burst),
+		buckets:           make(map[rateLimitKey]*tokenBucket),
+		now:               time.Now,
+	}
+}
+
+// allow takes a token from the bucket of the chain and peer, returning false if it is empty.
+func (l *grpcRateLimiter) allow(key rateLimitKey) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitBuckets {
+			l.deleteFullBuckets(now)
+		}
+		if len(l.buckets) >= maxRateLimitBuckets {
+			// too many chains are requested, limit the new ones together with the other requests of the peer.
+			key.chainID = ""
+			b, ok = l.buckets[key]
+		}
+		if !ok {
+			b = &tokenBucket{tokens: l.burst, last: now}
+			l.buckets[key] = b
+		}
+	}
+
+	l.refill(b, now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (l *grpcRateLimiter) refill(b *tokenBucket, now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed*l.requestsPerSecond)
+	}
+	b.last = now
+}
+
+// deleteFullBuckets removes the buckets that have been idle long enough to be full again, since a new
+// bucket starts out full. Must be called with the mutex held.
+func (l *grpcRateLimiter) deleteFullBuckets(now time.Time) {
+	for key, b := range l.buckets {
+		l.refill(b, now)
+		if b.tokens >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *grpcRateLimiter) unaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, "/"+proto.CosignerGRPC_ServiceDesc.ServiceName+"/") {
+		return handler(ctx, req)
+	}
+
+	var chainID string
+	if r, ok := req.(chainIDRequest); ok {
+		chainID = r.GetChainID()
+	}
+
+	if !l.allow(rateLimitKey{chainID: chainID, peer: peerHost(ctx)}) {
+		totalGRPCRequestsThrottled.WithLabelValues(path.Base(info.FullMethod), chainID).Inc()
+		return nil, status.Errorf(codes.ResourceExhausted, "rate limit of %g requests per second exceeded for chain %q",
+			l.requestsPerSecond, chainID)
+	}
+
+	return handler(ctx, req)
+}
+
+// peerHost returns the host of the peer of the gRPC request, without the port, which differs per connection.
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -0,0 +1,105 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestCosignerRateLimitConfig(t *testing.T) {
+	// rate limiting is enabled by default.
+	var c *CosignerRateLimitConfig
+	requestsPerSecond, burst := c.limits()
+	require.Equal(t, 50.0, requestsPerSecond)
+	require.Equal(t, 100, burst)
+
+	c = &CosignerRateLimitConfig{RequestsPerSecond: 10}
+	require.NoError(t, c.Validate())
+	requestsPerSecond, burst = c.limits()
+	require.Equal(t, 10.0, requestsPerSecond)
+	require.Equal(t, 100, burst)
+
+	require.EqualError(t, (&CosignerRateLimitConfig{Burst: -1}).Validate(), "rateLimit burst (-1) must not be negative")
+}
+
+func peerContext(addr string) context.Context {
+	tcpAddr, _ := net.ResolveTCPAddr("tcp", addr)
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: tcpAddr})
+}
+
+func TestGRPCRateLimiter(t *testing.T) {
+	const chainID = "rate-limit-1"
+
+	now := time.Now()
+	l := newGRPCRateLimiter(10, 5)
+	l.now = func() time.Time { return now }
+
+	handler := func(context.Context, any) (any, error) {
+		return "ok", nil
+	}
+
+	signInfo := &grpc.UnaryServerInfo{FullMethod: "/" + proto.CosignerGRPC_ServiceDesc.ServiceName + "/GetNonces"}
+	req := &proto.CosignerGRPCGetNoncesRequest{ChainID: chainID}
+
+	call := func(ctx context.Context, req any) error {
+		_, err := l.unaryServerInterceptor(ctx, req, signInfo, handler)
+		return err
+	}
+
+	// the burst is allowed, then requests are throttled.
+	peer1 := peerContext("10.0.0.1:1234")
+	for i := 0; i < 5; i++ {
+		require.NoError(t, call(peer1, req))
+	}
+	err := call(peer1, req)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, 1.0, testutil.ToFloat64(totalGRPCRequestsThrottled.prom.WithLabelValues("GetNonces", chainID)))
+
+	// the limit is per peer host, regardless of the connection.
+	require.Error(t, call(peerContext("10.0.0.1:5678"), req))
+	require.NoError(t, call(peerContext("10.0.0.2:1234"), req))
+
+	// the limit is per chain.
+	require.NoError(t, call(peer1, &proto.CosignerGRPCGetNoncesRequest{ChainID: "rate-limit-2"}))
+
+	// tokens are refilled at the sustained rate.
+	now = now.Add(200 * time.Millisecond)
+	require.NoError(t, call(peer1, req))
+	require.NoError(t, call(peer1, req))
+	require.Error(t, call(peer1, req))
+
+	// the raft transport is not limited.
+	raftInfo := &grpc.UnaryServerInfo{FullMethod: "/RaftTransport/AppendEntries"}
+	for i := 0; i < 10; i++ {
+		_, err := l.unaryServerInterceptor(peer1, nil, raftInfo, handler)
+		require.NoError(t, err)
+	}
+}
+
+func TestGRPCRateLimiterBuckets(t *testing.T) {
+	now := time.Now()
+	l := newGRPCRateLimiter(1, 1)
+	l.now = func() time.Time { return now }
+
+	// requests for arbitrary chain IDs do not grow the limiter without bound.
+	for i := 0; i < 2*maxRateLimitBuckets; i++ {
+		l.allow(rateLimitKey{chainID: fmt.Sprintf("chain-%d", i), peer: "10.0.0.1"})
+	}
+	require.LessOrEqual(t, len(l.buckets), maxRateLimitBuckets+1)
+	require.False(t, l.allow(rateLimitKey{chainID: "chain-new", peer: "10.0.0.1"}))
+
+	// idle buckets are full again and removed to make room.
+	now = now.Add(time.Second)
+	require.True(t, l.allow(rateLimitKey{chainID: "chain-new", peer: "10.0.0.1"}))
+	require.Len(t, l.buckets, 1)
+}
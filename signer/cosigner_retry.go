@@ -0,0 +1,104 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	mrand "math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 25 * time.Millisecond
+	defaultRetryBudget         = 250 * time.Millisecond
+)
+
+// CosignerRetryConfig configures the retries of the nonce and sign requests to the other cosigners, so that
+// a cosigner that is briefly unreachable does not cause a missed block.
+type CosignerRetryConfig struct {
+	// MaxAttempts is the number of times a request is sent, including the first. Defaults to 3, 1 disables retries.
+	MaxAttempts int `yaml:"maxAttempts,omitempty"`
+	// InitialBackoff is the wait before the first retry, doubled for each further retry, with jitter.
+	// Defaults to 25ms.
+	InitialBackoff string `yaml:"initialBackoff,omitempty"`
+	// Budget is the total time after which a request is no longer retried. Keep it well below the
+	// consensus timeouts. Defaults to 250ms.
+	Budget string `yaml:"budget,omitempty"`
+}
+
+func (c *CosignerRetryConfig) Validate() error {
+	if c.MaxAttempts < 0 {
+		return fmt.Errorf("retry maxAttempts (%d) must not be negative", c.MaxAttempts)
+	}
+	if c.InitialBackoff != "" {
+		if d, err := time.ParseDuration(c.InitialBackoff); err != nil {
+			return fmt.Errorf("invalid retry initialBackoff: %w", err)
+		} else if d <= 0 {
+			return fmt.Errorf("retry initialBackoff (%s) must be greater than 0", d)
+		}
+	}
+	if c.Budget != "" {
+		if d, err := time.ParseDuration(c.Budget); err != nil {
+			return fmt.Errorf("invalid retry budget: %w", err)
+		} else if d <= 0 {
+			return fmt.Errorf("retry budget (%s) must be greater than 0", d)
+		}
+	}
+	return nil
+}
+
+// params returns the configured retry parameters, or the defaults for a nil config.
+func (c *CosignerRetryConfig) params() (maxAttempts int, initialBackoff, budget time.Duration) {
+	maxAttempts, initialBackoff, budget = defaultRetryMaxAttempts, defaultRetryInitialBackoff, defaultRetryBudget
+	if c == nil {
+		return maxAttempts, initialBackoff, budget
+	}
+
+	if c.MaxAttempts > 0 {
+		maxAttempts = c.MaxAttempts
+	}
+	// Validated prior in ValidateThresholdModeConfig
+	if d, err := time.ParseDuration(c.InitialBackoff); err == nil {
+		initialBackoff = d
+	}
+	if d, err := time.ParseDuration(c.Budget); err == nil {
+		budget = d
+	}
+	return maxAttempts, initialBackoff, budget
+}
+
+// retry calls fn until it succeeds, fails with an error other than the cosigner being unavailable,
+// or the attempts or budget are spent. The request of fn must be the same for each call, so that the
+// nonces of a request are only ever signed with the same sign bytes: a cosigner that already handled
+// the request returns the same nonces, or the signature it already made.
+func (c *CosignerRetryConfig) retry(ctx context.Context, peer string, fn func() error) error {
+	maxAttempts, backoff, budget := c.params()
+	deadline := time.Now().Add(budget)
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= maxAttempts || status.Code(err) != codes.Unavailable {
+			return err
+		}
+
+		// wait between half and the full backoff, so that retries to a recovering cosigner are spread out.
+		wait := backoff/2 + time.Duration(mrand.Int63n(int64(backoff/2)+1))
+		if time.Now().Add(wait).After(deadline) {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		totalCosignerRetries.WithLabelValues(peer).Inc()
+		backoff *= 2
+	}
+}
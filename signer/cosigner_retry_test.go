@@ -0,0 +1,117 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCosignerRetryConfig(t *testing.T) {
+	// retries are enabled by default.
+	var c *CosignerRetryConfig
+	maxAttempts, initialBackoff, budget := c.params()
+	require.Equal(t, 3, maxAttempts)
+	require.Equal(t, 25*time.Millisecond, initialBackoff)
+	require.Equal(t, 250*time.Millisecond, budget)
+
+	c = &CosignerRetryConfig{MaxAttempts: 5, Budget: "1s"}
+	require.NoError(t, c.Validate())
+	maxAttempts, initialBackoff, budget = c.params()
+	require.Equal(t, 5, maxAttempts)
+	require.Equal(t, 25*time.Millisecond, initialBackoff)
+	require.Equal(t, time.Second, budget)
+
+	require.EqualError(t, (&CosignerRetryConfig{Budget: "0s"}).Validate(), "retry budget (0s) must be greater than 0")
+	require.ErrorContains(t, (&CosignerRetryConfig{InitialBackoff: "soon"}).Validate(), "invalid retry initialBackoff")
+}
+
+func TestCosignerRetry(t *testing.T) {
+	const peer = "tcp://retry-1:2222"
+
+	c := &CosignerRetryConfig{MaxAttempts: 4, InitialBackoff: "1ms", Budget: "1s"}
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+
+	// a transient failure is retried until it succeeds.
+	calls := 0
+	err := c.retry(context.Background(), peer, func() error {
+		calls++
+		if calls < 3 {
+			return unavailable
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+	require.Equal(t, 2.0, testutil.ToFloat64(totalCosignerRetries.prom.WithLabelValues(peer)))
+
+	// the attempts are bounded.
+	calls = 0
+	err = c.retry(context.Background(), peer, func() error {
+		calls++
+		return unavailable
+	})
+	require.ErrorIs(t, err, unavailable)
+	require.Equal(t, 4, calls)
+
+	// errors other than the cosigner being unavailable are not retried.
+	calls = 0
+	err = c.retry(context.Background(), peer, func() error {
+		calls++
+		return status.Error(codes.FailedPrecondition, "regression")
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Equal(t, 1, calls)
+
+	calls = 0
+	err = c.retry(context.Background(), peer, func() error {
+		calls++
+		return errors.New("failed")
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+
+	// no retry is started past the budget.
+	c = &CosignerRetryConfig{MaxAttempts: 10, InitialBackoff: "20ms", Budget: "50ms"}
+	calls = 0
+	start := time.Now()
+	err = c.retry(context.Background(), peer, func() error {
+		calls++
+		return unavailable
+	})
+	require.ErrorIs(t, err, unavailable)
+	require.Less(t, calls, 10)
+	require.Less(t, time.Since(start), 100*time.Millisecond)
+
+	// nor once the request is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = c.retry(ctx, peer, func() error {
+		calls++
+		cancel()
+		return unavailable
+	})
+	require.ErrorIs(t, err, unavailable)
+	require.Equal(t, 1, calls)
+}
+
+func TestRemoteCosignerRetry(t *testing.T) {
+	// reserve a port that nothing listens on.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := "tcp://" + lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	cosigner := NewRemoteCosigner(2, address)
+	cosigner.SetRetry(&CosignerRetryConfig{MaxAttempts: 3, InitialBackoff: "1ms"})
+
+	_, err = cosigner.GetNonces(context.Background(), testChainID, HRSTKey{Height: 1, Step: stepPrevote})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, 2.0, testutil.ToFloat64(totalCosignerRetries.prom.WithLabelValues(address)))
+}
@@ -0,0 +1,182 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/strangelove-ventures/horcrux/client"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	// cosignerTLSCertValidity is the validity of the CA and cosigner certificates created by CreateCosignerTLSCerts.
+	cosignerTLSCertValidity = 10 * 365 * 24 * time.Hour
+
+	// CosignerTLSServerName is included in the cosigner certificates created by CreateCosignerTLSCerts,
+	// so that it can be configured as the tls serverName of every cosigner.
+	CosignerTLSServerName = "horcrux-cosigner"
+)
+
+// CosignerTLSConfig enables mutual TLS on the cosigner gRPC connections. Each cosigner presents its certificate
+// both as a server and as a client, and only accepts peers with a certificate signed by the CA.
+// Relative paths are relative to the home directory.
+type CosignerTLSConfig struct {
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+	CAFile   string `yaml:"caFile"`
+	// ServerName is the name verified in the certificates of the other cosigners.
+	// Defaults to the host of their p2pAddr.
+	ServerName string `yaml:"serverName,omitempty"`
+}
+
+func (c *CosignerTLSConfig) Validate() error {
+	var errs []error
+	if c.CertFile == "" {
+		errs = append(errs, fmt.Errorf("tls certFile is required"))
+	}
+	if c.KeyFile == "" {
+		errs = append(errs, fmt.Errorf("tls keyFile is required"))
+	}
+	if c.CAFile == "" {
+		errs = append(errs, fmt.Errorf("tls caFile is required"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c RuntimeConfig) cosignerTLSPath(file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(c.HomeDir, file)
+}
+
+// CosignerTransportCredentials returns the credentials of the cosigner gRPC server and clients.
+// They are insecure unless tls is configured under thresholdMode.
+func (c RuntimeConfig) CosignerTransportCredentials() (credentials.TransportCredentials, error) {
+	tc := c.Config.ThresholdModeConfig
+	if tc == nil || tc.TLS == nil {
+		return insecure.NewCredentials(), nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.cosignerTLSPath(tc.TLS.CertFile), c.cosignerTLSPath(tc.TLS.KeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cosigner tls certificate: %w", err)
+	}
+
+	caFile := c.cosignerTLSPath(tc.TLS.CAFile)
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cosigner tls ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in cosigner tls ca %s", caFile)
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ServerName:   tc.TLS.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// CosignerTLSCert is the PEM encoded certificate and key of a cosigner, signed by the cosigner CA.
+type CosignerTLSCert struct {
+	ID   int
+	Cert []byte
+	Key  []byte
+}
+
+// CreateCosignerTLSCerts creates a self-signed CA and a certificate for each of the cosigners, for clusters
+// without a PKI. It returns the PEM encoded CA certificate and the cosigner certificates. Each cosigner certificate
+// is valid for the host of its p2pAddr and for CosignerTLSServerName, as both a server and a client.
+func CreateCosignerTLSCerts(cosigners CosignersConfig) ([]byte, []CosignerTLSCert, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "horcrux cosigner CA"},
+		NotBefore:             now,
+		NotAfter:              now.Add(cosignerTLSCertValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create cosigner ca: %w", err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certs := make([]CosignerTLSCert, len(cosigners))
+	for i, c := range cosigners {
+		address, err := client.SanitizeAddress(c.P2PAddr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid p2pAddr for cosigner %d: %w", c.ShardID, err)
+		}
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid p2pAddr for cosigner %d: %w", c.ShardID, err)
+		}
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i + 2)),
+			Subject:      pkix.Name{CommonName: fmt.Sprintf("horcrux cosigner %d", c.ShardID)},
+			NotBefore:    now,
+			NotAfter:     now.Add(cosignerTLSCertValidity),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+			DNSNames:     []string{CosignerTLSServerName},
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = []net.IP{ip}
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create certificate for cosigner %d: %w", c.ShardID, err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		certs[i] = CosignerTLSCert{
+			ID:   c.ShardID,
+			Cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			Key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		}
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), certs, nil
+}
@@ -0,0 +1,104 @@
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// writeTestCosignerTLSCerts creates the tls files of each cosigner in its own home directory,
+// and returns the runtime config of each cosigner.
+func writeTestCosignerTLSCerts(t *testing.T, cosigners CosignersConfig) []RuntimeConfig {
+	caPEM, certs, err := CreateCosignerTLSCerts(cosigners)
+	require.NoError(t, err)
+
+	configs := make([]RuntimeConfig, len(certs))
+	for i, c := range certs {
+		home := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(home, "ca.crt"), caPEM, 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(home, "cosigner.crt"), c.Cert, 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(home, "cosigner.key"), c.Key, 0600))
+
+		configs[i] = RuntimeConfig{
+			HomeDir: home,
+			Config: Config{
+				ThresholdModeConfig: &ThresholdModeConfig{
+					Cosigners: cosigners,
+					TLS: &CosignerTLSConfig{
+						CertFile: "cosigner.crt",
+						KeyFile:  "cosigner.key",
+						CAFile:   "ca.crt",
+					},
+				},
+			},
+		}
+	}
+	return configs
+}
+
+func TestCosignerTransportCredentials(t *testing.T) {
+	cosigners := CosignersConfig{
+		{ShardID: 1, P2PAddr: "tcp://127.0.0.1:2222"},
+		{ShardID: 2, P2PAddr: "tcp://127.0.0.1:2223"},
+	}
+	configs := writeTestCosignerTLSCerts(t, cosigners)
+	untrusted := writeTestCosignerTLSCerts(t, cosigners)
+
+	serverCreds, err := configs[0].CosignerTransportCredentials()
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(serverCreds))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	check := func(creds credentials.TransportCredentials) error {
+		conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(creds))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	trustedCreds, err := configs[1].CosignerTransportCredentials()
+	require.NoError(t, err)
+	require.NoError(t, check(trustedCreds))
+
+	// a client certificate from another CA is rejected, even if the client trusts the server.
+	untrustedCert, err := tls.LoadX509KeyPair(
+		filepath.Join(untrusted[1].HomeDir, "cosigner.crt"), filepath.Join(untrusted[1].HomeDir, "cosigner.key"))
+	require.NoError(t, err)
+	caPEM, err := os.ReadFile(filepath.Join(configs[1].HomeDir, "ca.crt"))
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caPEM))
+	require.Error(t, check(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{untrustedCert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	})))
+
+	require.Error(t, check(insecure.NewCredentials()))
+
+	// without tls configured, the credentials are insecure.
+	creds, err := RuntimeConfig{Config: Config{ThresholdModeConfig: &ThresholdModeConfig{}}}.CosignerTransportCredentials()
+	require.NoError(t, err)
+	require.Equal(t, "insecure", creds.Info().SecurityProtocol)
+}
@@ -0,0 +1,99 @@
+package signer
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+const (
+	// defaultStateDirMinFreeMB is used when the stateDirMinFreeMB is not configured.
+	defaultStateDirMinFreeMB = 100
+
+	diskSpaceCheckInterval = time.Minute
+)
+
+// stateDirFreeBytes returns the space available to unprivileged users on the filesystem holding dir.
+func stateDirFreeBytes(dir string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return 0, fmt.Errorf("failed to check free space of %s: %w", dir, err)
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil //nolint:unconvert
+}
+
+var _ service.Service = &DiskSpaceMonitor{}
+
+// DiskSpaceMonitor periodically checks the free space of the state directory, since sign state
+// writes fail once the disk is full, and the signer stops signing.
+type DiskSpaceMonitor struct {
+	service.BaseService
+
+	stateDir string
+	minFree  uint64
+
+	// lowSpace is only accessed by check, which is never called concurrently.
+	lowSpace bool
+
+	quit chan struct{}
+}
+
+func NewDiskSpaceMonitor(logger log.Logger, stateDir string, minFree uint64) *DiskSpaceMonitor {
+	m := &DiskSpaceMonitor{
+		stateDir: stateDir,
+		minFree:  minFree,
+		quit:     make(chan struct{}),
+	}
+	m.BaseService = *service.NewBaseService(logger, "DiskSpaceMonitor", m)
+	return m
+}
+
+// OnStart checks the free space once before starting the periodic checks.
+func (m *DiskSpaceMonitor) OnStart() error {
+	m.check()
+	go m.loop()
+	return nil
+}
+
+func (m *DiskSpaceMonitor) OnStop() {
+	close(m.quit)
+}
+
+func (m *DiskSpaceMonitor) loop() {
+	ticker := time.NewTicker(diskSpaceCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.quit:
+			return
+		case <-ticker.C:
+			m.check()
+		}
+	}
+}
+
+func (m *DiskSpaceMonitor) check() {
+	free, err := stateDirFreeBytes(m.stateDir)
+	if err != nil {
+		m.Logger.Error("Failed to check state directory free space", "error", err)
+		return
+	}
+
+	stateDirFreeSpace.Set(float64(free))
+
+	lowSpace := free < m.minFree
+	if lowSpace {
+		m.Logger.Error(
+			"Low free space for the state directory, sign state writes will fail when the disk is full",
+			"state_dir", m.stateDir,
+			"free_mb", free/1e6,
+			"min_free_mb", m.minFree/1e6,
+		)
+	} else if m.lowSpace {
+		m.Logger.Info("State directory free space recovered", "state_dir", m.stateDir, "free_mb", free/1e6)
+	}
+	m.lowSpace = lowSpace
+}
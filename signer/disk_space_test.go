@@ -0,0 +1,48 @@
+package signer
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskSpaceMonitor(t *testing.T) {
+	stateDir := t.TempDir()
+
+	m := NewDiskSpaceMonitor(cometlog.NewNopLogger(), stateDir, 0)
+	require.NoError(t, m.Start())
+	defer func() {
+		require.NoError(t, m.Stop())
+	}()
+
+	require.False(t, m.lowSpace)
+	require.NotZero(t, testutil.ToFloat64(stateDirFreeSpace.vec.prom.WithLabelValues()))
+
+	// no filesystem has this much free space
+	m.minFree = math.MaxUint64
+	m.check()
+	require.True(t, m.lowSpace)
+
+	m.minFree = 0
+	m.check()
+	require.False(t, m.lowSpace)
+}
+
+func TestGRPCServerGetStatus(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+
+	res, err := NewGRPCServer(cosigners[0], nil, nil).GetStatus(
+		context.Background(),
+		&proto.CosignerGRPCGetStatusRequest{},
+	)
+	require.NoError(t, err)
+	require.NotZero(t, res.StateDirFreeBytes)
+	require.Equal(t, res.StateDirFreeBytes >= cosigners[0].config.Config.StateDirMinFreeBytes(), res.Ready)
+	require.WithinDuration(t, time.Now(), time.Unix(0, res.Time), time.Second)
+}
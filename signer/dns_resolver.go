@@ -0,0 +1,43 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// defaultDNSPort is used when the dnsResolver is configured without a port.
+	defaultDNSPort = "53"
+
+	dnsResolverTimeout = 5 * time.Second
+)
+
+// newDNSResolver returns a resolver that sends every query to the DNS server at addr.
+func newDNSResolver(addr string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{Timeout: dnsResolverTimeout}
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// SetDNSResolver resolves the hostnames of the cosigners and chain nodes, and any other hostname
+// dialed by horcrux, with the DNS server at addr instead of the system resolver.
+// It returns an error if the DNS server does not answer a query for the root name servers.
+func SetDNSResolver(addr string) error {
+	resolver := newDNSResolver(addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), dnsResolverTimeout)
+	defer cancel()
+
+	if _, err := resolver.LookupNS(ctx, "."); err != nil {
+		return fmt.Errorf("dns resolver %s is not reachable: %w", addr, err)
+	}
+
+	net.DefaultResolver = resolver
+	return nil
+}
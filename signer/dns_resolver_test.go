@@ -0,0 +1,78 @@
+package signer
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// serveTestDNS answers every A query with 10.0.0.1 and every NS query with ns.test.,
+// as a DNS server with a different view than the system resolver.
+func serveTestDNS(t *testing.T) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var req dnsmessage.Message
+			if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) != 1 {
+				continue
+			}
+			q := req.Questions[0]
+
+			res := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: req.ID, Response: true, Authoritative: true},
+				Questions: req.Questions,
+			}
+			hdr := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 60}
+			switch q.Type {
+			case dnsmessage.TypeA:
+				res.Answers = append(res.Answers, dnsmessage.Resource{
+					Header: hdr,
+					Body:   &dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}},
+				})
+			case dnsmessage.TypeNS:
+				res.Answers = append(res.Answers, dnsmessage.Resource{
+					Header: hdr,
+					Body:   &dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns.test.")},
+				})
+			}
+
+			out, err := res.Pack()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(out, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestDNSResolver(t *testing.T) {
+	addr := serveTestDNS(t)
+
+	addrs, err := newDNSResolver(addr).LookupHost(context.Background(), "cosigner-2.horcrux.test")
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1"}, addrs)
+
+	defaultResolver := net.DefaultResolver
+	defer func() { net.DefaultResolver = defaultResolver }()
+
+	// nothing is listening on the discard port.
+	require.ErrorContains(t, SetDNSResolver("127.0.0.1:9"), "dns resolver 127.0.0.1:9 is not reachable")
+	require.Equal(t, defaultResolver, net.DefaultResolver)
+
+	require.NoError(t, SetDNSResolver(addr))
+	require.NotEqual(t, defaultResolver, net.DefaultResolver)
+}
@@ -0,0 +1,105 @@
+package signer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxDrillDuration limits how long a cosigner can be taken down by a failure drill.
+const maxDrillDuration = time.Hour
+
+// drill is the failure drill state of the cosigner in this process. While a drill is active,
+// every gRPC call to or from the cosigner fails, including the raft transport, as if the cosigner was down.
+var drill = &cosignerDrill{}
+
+var errDrillActive = status.Error(codes.Unavailable, "cosigner is down for a failure drill")
+
+type cosignerDrill struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// start takes the cosigner down until the duration has elapsed, and returns when it will recover.
+func (d *cosignerDrill) start(duration time.Duration) time.Time {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.until = time.Now().Add(duration)
+	return d.until
+}
+
+func (d *cosignerDrill) active() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return time.Now().Before(d.until)
+}
+
+func (d *cosignerDrill) unaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if d.active() {
+		return nil, errDrillActive
+	}
+	return handler(ctx, req)
+}
+
+func (d *cosignerDrill) streamServerInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if d.active() {
+		return errDrillActive
+	}
+	return handler(srv, ss)
+}
+
+func (d *cosignerDrill) unaryClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if d.active() {
+		return errDrillActive
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (d *cosignerDrill) streamClientInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	if d.active() {
+		return nil, errDrillActive
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+func drillDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(drill.unaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(drill.streamClientInterceptor),
+	}
+}
+
+func drillServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(drill.unaryServerInterceptor),
+		grpc.ChainStreamInterceptor(drill.streamServerInterceptor),
+	}
+}
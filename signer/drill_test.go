@@ -0,0 +1,61 @@
+package signer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCosignerDrill(t *testing.T) {
+	d := &cosignerDrill{}
+
+	handler := func(context.Context, any) (any, error) {
+		return "ok", nil
+	}
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return nil
+	}
+
+	res, err := d.unaryServerInterceptor(context.Background(), nil, nil, handler)
+	require.NoError(t, err)
+	require.Equal(t, "ok", res)
+
+	d.start(100 * time.Millisecond)
+
+	// both incoming and outgoing requests fail while the drill is active.
+	_, err = d.unaryServerInterceptor(context.Background(), nil, nil, handler)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	err = d.unaryClientInterceptor(context.Background(), "", nil, nil, nil, invoker)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	// the cosigner recovers after the duration.
+	require.Eventually(t, func() bool { return !d.active() }, time.Second, 10*time.Millisecond)
+
+	res, err = d.unaryServerInterceptor(context.Background(), nil, nil, handler)
+	require.NoError(t, err)
+	require.Equal(t, "ok", res)
+	require.NoError(t, d.unaryClientInterceptor(context.Background(), "", nil, nil, nil, invoker))
+}
+
+func TestGRPCServerDrillKillGated(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+
+	rpc := NewGRPCServer(cosigners[0], nil, nil)
+
+	_, err := rpc.DrillKill(context.Background(), &proto.CosignerGRPCDrillKillRequest{Duration: int64(time.Minute)})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	cosigners[0].config.Config.ThresholdModeConfig.EnableDrills = true
+
+	_, err = rpc.DrillKill(context.Background(), &proto.CosignerGRPCDrillKillRequest{
+		Duration: int64(2 * maxDrillDuration),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.False(t, drill.active())
+}
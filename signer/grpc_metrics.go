@@ -0,0 +1,50 @@
+package signer
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"google.golang.org/grpc"
+)
+
+// chainIDRequest is implemented by the cosigner gRPC requests that are scoped to a chain.
+type chainIDRequest interface {
+	GetChainID() string
+}
+
+// grpcMetricsServerOption records the count, errors and latency of each cosigner gRPC request
+// by method and chain ID. The raft transport RPCs are excluded, like for tracing.
+func grpcMetricsServerOption() grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(grpcMetricsUnaryServerInterceptor)
+}
+
+func grpcMetricsUnaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, "/"+proto.CosignerGRPC_ServiceDesc.ServiceName+"/") {
+		return handler(ctx, req)
+	}
+
+	method := path.Base(info.FullMethod)
+	var chainID string
+	if r, ok := req.(chainIDRequest); ok {
+		chainID = r.GetChainID()
+	}
+
+	start := time.Now()
+	res, err := handler(ctx, req)
+
+	totalGRPCRequests.WithLabelValues(method, chainID).Inc()
+	timedGRPCRequestLatency.WithLabelValues(method, chainID).Observe(time.Since(start).Seconds())
+	if err != nil {
+		totalGRPCRequestErrors.WithLabelValues(method, chainID).Inc()
+	}
+
+	return res, err
+}
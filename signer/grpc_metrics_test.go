@@ -0,0 +1,49 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestGRPCMetricsUnaryServerInterceptor(t *testing.T) {
+	const chainID = "grpc-metrics-1"
+
+	handler := func(context.Context, any) (any, error) {
+		return "ok", nil
+	}
+	failing := func(context.Context, any) (any, error) {
+		return nil, errors.New("failed")
+	}
+
+	signInfo := &grpc.UnaryServerInfo{FullMethod: "/" + proto.CosignerGRPC_ServiceDesc.ServiceName + "/SetNoncesAndSign"}
+	req := &proto.CosignerGRPCSetNoncesAndSignRequest{ChainID: chainID}
+
+	res, err := grpcMetricsUnaryServerInterceptor(context.Background(), req, signInfo, handler)
+	require.NoError(t, err)
+	require.Equal(t, "ok", res)
+	_, err = grpcMetricsUnaryServerInterceptor(context.Background(), req, signInfo, failing)
+	require.Error(t, err)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(totalGRPCRequests.prom.WithLabelValues("SetNoncesAndSign", chainID)))
+	require.Equal(t, 1.0, testutil.ToFloat64(totalGRPCRequestErrors.prom.WithLabelValues("SetNoncesAndSign", chainID)))
+	require.Equal(t, 1, testutil.CollectAndCount(timedGRPCRequestLatency.prom, "signer_grpc_request_duration_seconds"))
+
+	// requests without a chain ID are recorded with an empty chain_id.
+	leaderInfo := &grpc.UnaryServerInfo{FullMethod: "/" + proto.CosignerGRPC_ServiceDesc.ServiceName + "/GetLeader"}
+	leaderReq := &proto.CosignerGRPCGetLeaderRequest{}
+	_, err = grpcMetricsUnaryServerInterceptor(context.Background(), leaderReq, leaderInfo, handler)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, testutil.ToFloat64(totalGRPCRequests.prom.WithLabelValues("GetLeader", "")))
+
+	// the raft transport is not recorded.
+	raftInfo := &grpc.UnaryServerInfo{FullMethod: "/RaftTransport/AppendEntries"}
+	_, err = grpcMetricsUnaryServerInterceptor(context.Background(), nil, raftInfo, handler)
+	require.NoError(t, err)
+	require.Equal(t, 0.0, testutil.ToFloat64(totalGRPCRequests.prom.WithLabelValues("AppendEntries", "")))
+}
@@ -2,11 +2,12 @@ package signer
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/hashicorp/raft"
 	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var _ proto.CosignerGRPCServer = &GRPCServer{}
@@ -31,19 +32,17 @@ func NewGRPCServer(
 }
 
 func (rpc *GRPCServer) SignBlock(
-	_ context.Context,
+	ctx context.Context,
 	req *proto.CosignerGRPCSignBlockRequest,
 ) (*proto.CosignerGRPCSignBlockResponse, error) {
-	block := &Block{
-		Height:    req.Block.GetHeight(),
-		Round:     req.Block.GetRound(),
-		Step:      int8(req.Block.GetStep()),
-		SignBytes: req.Block.GetSignBytes(),
-		Timestamp: time.Unix(0, req.Block.GetTimestamp()),
+	block := BlockFromProto(req.GetBlock())
+	// the sign bytes of a block proxied by another cosigner are not trusted blindly
+	if err := block.VerifySignBytes(req.ChainID); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	res, _, err := rpc.thresholdValidator.SignBlock(req.ChainID, block)
+	res, _, err := rpc.thresholdValidator.SignBlock(ctx, req.ChainID, block)
 	if err != nil {
-		return nil, err
+		return nil, grpcStatusError(err)
 	}
 	return &proto.CosignerGRPCSignBlockResponse{
 		Signature: res,
@@ -51,10 +50,10 @@ func (rpc *GRPCServer) SignBlock(
 }
 
 func (rpc *GRPCServer) SetNoncesAndSign(
-	_ context.Context,
+	ctx context.Context,
 	req *proto.CosignerGRPCSetNoncesAndSignRequest,
 ) (*proto.CosignerGRPCSetNoncesAndSignResponse, error) {
-	res, err := rpc.cosigner.SetNoncesAndSign(CosignerSetNoncesAndSignRequest{
+	res, err := rpc.cosigner.SetNoncesAndSign(ctx, CosignerSetNoncesAndSignRequest{
 		ChainID:   req.ChainID,
 		Nonces:    CosignerNoncesFromProto(req.GetNonces()),
 		HRST:      HRSTKeyFromProto(req.GetHrst()),
@@ -69,7 +68,7 @@ func (rpc *GRPCServer) SetNoncesAndSign(
 			"step", req.Hrst.Step,
 			"error", err,
 		)
-		return nil, err
+		return nil, grpcStatusError(err)
 	}
 	rpc.raftStore.logger.Info(
 		"Signed with shard",
@@ -82,19 +81,21 @@ func (rpc *GRPCServer) SetNoncesAndSign(
 		NoncePublic: res.NoncePublic,
 		Timestamp:   res.Timestamp.UnixNano(),
 		Signature:   res.Signature,
+		Hrst:        res.HRST.toProto(),
 	}, nil
 }
 
 func (rpc *GRPCServer) GetNonces(
-	_ context.Context,
+	ctx context.Context,
 	req *proto.CosignerGRPCGetNoncesRequest,
 ) (*proto.CosignerGRPCGetNoncesResponse, error) {
 	res, err := rpc.cosigner.GetNonces(
+		ctx,
 		req.ChainID,
 		HRSTKeyFromProto(req.GetHrst()),
 	)
 	if err != nil {
-		return nil, err
+		return nil, grpcStatusError(err)
 	}
 	return &proto.CosignerGRPCGetNoncesResponse{
 		Nonces: CosignerNonces(res.Nonces).toProto(),
@@ -102,7 +103,7 @@ func (rpc *GRPCServer) GetNonces(
 }
 
 func (rpc *GRPCServer) TransferLeadership(
-	_ context.Context,
+	ctx context.Context,
 	req *proto.CosignerGRPCTransferLeadershipRequest,
 ) (*proto.CosignerGRPCTransferLeadershipResponse, error) {
 	if rpc.raftStore.raft.State() != raft.Leader {
@@ -111,16 +112,37 @@ func (rpc *GRPCServer) TransferLeadership(
 	leaderID := req.GetLeaderID()
 	if leaderID != "" {
 		for _, c := range rpc.raftStore.Cosigners {
-			shardID := fmt.Sprint(c.GetID())
-			if shardID == leaderID {
-				raftAddress := p2pURLToRaftAddress(c.GetAddress())
-				fmt.Printf("Transferring leadership to ID: %s - Address: %s\n", shardID, raftAddress)
-				rpc.raftStore.raft.LeadershipTransferToServer(raft.ServerID(shardID), raft.ServerAddress(raftAddress))
-				return &proto.CosignerGRPCTransferLeadershipResponse{LeaderID: shardID, LeaderAddress: raftAddress}, nil
+			if string(RaftServerID(c.GetID())) != leaderID {
+				continue
+			}
+			srv, err := peerServer(c)
+			if err != nil {
+				return nil, status.Error(codes.FailedPrecondition, err.Error())
 			}
+			rpc.raftStore.logger.Info("Transferring leadership", "id", srv.ID, "address", srv.Address)
+			rpc.raftStore.raft.LeadershipTransferToServer(srv.ID, srv.Address)
+			return &proto.CosignerGRPCTransferLeadershipResponse{
+				LeaderID:      string(srv.ID),
+				LeaderAddress: string(srv.Address),
+			}, nil
 		}
+		// don't fall back to the next candidate, the operator asked for a specific cosigner.
+		return nil, status.Errorf(codes.InvalidArgument, "cosigner with shard ID %s is not a peer", leaderID)
 	}
-	fmt.Printf("Transferring leadership to next candidate\n")
+	if rpc.raftStore.leaderTransferByLatency {
+		srv, ok, err := rpc.raftStore.transferLeadershipByLatency(ctx)
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if ok {
+			return &proto.CosignerGRPCTransferLeadershipResponse{
+				LeaderID:      string(srv.ID),
+				LeaderAddress: string(srv.Address),
+			}, nil
+		}
+		rpc.raftStore.logger.Error("No cosigner responded to the latency measurement before the leadership transfer")
+	}
+	rpc.raftStore.logger.Info("Transferring leadership to next candidate")
 	rpc.raftStore.raft.LeadershipTransfer()
 	return &proto.CosignerGRPCTransferLeadershipResponse{}, nil
 }
@@ -132,3 +154,79 @@ func (rpc *GRPCServer) GetLeader(
 	leader := rpc.raftStore.GetLeader()
 	return &proto.CosignerGRPCGetLeaderResponse{Leader: string(leader)}, nil
 }
+
+// DrillKill takes this cosigner down for the requested duration, for resilience drills.
+func (rpc *GRPCServer) DrillKill(
+	_ context.Context,
+	req *proto.CosignerGRPCDrillKillRequest,
+) (*proto.CosignerGRPCDrillKillResponse, error) {
+	thresholdCfg := rpc.cosigner.config.Config.ThresholdModeConfig
+	if thresholdCfg == nil || !thresholdCfg.EnableDrills {
+		return nil, status.Error(codes.FailedPrecondition, "drills are not enabled, set thresholdMode.enableDrills")
+	}
+
+	duration := time.Duration(req.Duration)
+	if duration <= 0 || duration > maxDrillDuration {
+		return nil, status.Errorf(codes.InvalidArgument, "drill duration must be between 0 and %s", maxDrillDuration)
+	}
+
+	// hand off leadership first, the cluster would not be able to sign while the leader is down.
+	if rpc.raftStore.IsLeader() {
+		if err := rpc.raftStore.raft.LeadershipTransfer().Error(); err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to transfer leadership before drill: %v", err)
+		}
+	}
+
+	until := drill.start(duration)
+	rpc.raftStore.logger.Error("Cosigner is down for a failure drill", "duration", duration, "until", until)
+	time.AfterFunc(duration, func() {
+		rpc.raftStore.logger.Info("Cosigner recovered from failure drill")
+	})
+
+	return &proto.CosignerGRPCDrillKillResponse{Until: until.UnixNano()}, nil
+}
+
+// SetPaused pauses or resumes signing on every cosigner. It is only served by the leader,
+// which replicates the paused state through raft.
+func (rpc *GRPCServer) SetPaused(
+	_ context.Context,
+	req *proto.CosignerGRPCSetPausedRequest,
+) (*proto.CosignerGRPCSetPausedResponse, error) {
+	if !rpc.raftStore.IsLeader() {
+		return nil, status.Error(codes.Unavailable, "cosigner is not the raft leader")
+	}
+	if err := rpc.raftStore.SetPaused(req.Paused); err != nil {
+		return nil, grpcStatusError(err)
+	}
+	return &proto.CosignerGRPCSetPausedResponse{}, nil
+}
+
+func (rpc *GRPCServer) GetStatus(
+	context.Context,
+	*proto.CosignerGRPCGetStatusRequest,
+) (*proto.CosignerGRPCGetStatusResponse, error) {
+	free, err := stateDirFreeBytes(rpc.cosigner.config.StateDir)
+	if err != nil {
+		return nil, grpcStatusError(err)
+	}
+	return &proto.CosignerGRPCGetStatusResponse{
+		StateDirFreeBytes: free,
+		Ready:             free >= rpc.cosigner.config.Config.StateDirMinFreeBytes(),
+		Time:              time.Now().UnixNano(),
+	}, nil
+}
+
+func (rpc *GRPCServer) GetSignState(
+	_ context.Context,
+	req *proto.CosignerGRPCGetSignStateRequest,
+) (*proto.CosignerGRPCGetSignStateResponse, error) {
+	hrs, err := rpc.cosigner.LastSignState(req.ChainID)
+	if err != nil {
+		return nil, grpcStatusError(err)
+	}
+	return &proto.CosignerGRPCGetSignStateResponse{
+		Height: hrs.Height,
+		Round:  hrs.Round,
+		Step:   int32(hrs.Step),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcStatusError converts an error of a cosigner gRPC handler to a status error, so that clients can
+// tell a request that must never be retried from one that may succeed against another leader or later.
+// Errors that already carry a status, such as the ones returned by the leader for a proxied request,
+// are returned as is.
+func grpcStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(grpcStatusCode(err), err.Error())
+}
+
+// grpcStatusCode is the mapping of the errors of the cosigner to gRPC status codes.
+func grpcStatusCode(err error) codes.Code {
+	var noncesErr *NoncesError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, errShuttingDown):
+		// another cosigner can take the request.
+		return codes.Unavailable
+	case errors.Is(err, errEmptyChainID), errors.Is(err, errBlockTimestampOutOfWindow):
+		return codes.InvalidArgument
+	case errors.Is(err, errChainStateMissing), errors.Is(err, os.ErrNotExist):
+		return codes.NotFound
+	case isSignStateRefusal(err):
+		// signing would regress the sign state or double sign, retrying can not succeed.
+		return codes.FailedPrecondition
+	case errors.As(err, &noncesErr), errors.Is(err, errSignerDisarmed), errors.Is(err, errSigningPaused):
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,104 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	comet "github.com/cometbft/cometbft/types"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCStatusCode(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"regression", newRegressionError("height regression. Got 1, last height 2"), codes.FailedPrecondition},
+		{"conflicting data", newConflictingDataError([]byte{1}, []byte{2}), codes.FailedPrecondition},
+		{"same hrs", newSameHRSError(HRSKey{Height: 1}), codes.FailedPrecondition},
+		{"beyond block", &BeyondBlockError{msg: "Progress already started on block 2.0.1"}, codes.FailedPrecondition},
+		{"wrapped same block", fmt.Errorf("same block error: %w", newSameBlockError(testChainID, HRSKey{})),
+			codes.FailedPrecondition},
+		{"missing nonces", newNoncesError("no metadata at HRS"), codes.FailedPrecondition},
+		{"disarmed", fmt.Errorf("%w, create ARMED to resume signing", errSignerDisarmed), codes.FailedPrecondition},
+		{"paused", fmt.Errorf("%w, run horcrux resume to sign again", errSigningPaused), codes.FailedPrecondition},
+		{"unknown chain id", fmt.Errorf("%w for %s", errChainStateMissing, testChainID), codes.NotFound},
+		{"empty chain id", errEmptyChainID, codes.InvalidArgument},
+		{"block timestamp", fmt.Errorf("%w: 1h from the cosigner clock", errBlockTimestampOutOfWindow),
+			codes.InvalidArgument},
+		{"shutting down", errShuttingDown, codes.Unavailable},
+		{"cancelled", context.Canceled, codes.Canceled},
+		{"deadline exceeded", context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"crypto failure", errors.New("ephemeral share is out of bounds"), codes.Internal},
+		{"status", errDrillActive, codes.Unavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := grpcStatusError(tc.err)
+			require.Equal(t, tc.code, status.Code(err))
+			require.Contains(t, err.Error(), tc.err.Error())
+		})
+	}
+
+	require.NoError(t, grpcStatusError(nil))
+}
+
+func TestGRPCServerStatusCodes(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	defer cosigners[0].waitForSignStatesToFlushToDisk()
+	require.NoError(t, cosigners[0].LoadSignStateIfNecessary(testChainID))
+	rpc := NewGRPCServer(cosigners[0], nil, &RaftStore{logger: log.NewNopLogger()})
+
+	setNoncesAndSign := func(height int64) error {
+		now := time.Now()
+		vote := cometproto.Vote{Height: height, Round: 0, Type: cometproto.PrevoteType, Timestamp: now}
+		hrst := HRSTKey{Height: height, Round: 0, Step: stepPrevote, Timestamp: now.UnixNano()}
+		_, err := rpc.SetNoncesAndSign(context.Background(), &proto.CosignerGRPCSetNoncesAndSignRequest{
+			ChainID:   testChainID,
+			Hrst:      hrst.toProto(),
+			SignBytes: comet.VoteSignBytes(testChainID, &vote),
+		})
+		return err
+	}
+
+	// no nonces were exchanged for the block.
+	require.Equal(t, codes.FailedPrecondition, status.Code(setNoncesAndSign(5)))
+
+	cs, err := cosigners[0].getChainState(testChainID)
+	require.NoError(t, err)
+	cs.lastSignState.Height = 10
+	require.Equal(t, codes.FailedPrecondition, status.Code(setNoncesAndSign(5)))
+
+	cosigners[0].armed = &ArmedFile{logger: log.NewNopLogger(), path: filepath.Join(t.TempDir(), "ARMED")}
+	require.Equal(t, codes.FailedPrecondition, status.Code(setNoncesAndSign(11)))
+
+	_, err = rpc.GetNonces(context.Background(), &proto.CosignerGRPCGetNoncesRequest{Hrst: &proto.HRST{}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = rpc.GetSignState(context.Background(), &proto.CosignerGRPCGetSignStateRequest{ChainID: testChainID2})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// a proxied block without a BlockID can not be verified, so its tampered sign bytes are refused.
+	_, err = rpc.SignBlock(context.Background(), &proto.CosignerGRPCSignBlockRequest{
+		ChainID: testChainID,
+		Block: &proto.Block{
+			Height:    12,
+			Round:     0,
+			Step:      int32(stepPrevote),
+			SignBytes: []byte("tampered"),
+			Timestamp: time.Now().UnixNano(),
+		},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
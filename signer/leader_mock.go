@@ -1,6 +1,7 @@
 package signer
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
@@ -27,7 +28,7 @@ func (m *MockLeader) SetLeader(tv *ThresholdValidator) {
 	m.leader = tv
 }
 
-func (m *MockLeader) SignBlock(req CosignerSignBlockRequest) (*CosignerSignBlockResponse, error) {
+func (m *MockLeader) SignBlock(ctx context.Context, req CosignerSignBlockRequest) (*CosignerSignBlockResponse, error) {
 	var l *ThresholdValidator
 	for i := 0; i < 30; i++ {
 		m.mu.Lock()
@@ -49,8 +50,9 @@ func (m *MockLeader) SignBlock(req CosignerSignBlockRequest) (*CosignerSignBlock
 		Step:      req.Block.Step,
 		SignBytes: req.Block.SignBytes,
 		Timestamp: req.Block.Timestamp,
+		Deadline:  req.Block.Deadline,
 	}
-	res, _, err := l.SignBlock(req.ChainID, block)
+	res, _, err := l.SignBlock(ctx, req.ChainID, block)
 	if err != nil {
 		return nil, err
 	}
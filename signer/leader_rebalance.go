@@ -0,0 +1,130 @@
+package signer
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// leaderRebalanceWindow is the number of blocks the sign latency of the leader is averaged over.
+	leaderRebalanceWindow = 20
+
+	// defaultLeaderRebalanceCooldown is used when the leaderRebalance cooldown is not configured.
+	defaultLeaderRebalanceCooldown = 10 * time.Minute
+
+	// leaderTermGap is the time without signing after which the cosigner is considered to have lost leadership,
+	// so that the sign latency of a previous term is not held against a new one.
+	leaderTermGap = 30 * time.Second
+
+	// peerLatencyWeight is the weight of the newest sample in the moving average of a peer's sign latency.
+	peerLatencyWeight = 0.2
+)
+
+// leadershipTransferer is implemented by leaders that can hand off leadership to a specific cosigner.
+type leadershipTransferer interface {
+	TransferLeadershipTo(shardID int) error
+}
+
+// leaderRebalancer decides when the leader should hand off leadership because it is slow to sign.
+// The sign latency is averaged over a window of blocks and a leader must lead for the cooldown
+// before it can transfer, so that leadership does not flap between cosigners.
+type leaderRebalancer struct {
+	threshold time.Duration
+	cooldown  time.Duration
+
+	mu          sync.Mutex
+	samples     []time.Duration
+	next        int
+	lastSample  time.Time
+	leaderSince time.Time
+	peerLatency map[int]time.Duration
+}
+
+// newLeaderRebalancer returns nil if leader rebalancing is not configured.
+func newLeaderRebalancer(config *RuntimeConfig) *leaderRebalancer {
+	tc := config.Config.ThresholdModeConfig
+	if tc == nil || tc.LeaderRebalance == nil {
+		return nil
+	}
+
+	// Validated prior in ValidateThresholdModeConfig
+	threshold, _ := time.ParseDuration(tc.LeaderRebalance.SignLatencyThreshold)
+	cooldown := defaultLeaderRebalanceCooldown
+	if tc.LeaderRebalance.Cooldown != "" {
+		cooldown, _ = time.ParseDuration(tc.LeaderRebalance.Cooldown)
+	}
+
+	return &leaderRebalancer{
+		threshold:   threshold,
+		cooldown:    cooldown,
+		samples:     make([]time.Duration, 0, leaderRebalanceWindow),
+		peerLatency: make(map[int]time.Duration),
+	}
+}
+
+// observeSign records the time taken by the leader to sign a block,
+// and returns the average sign latency if leadership should be transferred.
+func (r *leaderRebalancer) observeSign(latency time.Duration, now time.Time) (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Sub(r.lastSample) > leaderTermGap {
+		r.resetLocked(now)
+	}
+	r.lastSample = now
+
+	if len(r.samples) < leaderRebalanceWindow {
+		r.samples = append(r.samples, latency)
+	} else {
+		r.samples[r.next] = latency
+		r.next = (r.next + 1) % leaderRebalanceWindow
+	}
+
+	if len(r.samples) < leaderRebalanceWindow || now.Sub(r.leaderSince) < r.cooldown {
+		return 0, false
+	}
+
+	var sum time.Duration
+	for _, s := range r.samples {
+		sum += s
+	}
+	avg := sum / time.Duration(len(r.samples))
+	if avg <= r.threshold {
+		return 0, false
+	}
+
+	r.resetLocked(now)
+	return avg, true
+}
+
+func (r *leaderRebalancer) resetLocked(now time.Time) {
+	r.samples = r.samples[:0]
+	r.next = 0
+	r.leaderSince = now
+}
+
+// observePeer records the time taken by a peer to respond with its signature.
+func (r *leaderRebalancer) observePeer(id int, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	avg, ok := r.peerLatency[id]
+	if !ok {
+		r.peerLatency[id] = latency
+		return
+	}
+	r.peerLatency[id] = avg + time.Duration(peerLatencyWeight*float64(latency-avg))
+}
+
+// fastestPeer returns the ID of the peer with the lowest average sign latency.
+func (r *leaderRebalancer) fastestPeer() (id int, latency time.Duration, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for peerID, l := range r.peerLatency {
+		if !ok || l < latency || (l == latency && peerID < id) {
+			id, latency, ok = peerID, l, true
+		}
+	}
+	return id, latency, ok
+}
@@ -0,0 +1,97 @@
+package signer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testLeaderRebalancer(t *testing.T, cooldown string) *leaderRebalancer {
+	r := newLeaderRebalancer(&RuntimeConfig{
+		Config: Config{
+			ThresholdModeConfig: &ThresholdModeConfig{
+				LeaderRebalance: &LeaderRebalanceConfig{
+					SignLatencyThreshold: "100ms",
+					Cooldown:             cooldown,
+				},
+			},
+		},
+	})
+	require.NotNil(t, r)
+	return r
+}
+
+func TestLeaderRebalancer(t *testing.T) {
+	r := testLeaderRebalancer(t, "20s")
+
+	now := time.Now()
+	sign := func(latency time.Duration) bool {
+		now = now.Add(500 * time.Millisecond)
+		_, ok := r.observeSign(latency, now)
+		return ok
+	}
+
+	// slow signing does not transfer leadership within the cooldown of becoming leader.
+	signs := 0
+	for !sign(200 * time.Millisecond) {
+		signs++
+		require.Less(t, signs, 100)
+	}
+	require.Equal(t, 40, signs)
+
+	// the window and cooldown start over after a transfer.
+	for i := 0; i < leaderRebalanceWindow; i++ {
+		require.False(t, sign(200*time.Millisecond))
+	}
+
+	// the samples of a previous leadership term are discarded.
+	now = now.Add(time.Hour)
+	require.False(t, sign(200*time.Millisecond))
+	require.Len(t, r.samples, 1)
+
+	require.Nil(t, newLeaderRebalancer(&RuntimeConfig{}))
+}
+
+func TestLeaderRebalancerAverage(t *testing.T) {
+	r := testLeaderRebalancer(t, "0s")
+
+	now := time.Now()
+	sign := func(latency time.Duration) bool {
+		now = now.Add(time.Second)
+		_, ok := r.observeSign(latency, now)
+		return ok
+	}
+
+	// a single slow block is averaged out by the rest of the window.
+	for i := 0; i < leaderRebalanceWindow-1; i++ {
+		require.False(t, sign(50*time.Millisecond))
+	}
+	require.False(t, sign(time.Second))
+
+	// consistently slow blocks raise the average over the threshold.
+	signs := 0
+	for !sign(150 * time.Millisecond) {
+		signs++
+		require.Less(t, signs, leaderRebalanceWindow)
+	}
+}
+
+func TestLeaderRebalancerFastestPeer(t *testing.T) {
+	r := testLeaderRebalancer(t, "")
+	require.Equal(t, defaultLeaderRebalanceCooldown, r.cooldown)
+
+	_, _, ok := r.fastestPeer()
+	require.False(t, ok)
+
+	r.observePeer(2, 30*time.Millisecond)
+	r.observePeer(3, 10*time.Millisecond)
+	for i := 0; i < 10; i++ {
+		r.observePeer(3, 50*time.Millisecond)
+	}
+
+	id, latency, ok := r.fastestPeer()
+	require.True(t, ok)
+	require.Equal(t, 2, id)
+	require.Equal(t, 30*time.Millisecond, latency)
+}
@@ -0,0 +1,74 @@
+package signer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+const (
+	// pingSamples is the number of requests that the round trip time to a cosigner is measured with.
+	pingSamples = 3
+
+	// leaderTransferPingTimeout bounds the round trip time measurement of each peer before a leadership
+	// transfer, peers that do not respond in time are not transferred to.
+	leaderTransferPingTimeout = time.Second
+)
+
+// latencyPeer is a cosigner that the round trip time to can be measured.
+type latencyPeer interface {
+	Cosigner
+	Ping(ctx context.Context) (time.Duration, error)
+}
+
+// lowestLatencyPeer measures the round trip time to each peer concurrently, and returns the peer that
+// responds the fastest. It returns false if no peer responds.
+func lowestLatencyPeer(ctx context.Context, peers []Cosigner) (peer Cosigner, latency time.Duration, ok bool) {
+	ctx, cancel := context.WithTimeout(ctx, leaderTransferPingTimeout)
+	defer cancel()
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, p := range peers {
+		lp, isLatencyPeer := p.(latencyPeer)
+		if !isLatencyPeer {
+			continue
+		}
+		wg.Add(1)
+		go func(p latencyPeer) {
+			defer wg.Done()
+			rtt, err := p.Ping(ctx)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if !ok || rtt < latency || (rtt == latency && p.GetID() < peer.GetID()) {
+				peer, latency, ok = p, rtt, true
+			}
+		}(lp)
+	}
+	wg.Wait()
+	return peer, latency, ok
+}
+
+// transferLeadershipByLatency transfers leadership to the peer with the lowest round trip time.
+// It returns false, without transferring, if no peer responds.
+func (s *RaftStore) transferLeadershipByLatency(ctx context.Context) (raft.Server, bool, error) {
+	peer, latency, ok := lowestLatencyPeer(ctx, s.Cosigners)
+	if !ok {
+		return raft.Server{}, false, nil
+	}
+	srv, err := peerServer(peer)
+	if err != nil {
+		return raft.Server{}, false, err
+	}
+	s.logger.Info("Transferring leadership to the lowest latency cosigner",
+		"id", srv.ID, "address", srv.Address, "latency", latency)
+	s.raft.LeadershipTransferToServer(srv.ID, srv.Address)
+	return srv, true, nil
+}
@@ -0,0 +1,67 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type latencyTestCosigner struct {
+	Cosigner
+	id      int
+	latency time.Duration
+	err     error
+}
+
+func (c *latencyTestCosigner) GetID() int {
+	return c.id
+}
+
+func (c *latencyTestCosigner) GetAddress() string {
+	return fmt.Sprintf("tcp://cosigner-%d:2222", c.id)
+}
+
+func (c *latencyTestCosigner) Ping(context.Context) (time.Duration, error) {
+	return c.latency, c.err
+}
+
+func TestLowestLatencyPeer(t *testing.T) {
+	peers := []Cosigner{
+		&latencyTestCosigner{id: 2, latency: 80 * time.Millisecond},
+		&latencyTestCosigner{id: 3, latency: 5 * time.Millisecond, err: errors.New("unreachable")},
+		&latencyTestCosigner{id: 4, latency: 20 * time.Millisecond},
+		&latencyTestCosigner{id: 5, latency: 20 * time.Millisecond},
+	}
+
+	// unreachable peers are not eligible, and ties go to the lowest shard ID.
+	peer, latency, ok := lowestLatencyPeer(context.Background(), peers)
+	require.True(t, ok)
+	require.Equal(t, 4, peer.GetID())
+	require.Equal(t, 20*time.Millisecond, latency)
+
+	_, _, ok = lowestLatencyPeer(context.Background(), peers[1:2])
+	require.False(t, ok)
+
+	// peers that can not be pinged are not eligible either.
+	_, _, ok = lowestLatencyPeer(context.Background(), []Cosigner{&clockTestCosigner{id: 2}})
+	require.False(t, ok)
+}
+
+func TestRaftStoreTransferLeadershipByLatency(t *testing.T) {
+	_, addrs := startTestRaftCluster(t, 3, func(s *RaftStore) {
+		s.leaderTransferByLatency = true
+	})
+
+	leader := waitForTestLeader(t, addrs, 15*time.Second)
+	leaderIdx := testLeaderIndex(t, addrs, leader)
+
+	// without a shard ID, leadership is transferred to a peer that responds, and that peer is returned.
+	res := transferTestLeadership(t, addrs[leaderIdx], "")
+	require.NotEmpty(t, res.GetLeaderID())
+	require.NotEqual(t, fmt.Sprint(leaderIdx+1), res.GetLeaderID())
+	require.Equal(t, res.GetLeaderAddress(), waitForTestLeader(t, addrs, 5*time.Second, leader))
+}
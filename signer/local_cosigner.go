@@ -1,6 +1,7 @@
 package signer
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -14,6 +15,29 @@ import (
 
 var _ Cosigner = &LocalCosigner{}
 
+// defaultNonceExpiration is used when the nonceExpiration is not configured.
+const defaultNonceExpiration = 10 * time.Second
+
+// defaultNonceCacheSize is used when the nonceCacheSize is not configured.
+const defaultNonceCacheSize = 1000
+
+var (
+	errEmptyChainID      = errors.New("chain id cannot be empty")
+	errChainStateMissing = errors.New("failed to load chain state")
+)
+
+// NoncesError is returned when the nonces for a sign request are missing or expired,
+// so the round can only be signed with new nonces.
+type NoncesError struct {
+	msg string
+}
+
+func (e *NoncesError) Error() string { return e.msg }
+
+func newNoncesError(format string, a ...any) *NoncesError {
+	return &NoncesError{msg: fmt.Sprintf(format, a...)}
+}
+
 // LocalCosigner responds to sign requests.
 // It maintains a high watermark to avoid double-signing.
 // Signing is thread safe.
@@ -24,6 +48,15 @@ type LocalCosigner struct {
 	chainState    sync.Map
 	address       string
 	pendingDiskWG sync.WaitGroup
+
+	// cached nonces older than this are discarded
+	nonceExpiration time.Duration
+	// maximum number of HRSTs that nonces are cached for, for each chain
+	nonceCacheSize int
+	// number of nonces generated ahead of the nonce requests for each chain
+	noncePoolSize int
+
+	armed *ArmedFile
 }
 
 func NewLocalCosigner(
@@ -32,15 +65,36 @@ func NewLocalCosigner(
 	security CosignerSecurity,
 	address string,
 ) *LocalCosigner {
+	nonceExpiration := defaultNonceExpiration
+	nonceCacheSize := defaultNonceCacheSize
+	var noncePoolSize int
+	if tc := config.Config.ThresholdModeConfig; tc != nil {
+		if tc.NonceExpiration != "" {
+			// Validated prior in ValidateThresholdModeConfig
+			if d, err := time.ParseDuration(tc.NonceExpiration); err == nil {
+				nonceExpiration = d
+			}
+		}
+		if tc.NonceCacheSize > 0 {
+			nonceCacheSize = tc.NonceCacheSize
+		}
+		noncePoolSize = tc.NoncePoolSize
+	}
+
 	return &LocalCosigner{
-		logger:   logger,
-		config:   config,
-		security: security,
-		address:  address,
+		logger:          logger,
+		config:          config,
+		security:        security,
+		address:         address,
+		nonceExpiration: nonceExpiration,
+		nonceCacheSize:  nonceCacheSize,
+		noncePoolSize:   noncePoolSize,
 	}
 }
 
 type ChainState struct {
+	chainID string
+
 	// lastSignState stores the last sign state for an HRS we have fully signed
 	// incremented whenever we are asked to sign an HRS
 	lastSignState *SignState
@@ -52,15 +106,106 @@ type ChainState struct {
 
 	// Height, Round, Step -> metadata
 	nonces map[HRSTKey][]Nonces
+	// Height, Round, Step -> when our nonces were generated
+	noncesCreated map[HRSTKey]time.Time
+	// Height, Round, Step -> number of sign requests in progress with the nonces, which are never discarded
+	noncesInUse map[HRSTKey]int
+
+	// pre-generated nonces, nil if the pool is disabled
+	noncePool *noncePool
+}
+
+// nonceExpired returns true if the nonces for the HRST were generated more than expiration ago.
+// Must be called with the mutex held.
+func (ccs *ChainState) nonceExpired(hrst HRSTKey, expiration time.Duration, now time.Time) bool {
+	created, ok := ccs.noncesCreated[hrst]
+	return ok && now.Sub(created) > expiration
+}
+
+// deleteNonces discards the cached nonces for the HRST, unless a sign request with them is in progress.
+// Returns true if the nonces were discarded. Must be called with the mutex held.
+func (ccs *ChainState) deleteNonces(hrst HRSTKey) bool {
+	if ccs.noncesInUse[hrst] > 0 {
+		return false
+	}
+	delete(ccs.nonces, hrst)
+	delete(ccs.noncesCreated, hrst)
+	return true
 }
 
-func (ccs *ChainState) combinedNonces(myID int, threshold uint8, hrst HRSTKey) ([]Nonce, error) {
+// deleteExpiredNonces discards all cached nonces older than expiration, or below the HRS of the last sign
+// state, so that nonces for an HRST that was never signed are not kept around. Must be called with the mutex held.
+func (ccs *ChainState) deleteExpiredNonces(expiration time.Duration, now time.Time) {
+	signed := ccs.lastSignState.HRSKey()
+	for hrst := range ccs.nonces {
+		switch {
+		case ccs.nonceExpired(hrst, expiration, now):
+			if ccs.deleteNonces(hrst) {
+				totalExpiredNonces.Inc()
+			}
+		case hrst.HRSKey().LessThan(signed):
+			ccs.deleteNonces(hrst)
+		}
+	}
+	nonceCacheEntries.WithLabelValues(ccs.chainID).Set(float64(len(ccs.nonces)))
+}
+
+// evictOldestNonces discards the oldest cached nonces that are not in use until fewer than size are cached.
+// Returns false if the cache is still full. Must be called with the mutex held.
+func (ccs *ChainState) evictOldestNonces(size int) bool {
+	for len(ccs.nonces) >= size {
+		var oldest HRSTKey
+		var oldestCreated time.Time
+		found := false
+		for hrst, created := range ccs.noncesCreated {
+			if ccs.noncesInUse[hrst] > 0 {
+				continue
+			}
+			if !found || created.Before(oldestCreated) {
+				oldest, oldestCreated, found = hrst, created, true
+			}
+		}
+		if !found {
+			return false
+		}
+		ccs.deleteNonces(oldest)
+		totalEvictedNonces.Inc()
+	}
+	nonceCacheEntries.WithLabelValues(ccs.chainID).Set(float64(len(ccs.nonces)))
+	return true
+}
+
+// useNonces marks the nonces for the HRST as in use by a sign request until the returned func is called.
+func (ccs *ChainState) useNonces(hrst HRSTKey) func() {
+	ccs.mu.Lock()
+	ccs.noncesInUse[hrst]++
+	ccs.mu.Unlock()
+
+	return func() {
+		ccs.mu.Lock()
+		if ccs.noncesInUse[hrst]--; ccs.noncesInUse[hrst] <= 0 {
+			delete(ccs.noncesInUse, hrst)
+		}
+		ccs.mu.Unlock()
+	}
+}
+
+func (ccs *ChainState) combinedNonces(
+	myID int,
+	threshold uint8,
+	hrst HRSTKey,
+	expiration time.Duration,
+) ([]Nonce, error) {
 	ccs.mu.RLock()
 	defer ccs.mu.RUnlock()
 
 	nonces, ok := ccs.nonces[hrst]
 	if !ok {
-		return nil, errors.New("no metadata at HRS")
+		return nil, newNoncesError("no metadata at HRS")
+	}
+
+	if ccs.nonceExpired(hrst, expiration, time.Now()) {
+		return nil, newNoncesError("nonces at HRS expired after %s", expiration)
 	}
 
 	combinedNonces := make([]Nonce, 0, threshold)
@@ -123,10 +268,15 @@ func (cosigner *LocalCosigner) GetAddress() string {
 	return cosigner.address
 }
 
+// SetArmedFile requires the armed file to be present for the cosigner to sign.
+func (cosigner *LocalCosigner) SetArmedFile(armed *ArmedFile) {
+	cosigner.armed = armed
+}
+
 func (cosigner *LocalCosigner) getChainState(chainID string) (*ChainState, error) {
 	cs, ok := cosigner.chainState.Load(chainID)
 	if !ok {
-		return nil, fmt.Errorf("failed to load chain state for %s", chainID)
+		return nil, fmt.Errorf("%w for %s", errChainStateMissing, chainID)
 	}
 
 	ccs, ok := cs.(*ChainState)
@@ -180,7 +330,7 @@ func (cosigner *LocalCosigner) VerifySignature(chainID string, payload, signatur
 // Sign the sign request using the cosigner's shard
 // Return the signed bytes or an error
 // Implements Cosigner interface
-func (cosigner *LocalCosigner) sign(req CosignerSignRequest) (CosignerSignResponse, error) {
+func (cosigner *LocalCosigner) sign(ctx context.Context, req CosignerSignRequest) (CosignerSignResponse, error) {
 	chainID := req.ChainID
 
 	res := CosignerSignResponse{}
@@ -198,6 +348,9 @@ func (cosigner *LocalCosigner) sign(req CosignerSignRequest) (CosignerSignRespon
 		return res, err
 	}
 
+	// report the round actually signed, so that the leader can discard a signature for another round.
+	res.HRST = hrst
+
 	existingSignature, err := ccs.lastSignState.existingSignatureOrErrorIfRegression(hrst, req.SignBytes)
 	if err != nil {
 		return res, err
@@ -208,16 +361,31 @@ func (cosigner *LocalCosigner) sign(req CosignerSignRequest) (CosignerSignRespon
 		return res, nil
 	}
 
-	nonces, err := ccs.combinedNonces(cosigner.GetID(), uint8(cosigner.config.Config.ThresholdModeConfig.Threshold), hrst)
+	nonces, err := ccs.combinedNonces(
+		cosigner.GetID(),
+		uint8(cosigner.config.Config.ThresholdModeConfig.Threshold),
+		hrst,
+		cosigner.nonceExpiration,
+	)
 	if err != nil {
 		return res, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return res, err
+	}
+
 	sig, err := ccs.signer.Sign(nonces, req.SignBytes)
 	if err != nil {
 		return res, err
 	}
 
+	// do not persist a signature for a round that the leader has already abandoned.
+	if err := ctx.Err(); err != nil {
+		return res, err
+	}
+
+	_, span := tracer.Start(ctx, "SaveSignState", hrstAttributes(chainID, hrst))
 	err = ccs.lastSignState.Save(SignStateConsensus{
 		Height:    hrst.Height,
 		Round:     hrst.Round,
@@ -225,6 +393,7 @@ func (cosigner *LocalCosigner) sign(req CosignerSignRequest) (CosignerSignRespon
 		Signature: sig,
 		SignBytes: req.SignBytes,
 	}, &cosigner.pendingDiskWG)
+	endSpan(span, err)
 
 	if err != nil {
 		if _, isSameHRSError := err.(*SameHRSError); !isSameHRSError {
@@ -237,9 +406,10 @@ func (cosigner *LocalCosigner) sign(req CosignerSignRequest) (CosignerSignRespon
 		// delete any HRS lower than our signed level
 		// we will not be providing parts for any lower HRS
 		if existingKey.HRSKey().LessThan(hrst.HRSKey()) {
-			delete(ccs.nonces, existingKey)
+			ccs.deleteNonces(existingKey)
 		}
 	}
+	nonceCacheEntries.WithLabelValues(chainID).Set(float64(len(ccs.nonces)))
 	ccs.mu.Unlock()
 
 	res.Signature = sig
@@ -260,7 +430,12 @@ func (cosigner *LocalCosigner) dealShares(req CosignerGetNonceRequest) ([]Nonces
 
 	meta := make([]Nonces, len(cosigner.config.Config.ThresholdModeConfig.Cosigners))
 
-	nonces, err := ccs.signer.GenerateNonces()
+	generateNonces := ccs.signer.GenerateNonces
+	if ccs.noncePool != nil {
+		generateNonces = ccs.noncePool.get
+	}
+
+	nonces, err := generateNonces()
 	if err != nil {
 		return nil, err
 	}
@@ -272,7 +447,7 @@ func (cosigner *LocalCosigner) dealShares(req CosignerGetNonceRequest) ([]Nonces
 
 func (cosigner *LocalCosigner) LoadSignStateIfNecessary(chainID string) error {
 	if chainID == "" {
-		return fmt.Errorf("chain id cannot be empty")
+		return errEmptyChainID
 	}
 
 	if _, ok := cosigner.chainState.Load(chainID); ok {
@@ -291,16 +466,54 @@ func (cosigner *LocalCosigner) LoadSignStateIfNecessary(chainID string) error {
 		return err
 	}
 
-	cosigner.chainState.Store(chainID, &ChainState{
+	ccs := &ChainState{
+		chainID:       chainID,
 		lastSignState: signState,
 		nonces:        make(map[HRSTKey][]Nonces),
+		noncesCreated: make(map[HRSTKey]time.Time),
+		noncesInUse:   make(map[HRSTKey]int),
 		signer:        signer,
-	})
+	}
+	if cosigner.noncePoolSize > 0 {
+		ccs.noncePool = newNoncePool(cosigner.logger, chainID, cosigner.noncePoolSize, signer.GenerateNonces)
+	}
+
+	cosigner.chainState.Store(chainID, ccs)
 
 	return nil
 }
 
+// stopNoncePools discards the pre-generated nonces of all chains and stops refilling them.
+func (cosigner *LocalCosigner) stopNoncePools() {
+	cosigner.chainState.Range(func(_, v any) bool {
+		if p := v.(*ChainState).noncePool; p != nil {
+			p.stop()
+		}
+		return true
+	})
+}
+
+// LastSignState returns the HRS of the last share signed by the cosigner for the chain.
+// The sign state file is not created if it does not exist yet.
+func (cosigner *LocalCosigner) LastSignState(chainID string) (HRSKey, error) {
+	if chainID == "" {
+		return HRSKey{}, errEmptyChainID
+	}
+
+	if cs, ok := cosigner.chainState.Load(chainID); ok {
+		return cs.(*ChainState).lastSignState.HRSKey(), nil
+	}
+
+	signState, err := LoadSignState(cosigner.config.CosignerStateFile(chainID))
+	if err != nil {
+		return HRSKey{}, err
+	}
+
+	return signState.HRSKey(), nil
+}
+
 func (cosigner *LocalCosigner) GetNonces(
+	_ context.Context,
 	chainID string,
 	hrst HRSTKey,
 ) (*CosignerNoncesResponse, error) {
@@ -375,11 +588,17 @@ func (cosigner *LocalCosigner) dealSharesIfNecessary(chainID string, hrst HRSTKe
 	ccs.mu.Lock()
 	defer ccs.mu.Unlock()
 
+	ccs.deleteExpiredNonces(cosigner.nonceExpiration, time.Now())
+
 	nonces, ok := ccs.nonces[hrst]
 	if ok {
 		return nonces, nil
 	}
 
+	if !ccs.evictOldestNonces(cosigner.nonceCacheSize) {
+		return nil, newNoncesError("nonce cache is full with %d sign requests in progress", len(ccs.nonces))
+	}
+
 	newNonces, err := cosigner.dealShares(CosignerGetNonceRequest{
 		ChainID:   chainID,
 		Height:    hrst.Height,
@@ -393,6 +612,8 @@ func (cosigner *LocalCosigner) dealSharesIfNecessary(chainID string, hrst HRSTKe
 	}
 
 	ccs.nonces[hrst] = newNonces
+	ccs.noncesCreated[hrst] = time.Now()
+	nonceCacheEntries.WithLabelValues(chainID).Set(float64(len(ccs.nonces)))
 	return newNonces, nil
 }
 
@@ -461,7 +682,7 @@ func (cosigner *LocalCosigner) setNonce(req CosignerSetNonceRequest) error {
 	nonces, ok := ccs.nonces[hrst]
 	// generate metadata placeholder
 	if !ok {
-		return fmt.Errorf(
+		return newNoncesError(
 			"unexpected state, metadata does not exist for H: %d, R: %d, S: %d, T: %d",
 			hrst.Height,
 			hrst.Round,
@@ -470,6 +691,11 @@ func (cosigner *LocalCosigner) setNonce(req CosignerSetNonceRequest) error {
 		)
 	}
 
+	if ccs.nonceExpired(hrst, cosigner.nonceExpiration, time.Now()) {
+		return newNoncesError("nonces for H: %d, R: %d, S: %d expired after %s",
+			hrst.Height, hrst.Round, hrst.Step, cosigner.nonceExpiration)
+	}
+
 	// set slot
 	if nonces[req.SourceID-1].Shares == nil {
 		nonces[req.SourceID-1].Shares = make([][]byte, len(cosigner.config.Config.ThresholdModeConfig.Cosigners))
@@ -481,14 +707,47 @@ func (cosigner *LocalCosigner) setNonce(req CosignerSetNonceRequest) error {
 }
 
 func (cosigner *LocalCosigner) SetNoncesAndSign(
-	req CosignerSetNoncesAndSignRequest) (*CosignerSignResponse, error) {
+	ctx context.Context,
+	req CosignerSetNoncesAndSignRequest,
+) (*CosignerSignResponse, error) {
 	chainID := req.ChainID
 
+	// a disarmed cosigner does not contribute its share, including to blocks signed by the leader.
+	if err := cosigner.armed.Check(); err != nil {
+		return nil, err
+	}
+
 	if err := cosigner.LoadSignStateIfNecessary(chainID); err != nil {
 		return nil, err
 	}
 
-	var eg errgroup.Group
+	if err := cosigner.verifySignBytes(req); err != nil {
+		totalRejectedSignBytes.Inc()
+		cosigner.logger.Error(
+			"Refusing to sign bytes that do not match the request",
+			"chain_id", chainID,
+			"height", req.HRST.Height,
+			"round", req.HRST.Round,
+			"step", req.HRST.Step,
+			"err", err,
+		)
+		return nil, err
+	}
+
+	// the leader may have given up on this request while it was queued.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ccs, err := cosigner.getChainState(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the nonces are not discarded while they are set and signed with.
+	defer ccs.useNonces(req.HRST)()
+
+	eg, egCtx := errgroup.WithContext(ctx)
 
 	// setting nonces requires decrypting and verifying signature from each cosigner,
 	// so we perform these operations in parallel.
@@ -497,6 +756,9 @@ func (cosigner *LocalCosigner) SetNoncesAndSign(
 		secretPart := secretPart
 
 		eg.Go(func() error {
+			if err := egCtx.Err(); err != nil {
+				return err
+			}
 			return cosigner.setNonce(CosignerSetNonceRequest{
 				ChainID:   chainID,
 				SourceID:  secretPart.SourceID,
@@ -515,9 +777,34 @@ func (cosigner *LocalCosigner) SetNoncesAndSign(
 		return nil, err
 	}
 
-	res, err := cosigner.sign(CosignerSignRequest{
+	res, err := cosigner.sign(ctx, CosignerSignRequest{
 		ChainID:   chainID,
 		SignBytes: req.SignBytes,
 	})
+	// report our clock so that the leader can detect clock drift
+	res.Timestamp = time.Now()
 	return &res, err
 }
+
+// verifySignBytes checks that the sign bytes are for the chain-id and HRST of the request
+// when strict sign bytes verification is configured, so that a compromised leader or chain node
+// can not have this cosigner sign bytes for a different block than the one it claims.
+func (cosigner *LocalCosigner) verifySignBytes(req CosignerSetNoncesAndSignRequest) error {
+	if cosigner.config.Config.ThresholdModeConfig.SignBytesVerification != SignBytesVerificationStrict {
+		return nil
+	}
+
+	hrst, chainID, err := UnpackHRSTAndChainID(req.SignBytes)
+	if err != nil {
+		return err
+	}
+	if chainID != req.ChainID {
+		return fmt.Errorf("sign bytes are for chain-id %s, not %s", chainID, req.ChainID)
+	}
+	if hrst != req.HRST {
+		return fmt.Errorf("sign bytes are for %d.%d.%d at %d, not %d.%d.%d at %d",
+			hrst.Height, hrst.Round, hrst.Step, hrst.Timestamp,
+			req.HRST.Height, req.HRST.Round, req.HRST.Step, req.HRST.Timestamp)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package signer
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"fmt"
@@ -15,8 +16,12 @@ import (
 	comet "github.com/cometbft/cometbft/types"
 	"github.com/ethereum/go-ethereum/crypto/ecies"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
 	"github.com/stretchr/testify/require"
 	tsed25519 "gitlab.com/unit410/threshold-ed25519/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -169,7 +174,7 @@ func testLocalCosignerSign(t *testing.T, threshold, total uint8, security []Cosi
 		if i < int(threshold) {
 			thresholdCosigners[i] = cosigner
 
-			nonce, err := cosigner.GetNonces(testChainID, hrst)
+			nonce, err := cosigner.GetNonces(context.Background(), testChainID, hrst)
 			require.NoError(t, err)
 
 			nonces[i] = nonce.Nonces
@@ -202,7 +207,7 @@ func testLocalCosignerSign(t *testing.T, threshold, total uint8, security []Cosi
 			}
 		}
 
-		sigRes, err := cosigner.SetNoncesAndSign(CosignerSetNoncesAndSignRequest{
+		sigRes, err := cosigner.SetNoncesAndSign(context.Background(), CosignerSetNoncesAndSignRequest{
 			ChainID:   testChainID,
 			Nonces:    cosignerNonces,
 			HRST:      hrst,
@@ -221,3 +226,210 @@ func testLocalCosignerSign(t *testing.T, threshold, total uint8, security []Cosi
 
 	require.True(t, pubKey.VerifySignature(signBytes, combinedSig))
 }
+
+func TestLocalCosignerNonceExpiration(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	for _, c := range cosigners {
+		c.nonceExpiration = 50 * time.Millisecond
+		defer c.waitForSignStatesToFlushToDisk()
+		require.NoError(t, c.LoadSignStateIfNecessary(testChainID))
+	}
+
+	now := time.Now()
+	hrst := HRSTKey{Height: 1, Round: 0, Step: 2, Timestamp: now.UnixNano()}
+
+	vote := cometproto.Vote{Height: 1, Round: 0, Type: cometproto.PrevoteType, Timestamp: now}
+	signBytes := comet.VoteSignBytes(testChainID, &vote)
+
+	// setNoncesAndSign exchanges nonces between cosigners 1 and 2, then signs with cosigner 1 after wait.
+	setNoncesAndSign := func(wait time.Duration) error {
+		nonces1, err := cosigners[0].GetNonces(context.Background(), testChainID, hrst)
+		require.NoError(t, err)
+		nonces2, err := cosigners[1].GetNonces(context.Background(), testChainID, hrst)
+		require.NoError(t, err)
+
+		var toCosigner1 []CosignerNonce
+		for _, n := range append(nonces1.Nonces, nonces2.Nonces...) {
+			if n.SourceID != 1 && n.DestinationID == 1 {
+				toCosigner1 = append(toCosigner1, n)
+			}
+		}
+
+		time.Sleep(wait)
+
+		_, err = cosigners[0].SetNoncesAndSign(context.Background(), CosignerSetNoncesAndSignRequest{
+			ChainID:   testChainID,
+			Nonces:    toCosigner1,
+			HRST:      hrst,
+			SignBytes: signBytes,
+		})
+		return err
+	}
+
+	// the nonces expired while waiting to sign, so they must not be used.
+	require.ErrorContains(t, setNoncesAndSign(100*time.Millisecond), "expired after 50ms")
+
+	// the expired nonces are discarded and regenerated by the next nonce request.
+	require.NoError(t, setNoncesAndSign(0))
+}
+
+func TestLocalCosignerNonceCacheBounds(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	cosigner := cosigners[0]
+	cosigner.nonceCacheSize = 16
+	defer cosigner.waitForSignStatesToFlushToDisk()
+	require.NoError(t, cosigner.LoadSignStateIfNecessary(testChainID))
+
+	ccs, err := cosigner.getChainState(testChainID)
+	require.NoError(t, err)
+
+	cacheSize := func() int {
+		ccs.mu.RLock()
+		defer ccs.mu.RUnlock()
+		require.Len(t, ccs.noncesCreated, len(ccs.nonces))
+		return len(ccs.nonces)
+	}
+
+	// the nonces of a sign request in progress are never evicted.
+	inUse := HRSTKey{Height: 1, Round: 0, Step: 2, Timestamp: time.Now().UnixNano()}
+	_, err = cosigner.GetNonces(context.Background(), testChainID, inUse)
+	require.NoError(t, err)
+	done := ccs.useNonces(inUse)
+
+	// flood distinct HRSTs that are never signed, e.g. during a partition.
+	for i := 0; i < 500; i++ {
+		hrst := HRSTKey{Height: int64(2 + i), Round: 0, Step: 2, Timestamp: time.Now().UnixNano()}
+		_, err := cosigner.GetNonces(context.Background(), testChainID, hrst)
+		require.NoError(t, err)
+		require.LessOrEqual(t, cacheSize(), 16)
+	}
+	require.Equal(t, 16.0, testutil.ToFloat64(nonceCacheEntries.prom.WithLabelValues(testChainID)))
+
+	ccs.mu.RLock()
+	_, ok := ccs.nonces[inUse]
+	ccs.mu.RUnlock()
+	require.True(t, ok)
+
+	done()
+
+	// nonces below the last sign state are discarded.
+	require.NoError(t, ccs.lastSignState.Save(SignStateConsensus{Height: 1000, Round: 0, Step: 2}, nil))
+	_, err = cosigner.GetNonces(
+		context.Background(), testChainID, HRSTKey{Height: 1000, Round: 0, Step: 3, Timestamp: time.Now().UnixNano()},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, cacheSize())
+}
+
+func TestLocalCosignerStrictSignBytesVerification(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	for _, c := range cosigners {
+		c.config.Config.ThresholdModeConfig.SignBytesVerification = SignBytesVerificationStrict
+		defer c.waitForSignStatesToFlushToDisk()
+		require.NoError(t, c.LoadSignStateIfNecessary(testChainID))
+	}
+
+	now := time.Now()
+
+	// setNoncesAndSign exchanges nonces for hrst between cosigners 1 and 2, then signs the vote with cosigner 1.
+	setNoncesAndSign := func(hrst HRSTKey, chainID string, vote cometproto.Vote) error {
+		nonces1, err := cosigners[0].GetNonces(context.Background(), testChainID, hrst)
+		require.NoError(t, err)
+		nonces2, err := cosigners[1].GetNonces(context.Background(), testChainID, hrst)
+		require.NoError(t, err)
+
+		var toCosigner1 []CosignerNonce
+		for _, n := range append(nonces1.Nonces, nonces2.Nonces...) {
+			if n.SourceID != 1 && n.DestinationID == 1 {
+				toCosigner1 = append(toCosigner1, n)
+			}
+		}
+
+		_, err = cosigners[0].SetNoncesAndSign(context.Background(), CosignerSetNoncesAndSignRequest{
+			ChainID:   testChainID,
+			Nonces:    toCosigner1,
+			HRST:      hrst,
+			SignBytes: comet.VoteSignBytes(chainID, &vote),
+		})
+		return err
+	}
+
+	vote := cometproto.Vote{Height: 2, Round: 0, Type: cometproto.PrevoteType, Timestamp: now}
+	hrst := HRSTKey{Height: 2, Round: 0, Step: stepPrevote, Timestamp: now.UnixNano()}
+
+	require.ErrorContains(t, setNoncesAndSign(hrst, testChainID2, vote),
+		"sign bytes are for chain-id "+testChainID2+", not "+testChainID)
+
+	// the sign bytes claim a higher height than the request.
+	higher := vote
+	higher.Height = 3
+	require.ErrorContains(t, setNoncesAndSign(hrst, testChainID, higher), "sign bytes are for 3.0.2")
+
+	require.NoError(t, setNoncesAndSign(hrst, testChainID, vote))
+}
+
+func TestGRPCServerSetNoncesAndSignCancelled(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	for _, c := range cosigners {
+		defer c.waitForSignStatesToFlushToDisk()
+		require.NoError(t, c.LoadSignStateIfNecessary(testChainID))
+	}
+	rpc := NewGRPCServer(cosigners[0], nil, &RaftStore{logger: log.NewNopLogger()})
+
+	now := time.Now()
+	hrst := HRSTKey{Height: 1, Round: 0, Step: stepPrevote, Timestamp: now.UnixNano()}
+	vote := cometproto.Vote{Height: 1, Round: 0, Type: cometproto.PrevoteType, Timestamp: now}
+
+	nonces1, err := cosigners[0].GetNonces(context.Background(), testChainID, hrst)
+	require.NoError(t, err)
+	nonces2, err := cosigners[1].GetNonces(context.Background(), testChainID, hrst)
+	require.NoError(t, err)
+
+	var toCosigner1 []*proto.Nonce
+	for _, n := range append(nonces1.Nonces, nonces2.Nonces...) {
+		if n.SourceID != 1 && n.DestinationID == 1 {
+			toCosigner1 = append(toCosigner1, n.toProto())
+		}
+	}
+
+	// the leader has already given up on the request.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	_, err = rpc.SetNoncesAndSign(ctx, &proto.CosignerGRPCSetNoncesAndSignRequest{
+		ChainID:   testChainID,
+		Nonces:    toCosigner1,
+		Hrst:      hrst.toProto(),
+		SignBytes: comet.VoteSignBytes(testChainID, &vote),
+	})
+	require.Equal(t, codes.Canceled, status.Code(err))
+	require.Less(t, time.Since(start), time.Second)
+
+	// no signature was produced for the abandoned round.
+	cs, err := cosigners[0].getChainState(testChainID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), cs.lastSignState.Height)
+}
+
+func TestGRPCServerGetSignState(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	rpc := NewGRPCServer(cosigners[0], nil, nil)
+
+	// the sign state file does not exist until the chain is loaded
+	_, err := rpc.GetSignState(context.Background(), &proto.CosignerGRPCGetSignStateRequest{ChainID: testChainID})
+	require.Error(t, err)
+
+	require.NoError(t, cosigners[0].LoadSignStateIfNecessary(testChainID))
+	cs, err := cosigners[0].getChainState(testChainID)
+	require.NoError(t, err)
+	cs.lastSignState.Height = 10
+	cs.lastSignState.Round = 1
+	cs.lastSignState.Step = stepPrecommit
+
+	res, err := rpc.GetSignState(context.Background(), &proto.CosignerGRPCGetSignStateRequest{ChainID: testChainID})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), res.Height)
+	require.Equal(t, int64(1), res.Round)
+	require.Equal(t, int32(stepPrecommit), res.Step)
+}
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 type metricsTimer struct {
@@ -13,6 +12,7 @@ type metricsTimer struct {
 	previousPrecommit, previousPrevote              time.Time
 	previousLocalSignStart, previousLocalSignFinish time.Time
 	previousLocalNonce                              time.Time
+	previousLeaderChange                            time.Time
 }
 
 func newMetricsTimer() *metricsTimer {
@@ -21,7 +21,7 @@ func newMetricsTimer() *metricsTimer {
 		mu:                sync.Mutex{},
 		previousPrecommit: now, previousPrevote: now,
 		previousLocalSignStart: now, previousLocalSignFinish: now,
-		previousLocalNonce: now,
+		previousLocalNonce: now, previousLeaderChange: now,
 	}
 }
 
@@ -55,6 +55,12 @@ func (mt *metricsTimer) SetPreviousLocalNonce(t time.Time) {
 	mt.previousLocalNonce = t
 }
 
+func (mt *metricsTimer) SetPreviousLeaderChange(t time.Time) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+	mt.previousLeaderChange = t
+}
+
 func (mt *metricsTimer) UpdatePrometheusMetrics() {
 	mt.mu.Lock()
 	defer mt.mu.Unlock()
@@ -65,106 +71,110 @@ func (mt *metricsTimer) UpdatePrometheusMetrics() {
 	secondsSinceLastLocalSignStart.Set(time.Since(mt.previousLocalSignStart).Seconds())
 	secondsSinceLastLocalSignFinish.Set(time.Since(mt.previousLocalSignFinish).Seconds())
 	secondsSinceLastLocalNonceTime.Set(time.Since(mt.previousLocalNonce).Seconds())
+	secondsSinceLastLeaderChange.Set(time.Since(mt.previousLeaderChange).Seconds())
 }
 
+// signLatencyBuckets are the histogram buckets of the sign timings, tuned for sub-second consensus.
+var signLatencyBuckets = []float64{0.05, 0.1, 0.15, 0.2, 0.3, 0.5, 0.75, 1, 1.5, 2}
+
 var (
 	// Variables to calculate Prometheus Metrics
 	previousPrecommitHeight = int64(0)
 	previousPrevoteHeight   = int64(0)
 	metricsTimeKeeper       = newMetricsTimer()
 
-	// Prometheus Metrics
-	totalPubKeyRequests = promauto.NewCounter(prometheus.CounterOpts{
+	// Signer Metrics
+	totalPubKeyRequests = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_pubkey_requests",
 		Help: "Total times public key requested (High count may indicate validator restarts)",
 	})
-	lastPrecommitHeight = promauto.NewGauge(prometheus.GaugeOpts{
+	lastPrecommitHeight = newGauge(prometheus.GaugeOpts{
 		Name: "signer_last_precommit_height",
 		Help: "Last Height Precommit Signed",
 	})
-	lastPrevoteHeight = promauto.NewGauge(prometheus.GaugeOpts{
+	lastPrevoteHeight = newGauge(prometheus.GaugeOpts{
 		Name: "signer_last_prevote_height",
 		Help: "Last Height Prevote Signed",
 	})
 
-	lastProposalHeight = promauto.NewGauge(prometheus.GaugeOpts{
+	lastProposalHeight = newGauge(prometheus.GaugeOpts{
 		Name: "signer_last_proposal_height",
 		Help: "Last Height Proposal Signed",
 	})
-	lastPrecommitRound = promauto.NewGauge(prometheus.GaugeOpts{
+	lastPrecommitRound = newGauge(prometheus.GaugeOpts{
 		Name: "signer_last_precommit_round",
 		Help: "Last Round Precommit Signed",
 	})
-	lastPrevoteRound = promauto.NewGauge(prometheus.GaugeOpts{
+	lastPrevoteRound = newGauge(prometheus.GaugeOpts{
 		Name: "signer_last_prevote_round",
 		Help: "Last Round Prevote Signed",
 	})
-	lastProposalRound = promauto.NewGauge(prometheus.GaugeOpts{
+	lastProposalRound = newGauge(prometheus.GaugeOpts{
 		Name: "signer_last_proposal_round",
 		Help: "Last Round Proposal Signed",
 	})
 
-	totalPrecommitsSigned = promauto.NewCounter(prometheus.CounterOpts{
+	totalPrecommitsSigned = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_precommits_signed",
 		Help: "Total Precommit Signed",
 	})
-	totalPrevotesSigned = promauto.NewCounter(prometheus.CounterOpts{
+	totalPrevotesSigned = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_prevotes_signed",
 		Help: "Total Prevote Signed",
 	})
-	totalProposalsSigned = promauto.NewCounter(prometheus.CounterOpts{
+	totalProposalsSigned = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_proposals_signed",
 		Help: "Total Proposal Signed",
 	})
 
-	secondsSinceLastPrecommit = promauto.NewGauge(prometheus.GaugeOpts{
+	secondsSinceLastPrecommit = newGauge(prometheus.GaugeOpts{
 		Name: "signer_seconds_since_last_precommit",
 		Help: "Seconds Since Last Precommit (Useful for Signing Co-Signer Node, Single Signer)",
 	})
-	secondsSinceLastPrevote = promauto.NewGauge(prometheus.GaugeOpts{
+	secondsSinceLastPrevote = newGauge(prometheus.GaugeOpts{
 		Name: "signer_seconds_since_last_prevote",
 		Help: "Seconds Since Last Prevote (Useful for Signing Co-Signer Node, Single Signer)",
 	})
-	secondsSinceLastLocalSignStart = promauto.NewGauge(prometheus.GaugeOpts{
+	secondsSinceLastLocalSignStart = newGauge(prometheus.GaugeOpts{
 		Name: "signer_seconds_since_last_local_sign_start_time",
 		Help: "Seconds Since Last Local Start Sign (May increase beyond block time, Rarely important) ",
 	})
-	secondsSinceLastLocalSignFinish = promauto.NewGauge(prometheus.GaugeOpts{
+	secondsSinceLastLocalSignFinish = newGauge(prometheus.GaugeOpts{
 		Name: "signer_seconds_since_last_local_sign_finish_time",
 		Help: "Seconds Since Last Local Finish Sign (Should stay below 2 * Block Time)",
 	})
 
-	secondsSinceLastLocalNonceTime = promauto.NewGauge(prometheus.GaugeOpts{
+	secondsSinceLastLocalNonceTime = newGauge(prometheus.GaugeOpts{
 		Name: "signer_seconds_since_last_local_ephemeral_share_time",
 		Help: "Seconds Since Last Local Ephemeral Share Sign " +
 			"(Should not increase beyond block time; If high, may indicate raft joining issue for CoSigner) ",
 	})
 
-	missedPrecommits = promauto.NewGauge(prometheus.GaugeOpts{
+	missedPrecommits = newGauge(prometheus.GaugeOpts{
 		Name: "signer_missed_precommits",
 		Help: "Consecutive Precommit Missed",
 	})
-	missedPrevotes = promauto.NewGauge(prometheus.GaugeOpts{
+	missedPrevotes = newGauge(prometheus.GaugeOpts{
 		Name: "signer_missed_prevotes",
 		Help: "Consecutive Prevote Missed",
 	})
-	totalMissedPrecommits = promauto.NewCounter(prometheus.CounterOpts{
+	totalMissedPrecommits = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_missed_precommits",
 		Help: "Total Precommit Missed",
 	})
-	totalMissedPrevotes = promauto.NewCounter(prometheus.CounterOpts{
+	totalMissedPrevotes = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_missed_prevotes",
 		Help: "Total Prevote Missed",
 	})
 
-	missedNonces = promauto.NewGaugeVec(
+	missedNonces = newGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "signer_missed_ephemeral_shares",
 			Help: "Consecutive Threshold Signature Parts Missed",
 		},
 		[]string{"peerid"},
 	)
-	totalMissedNonces = promauto.NewCounterVec(
+	totalMissedNonces = newCounterVec(
 		prometheus.CounterOpts{
 			Name: "signer_total_missed_ephemeral_shares",
 			Help: "Total Threshold Signature Parts Missed",
@@ -172,66 +182,109 @@ var (
 		[]string{"peerid"},
 	)
 
-	sentryConnectTries = promauto.NewGauge(prometheus.GaugeOpts{
+	sentryConnectTries = newGauge(prometheus.GaugeOpts{
 		Name: "signer_sentry_connect_tries",
 		Help: "Consecutive Number of times sentry TCP connect has been tried (High count may indicate validator restarts)",
 	})
-	totalSentryConnectTries = promauto.NewCounter(prometheus.CounterOpts{
+	totalCosignerRetries = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "signer_total_cosigner_retries",
+			Help: "Total Nonce And Sign Requests Retried Because The Cosigner Was Unavailable",
+		},
+		[]string{"peerid"},
+	)
+	totalPeerTimeouts = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "signer_total_peer_timeouts",
+			Help: "Total Requests To A Cosigner Abandoned After The Peer Timeout",
+		},
+		[]string{"peerid"},
+	)
+
+	totalSentryConnectTries = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_sentry_connect_tries",
 		Help: "Total Number of times sentry TCP connect has been tried (High count may indicate validator restarts)",
 	})
 
-	beyondBlockErrors = promauto.NewCounter(prometheus.CounterOpts{
+	beyondBlockErrors = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_beyond_block_errors",
 		Help: "Total Times Signing Started but duplicate height/round request arrives",
 	})
-	failedSignVote = promauto.NewCounter(prometheus.CounterOpts{
+	failedSignVote = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_failed_sign_vote",
 		Help: "Total Times Signer Failed to sign block - Unstarted and Unexepcted Height",
 	})
 
-	totalRaftLeader = promauto.NewCounter(prometheus.CounterOpts{
+	totalRaftLeader = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_raft_leader",
 		Help: "Total Times Signer is Raft Leader",
 	})
-	totalNotRaftLeader = promauto.NewCounter(prometheus.CounterOpts{
+	totalNotRaftLeader = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_raft_not_leader",
 		Help: "Total Times Signer is NOT Raft Leader (Proxy signing to Raft Leader)",
 	})
-	totalRaftLeaderElectiontimeout = promauto.NewCounter(prometheus.CounterOpts{
+	totalRaftLeaderElectiontimeout = newCounter(prometheus.CounterOpts{
 		Name: "signer_total_raft_leader_election_timeout",
 		Help: "Total Times Raft Leader Failed Election (Lacking Peers)",
 	})
 
-	totalInvalidSignature = promauto.NewCounter(prometheus.CounterOpts{
+	totalInvalidSignature = newCounter(prometheus.CounterOpts{
 		Name: "signer_error_total_invalid_signatures",
 		Help: "Total Times Combined Signature is Invalid",
 	})
 
-	totalInsufficientCosigners = promauto.NewCounter(prometheus.CounterOpts{
+	totalStaleSignResponses = newCounter(prometheus.CounterOpts{
+		Name: "signer_error_total_stale_sign_responses",
+		Help: "Total Signature Parts Discarded Because They Were For A Different HRST",
+	})
+
+	totalRejectedSignBytes = newCounter(prometheus.CounterOpts{
+		Name: "signer_error_total_rejected_sign_bytes",
+		Help: "Total Sign Requests Refused Because The Sign Bytes Did Not Match The Request",
+	})
+
+	totalInsufficientCosigners = newCounter(prometheus.CounterOpts{
 		Name: "signer_error_total_insufficient_cosigners",
 		Help: "Total Times Cosigners doesn't reach threshold",
 	})
 
-	timedSignBlockThresholdLag = promauto.NewSummary(prometheus.SummaryOpts{
+	timedSignBlockThresholdLag = newSummary(prometheus.SummaryOpts{
 		Name:       "signer_sign_block_threshold_lag_seconds",
 		Help:       "Seconds taken to get threshold of cosigners available",
 		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 	})
 
-	timedSignBlockCosignerLag = promauto.NewSummary(prometheus.SummaryOpts{
+	timedSignBlockCosignerLag = newSummary(prometheus.SummaryOpts{
 		Name:       "signer_sign_block_cosigner_lag_seconds",
 		Help:       "Seconds taken to get all cosigner signatures",
 		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 	})
 
-	timedSignBlockLag = promauto.NewSummary(prometheus.SummaryOpts{
+	timedSignBlockLag = newSummary(prometheus.SummaryOpts{
 		Name:       "signer_sign_block_lag_seconds",
 		Help:       "Seconds taken to sign block",
 		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 	})
 
-	timedCosignerNonceLag = promauto.NewSummaryVec(
+	timedSignBlockDuration = newHistogramVec(prometheus.HistogramOpts{
+		Name:    "signer_sign_block_duration_seconds",
+		Help:    "Seconds taken to sign block, as the raft leader or by proxying the request to the leader",
+		Buckets: signLatencyBuckets,
+	}, []string{"chain_id", "leader"})
+
+	timedSignBlockGetNoncesDuration = newHistogramVec(prometheus.HistogramOpts{
+		Name:    "signer_sign_block_get_nonces_duration_seconds",
+		Help:    "Seconds taken by the raft leader to collect nonces from threshold cosigners",
+		Buckets: signLatencyBuckets,
+	}, []string{"chain_id"})
+
+	timedSignBlockSetNoncesAndSignDuration = newHistogramVec(prometheus.HistogramOpts{
+		Name:    "signer_sign_block_set_nonces_and_sign_duration_seconds",
+		Help:    "Seconds taken by the raft leader to collect share signatures from threshold cosigners",
+		Buckets: signLatencyBuckets,
+	}, []string{"chain_id"})
+
+	timedCosignerNonceLag = newSummaryVec(
 		prometheus.SummaryOpts{
 			Name:       "signer_cosigner_ephemeral_share_lag_seconds",
 			Help:       "Time taken to get cosigner ephemeral share",
@@ -239,7 +292,7 @@ var (
 		},
 		[]string{"peerid"},
 	)
-	timedCosignerSignLag = promauto.NewSummaryVec(
+	timedCosignerSignLag = newSummaryVec(
 		prometheus.SummaryOpts{
 			Name:       "signer_cosigner_sign_lag_seconds",
 			Help:       "Time taken to get cosigner signature",
@@ -247,6 +300,198 @@ var (
 		},
 		[]string{"peerid"},
 	)
+
+	totalExpiredNonces = newCounter(prometheus.CounterOpts{
+		Name: "signer_total_expired_nonces",
+		Help: "Total Cached Nonces Discarded Due To Age",
+	})
+	totalEvictedNonces = newCounter(prometheus.CounterOpts{
+		Name: "signer_total_evicted_nonces",
+		Help: "Total Cached Nonces Discarded Due To A Full Cache",
+	})
+	nonceCacheEntries = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_nonce_cache_size",
+		Help: "Number Of HRSTs With Cached Nonces",
+	}, []string{"chain_id"})
+
+	noncePoolDepth = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_nonce_pool_depth",
+		Help: "Pre-generated Nonces Ready In The Pool",
+	}, []string{"chain_id"})
+	totalNoncePoolHits = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_total_nonce_pool_hits",
+		Help: "Total Nonces Served From The Pool",
+	}, []string{"chain_id"})
+	totalNoncePoolMisses = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_total_nonce_pool_misses",
+		Help: "Total Nonces Generated On Demand Due To An Empty Pool",
+	}, []string{"chain_id"})
+
+	stateDirFreeSpace = newGauge(prometheus.GaugeOpts{
+		Name: "signer_state_dir_free_bytes",
+		Help: "Free space of the filesystem holding the state directory",
+	})
+
+	timedRaftApplyLag = newHistogram(prometheus.HistogramOpts{
+		Name:    "signer_raft_apply_lag_seconds",
+		Help:    "Seconds taken for raft to commit and apply sign state entries",
+		Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	})
+
+	totalUnexpectedProtocolVersions = newCounter(prometheus.CounterOpts{
+		Name: "signer_error_total_unexpected_protocol_versions",
+		Help: "Total Sign Requests Refused Because A Chain Node Reported An Unexpected Block Protocol Version",
+	})
+
+	totalChainNodeFailovers = newCounter(prometheus.CounterOpts{
+		Name: "signer_total_backup_chain_node_failovers",
+		Help: "Total Times All Primary Chain Nodes Were Unreachable And Backup Chain Nodes Were Used",
+	})
+	chainNodeConnected = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_chain_node_connected",
+		Help: "Whether The Privval Connection To The Chain Node Is Established",
+	}, []string{"node"})
+
+	totalLeaderRebalances = newCounter(prometheus.CounterOpts{
+		Name: "signer_total_leader_rebalances",
+		Help: "Total Times Leadership Was Transferred Due To Sign Latency",
+	})
+
+	signerArmed = newGauge(prometheus.GaugeOpts{
+		Name: "signer_armed",
+		Help: "Whether The Armed File Is Present (1) Or The Signer Is Disarmed (0)",
+	})
+
+	cosignerClockOffset = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_cosigner_clock_offset_seconds",
+		Help: "Seconds The Clock Of The Cosigner Is Ahead Of Ours, Negative If Behind",
+	}, []string{"peerid"})
+
+	cosignerClockSkew = newGauge(prometheus.GaugeOpts{
+		Name: "signer_cosigner_clock_skew_seconds",
+		Help: "Largest Clock Skew In Seconds Between Any Two Cosigners",
+	})
+
+	signerPaused = newGauge(prometheus.GaugeOpts{
+		Name: "signer_paused",
+		Help: "Whether Signing Is Paused (1) Or Not (0)",
+	})
+
+	totalGRPCRequests = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_total_grpc_requests",
+		Help: "Total Cosigner gRPC Requests Handled",
+	}, []string{"method", "chain_id"})
+
+	totalGRPCRequestErrors = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_error_total_grpc_requests",
+		Help: "Total Cosigner gRPC Requests That Returned An Error",
+	}, []string{"method", "chain_id"})
+
+	totalGRPCRequestsThrottled = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_total_grpc_requests_throttled",
+		Help: "Total Cosigner gRPC Requests Rejected By The Rate Limit",
+	}, []string{"method", "chain_id"})
+
+	timedGRPCRequestLatency = newHistogramVec(prometheus.HistogramOpts{
+		Name:    "signer_grpc_request_duration_seconds",
+		Help:    "Seconds taken to handle cosigner gRPC requests",
+		Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{"method", "chain_id"})
+
+	lastSignedHeight = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_last_signed_height",
+		Help: "Last Height Signed By The Cluster",
+	}, []string{"chain_id"})
+
+	lastSignedRound = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_last_signed_round",
+		Help: "Last Round Signed By The Cluster",
+	}, []string{"chain_id"})
+
+	lastSignedStep = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_last_signed_step",
+		Help: "Last Step Signed By The Cluster",
+	}, []string{"chain_id"})
+
+	totalSignedBlocks = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_total_signed_blocks",
+		Help: "Total Proposals And Votes Signed By The Cluster",
+	}, []string{"chain_id"})
+
+	totalDoubleSignsPrevented = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_total_double_signs_prevented",
+		Help: "Total Sign Requests Refused Because They Regress Or Conflict With The Sign State",
+	}, []string{"chain_id"})
+
+	totalRejectedBlockTimestamps = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_total_rejected_block_timestamps",
+		Help: "Total Sign Requests Refused Because The Block Timestamp Is Outside Of The Block Timestamp Window",
+	}, []string{"chain_id"})
+
+	totalAuditLogErrors = newCounter(prometheus.CounterOpts{
+		Name: "signer_error_total_audit_log_writes",
+		Help: "Total Times A Sign Decision Could Not Be Written To The Audit Log",
+	})
+
+	totalNotificationsSuppressed = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_total_notifications_suppressed",
+		Help: "Total Notifications Dropped Because One Of The Same Event And Chain Was Sent Within The Min Interval",
+	}, []string{"event"})
+
+	totalNotificationErrors = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_error_total_notifications",
+		Help: "Total Notifications That Could Not Be Sent To The Webhook",
+	}, []string{"event"})
+
+	signRequestsInFlight = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_sign_requests_in_flight",
+		Help: "Sign Requests Being Processed Within The Sign Concurrency Limit",
+	}, []string{"chain_id"})
+
+	signRequestsQueued = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_sign_requests_queued",
+		Help: "Sign Requests Waiting For The Sign Concurrency Limit",
+	}, []string{"chain_id"})
+
+	totalSignQueueFull = newCounterVec(prometheus.CounterOpts{
+		Name: "signer_total_sign_queue_full",
+		Help: "Total Sign Requests Refused Because The Sign Concurrency Queue Is Full",
+	}, []string{"chain_id"})
+
+	cosignerResponsive = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_cosigner_responsive",
+		Help: "Whether The Cosigner Answers The Nonce And Sign Requests Of The Leader (1) Or Not (0)",
+	}, []string{"peerid"})
+
+	cosignerSuccessRatio = newGaugeVec(prometheus.GaugeOpts{
+		Name: "signer_cosigner_success_ratio",
+		Help: "Ratio Of The Recent Nonce And Sign Requests Of The Leader That The Cosigner Answered",
+	}, []string{"peerid"})
+
+	responsiveCosigners = newGauge(prometheus.GaugeOpts{
+		Name: "signer_responsive_cosigners",
+		Help: "Number Of Responsive Cosigners, Including The Leader",
+	})
+
+	quorumHealthy = newGauge(prometheus.GaugeOpts{
+		Name: "signer_quorum_healthy",
+		Help: "Whether At Least The Threshold Of Cosigners Are Responsive (1) Or Not (0)",
+	})
+
+	raftLeaderID = newGauge(prometheus.GaugeOpts{
+		Name: "signer_raft_leader_id",
+		Help: "Shard ID Of The Current Raft Leader (0 If There Is No Leader)",
+	})
+
+	raftTerm = newGauge(prometheus.GaugeOpts{
+		Name: "signer_raft_term",
+		Help: "Current Raft Term",
+	})
+
+	secondsSinceLastLeaderChange = newGauge(prometheus.GaugeOpts{
+		Name: "signer_seconds_since_last_leader_change",
+		Help: "Seconds Since The Raft Leader Last Changed",
+	})
 )
 
 func StartMetrics() {
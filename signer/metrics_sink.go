@@ -0,0 +1,249 @@
+package signer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/armon/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type MetricsBackend string
+
+const (
+	MetricsBackendPrometheus MetricsBackend = "prometheus"
+	MetricsBackendStatsd     MetricsBackend = "statsd"
+)
+
+// metricsSink is the backend that the signer metrics are emitted to.
+// The metric types below forward to the active sink so that the instrumentation
+// call sites do not depend on the backend.
+type metricsSink interface {
+	incrCounter(c *counterVec, labelValues []string, val float64)
+	setGauge(g *gaugeVec, labelValues []string, val float64)
+	observe(s *summaryVec, labelValues []string, val float64)
+	observeHistogram(h *histogramVec, labelValues []string, val float64)
+}
+
+// activeMetricsSink defaults to prometheus, which is served by the debug server.
+var activeMetricsSink metricsSink = prometheusSink{}
+
+// EnableStatsdMetrics emits the signer metrics to the statsd server at addr instead of prometheus.
+// It returns the go-metrics sink so that it can also be used for the raft metrics.
+func EnableStatsdMetrics(addr string) (metrics.MetricSink, error) {
+	sink, err := metrics.NewStatsdSink(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create statsd sink: %w", err)
+	}
+	activeMetricsSink = statsdSink{sink: sink}
+	return sink, nil
+}
+
+type prometheusSink struct{}
+
+func (prometheusSink) incrCounter(c *counterVec, labelValues []string, val float64) {
+	c.prom.WithLabelValues(labelValues...).Add(val)
+}
+
+func (prometheusSink) setGauge(g *gaugeVec, labelValues []string, val float64) {
+	g.prom.WithLabelValues(labelValues...).Set(val)
+}
+
+func (prometheusSink) observe(s *summaryVec, labelValues []string, val float64) {
+	s.prom.WithLabelValues(labelValues...).Observe(val)
+}
+
+func (prometheusSink) observeHistogram(h *histogramVec, labelValues []string, val float64) {
+	h.prom.WithLabelValues(labelValues...).Observe(val)
+}
+
+type statsdSink struct {
+	sink metrics.MetricSink
+}
+
+func statsdLabels(names, values []string) []metrics.Label {
+	labels := make([]metrics.Label, len(names))
+	for i, name := range names {
+		labels[i] = metrics.Label{Name: name, Value: values[i]}
+	}
+	return labels
+}
+
+func (s statsdSink) incrCounter(c *counterVec, labelValues []string, val float64) {
+	s.sink.IncrCounterWithLabels([]string{c.name}, float32(val), statsdLabels(c.labels, labelValues))
+}
+
+func (s statsdSink) setGauge(g *gaugeVec, labelValues []string, val float64) {
+	s.sink.SetGaugeWithLabels([]string{g.name}, float32(val), statsdLabels(g.labels, labelValues))
+}
+
+// observe and observeHistogram emit the sample as a statsd timer. Timers are in milliseconds,
+// while the signer summaries are observed in seconds.
+func (s statsdSink) observe(sv *summaryVec, labelValues []string, val float64) {
+	s.addTimerSample(sv.name, sv.labels, labelValues, val)
+}
+
+func (s statsdSink) observeHistogram(h *histogramVec, labelValues []string, val float64) {
+	s.addTimerSample(h.name, h.labels, labelValues, val)
+}
+
+func (s statsdSink) addTimerSample(name string, labels, labelValues []string, val float64) {
+	key := strings.TrimSuffix(name, "_seconds")
+	s.sink.AddSampleWithLabels([]string{key}, float32(val*1000), statsdLabels(labels, labelValues))
+}
+
+type counterVec struct {
+	name   string
+	labels []string
+	prom   *prometheus.CounterVec
+}
+
+func newCounterVec(opts prometheus.CounterOpts, labels []string) *counterVec {
+	return &counterVec{name: opts.Name, labels: labels, prom: promauto.NewCounterVec(opts, labels)}
+}
+
+func (v *counterVec) WithLabelValues(labelValues ...string) counter {
+	return counter{vec: v, labelValues: labelValues}
+}
+
+type counter struct {
+	vec         *counterVec
+	labelValues []string
+}
+
+func newCounter(opts prometheus.CounterOpts) counter {
+	c := newCounterVec(opts, nil).WithLabelValues()
+	// initialize the unlabeled series so that it is exposed before the first increment.
+	c.vec.prom.WithLabelValues()
+	return c
+}
+
+func (c counter) Inc() {
+	c.Add(1)
+}
+
+func (c counter) Add(val float64) {
+	activeMetricsSink.incrCounter(c.vec, c.labelValues, val)
+}
+
+// gaugeVec tracks the current value of each series, since statsd gauges can only be set.
+type gaugeVec struct {
+	name   string
+	labels []string
+	prom   *prometheus.GaugeVec
+
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+func newGaugeVec(opts prometheus.GaugeOpts, labels []string) *gaugeVec {
+	return &gaugeVec{
+		name:   opts.Name,
+		labels: labels,
+		prom:   promauto.NewGaugeVec(opts, labels),
+		values: make(map[string]float64),
+	}
+}
+
+func (v *gaugeVec) WithLabelValues(labelValues ...string) gauge {
+	return gauge{vec: v, labelValues: labelValues}
+}
+
+func (v *gaugeVec) update(labelValues []string, fn func(float64) float64) {
+	key := strings.Join(labelValues, "\xff")
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	val := fn(v.values[key])
+	v.values[key] = val
+	activeMetricsSink.setGauge(v, labelValues, val)
+}
+
+type gauge struct {
+	vec         *gaugeVec
+	labelValues []string
+}
+
+func newGauge(opts prometheus.GaugeOpts) gauge {
+	g := newGaugeVec(opts, nil).WithLabelValues()
+	g.vec.prom.WithLabelValues()
+	return g
+}
+
+func (g gauge) Set(val float64) {
+	g.vec.update(g.labelValues, func(float64) float64 { return val })
+}
+
+func (g gauge) Add(val float64) {
+	g.vec.update(g.labelValues, func(current float64) float64 { return current + val })
+}
+
+// Delete stops exporting the series to prometheus. Statsd gauges are only sent when set.
+func (g gauge) Delete() {
+	g.vec.mu.Lock()
+	defer g.vec.mu.Unlock()
+
+	delete(g.vec.values, strings.Join(g.labelValues, "\xff"))
+	g.vec.prom.DeleteLabelValues(g.labelValues...)
+}
+
+type summaryVec struct {
+	name   string
+	labels []string
+	prom   *prometheus.SummaryVec
+}
+
+func newSummaryVec(opts prometheus.SummaryOpts, labels []string) *summaryVec {
+	return &summaryVec{name: opts.Name, labels: labels, prom: promauto.NewSummaryVec(opts, labels)}
+}
+
+func (v *summaryVec) WithLabelValues(labelValues ...string) summary {
+	return summary{vec: v, labelValues: labelValues}
+}
+
+type summary struct {
+	vec         *summaryVec
+	labelValues []string
+}
+
+func newSummary(opts prometheus.SummaryOpts) summary {
+	s := newSummaryVec(opts, nil).WithLabelValues()
+	s.vec.prom.WithLabelValues()
+	return s
+}
+
+func (s summary) Observe(val float64) {
+	activeMetricsSink.observe(s.vec, s.labelValues, val)
+}
+
+type histogramVec struct {
+	name   string
+	labels []string
+	prom   *prometheus.HistogramVec
+}
+
+func newHistogramVec(opts prometheus.HistogramOpts, labels []string) *histogramVec {
+	return &histogramVec{name: opts.Name, labels: labels, prom: promauto.NewHistogramVec(opts, labels)}
+}
+
+func (v *histogramVec) WithLabelValues(labelValues ...string) histogram {
+	return histogram{vec: v, labelValues: labelValues}
+}
+
+type histogram struct {
+	vec         *histogramVec
+	labelValues []string
+}
+
+func newHistogram(opts prometheus.HistogramOpts) histogram {
+	h := newHistogramVec(opts, nil).WithLabelValues()
+	h.vec.prom.WithLabelValues()
+	return h
+}
+
+func (h histogram) Observe(val float64) {
+	activeMetricsSink.observeHistogram(h.vec, h.labelValues, val)
+}
@@ -0,0 +1,56 @@
+package signer
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsdMetrics(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	prevSink := activeMetricsSink
+	defer func() { activeMetricsSink = prevSink }()
+
+	sink, err := EnableStatsdMetrics(conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer sink.(interface{ Shutdown() }).Shutdown()
+
+	testCounter := newCounter(prometheus.CounterOpts{Name: "signer_test_statsd_counter"})
+	testGauge := newGaugeVec(prometheus.GaugeOpts{Name: "signer_test_statsd_gauge"}, []string{"peerid"})
+	testSummary := newSummary(prometheus.SummaryOpts{Name: "signer_test_statsd_lag_seconds"})
+	testHistogram := newHistogram(prometheus.HistogramOpts{Name: "signer_test_statsd_histogram_seconds"})
+
+	testCounter.Inc()
+	testGauge.WithLabelValues("peer1").Set(2)
+	testGauge.WithLabelValues("peer1").Add(1)
+	testGauge.WithLabelValues("peer2").Add(1)
+	testSummary.Observe(0.25)
+	testHistogram.Observe(0.5)
+
+	expected := []string{
+		"signer_test_statsd_counter:1.000000|c",
+		"signer_test_statsd_gauge.peer1:2.000000|g",
+		"signer_test_statsd_gauge.peer1:3.000000|g",
+		"signer_test_statsd_gauge.peer2:1.000000|g",
+		"signer_test_statsd_lag:250.000000|ms",
+		"signer_test_statsd_histogram:500.000000|ms",
+	}
+
+	var received []string
+	buf := make([]byte, 1500)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for len(received) < len(expected) {
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		received = append(received, strings.Fields(string(buf[:n]))...)
+	}
+
+	require.Equal(t, expected, received)
+}
@@ -0,0 +1,117 @@
+package signer
+
+import (
+	"sync"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+)
+
+// noncePool keeps pre-generated nonces of the cosigner for a chain, so that they do not have to be generated
+// while the leader waits for GetNonces. Each nonce is removed from the pool when it is handed out, so that it
+// is never used for more than one HRST, and the pool is refilled in the background.
+type noncePool struct {
+	logger   cometlog.Logger
+	chainID  string
+	size     int
+	generate func() (Nonces, error)
+
+	mu        sync.Mutex
+	nonces    []Nonces
+	refilling bool
+	stopped   bool
+
+	wg sync.WaitGroup
+}
+
+func newNoncePool(logger cometlog.Logger, chainID string, size int, generate func() (Nonces, error)) *noncePool {
+	p := &noncePool{
+		logger:   logger,
+		chainID:  chainID,
+		size:     size,
+		generate: generate,
+		nonces:   make([]Nonces, 0, size),
+	}
+
+	p.mu.Lock()
+	p.refillLocked()
+	p.mu.Unlock()
+
+	return p
+}
+
+// get hands out a nonce from the pool, or generates a new one if the pool is empty.
+func (p *noncePool) get() (Nonces, error) {
+	p.mu.Lock()
+	n := len(p.nonces)
+	if n == 0 {
+		p.refillLocked()
+		p.mu.Unlock()
+		totalNoncePoolMisses.WithLabelValues(p.chainID).Inc()
+		return p.generate()
+	}
+
+	nonces := p.nonces[n-1]
+	p.nonces[n-1] = Nonces{}
+	p.nonces = p.nonces[:n-1]
+	noncePoolDepth.WithLabelValues(p.chainID).Set(float64(n - 1))
+	p.refillLocked()
+	p.mu.Unlock()
+
+	totalNoncePoolHits.WithLabelValues(p.chainID).Inc()
+	return nonces, nil
+}
+
+// refillLocked starts refilling the pool in the background if it is not full. Must be called with the mutex held.
+func (p *noncePool) refillLocked() {
+	if p.refilling || p.stopped || len(p.nonces) >= p.size {
+		return
+	}
+	p.refilling = true
+	p.wg.Add(1)
+	go p.refill()
+}
+
+func (p *noncePool) refill() {
+	defer p.wg.Done()
+
+	for {
+		nonces, err := p.generate()
+
+		p.mu.Lock()
+		if err != nil {
+			p.refilling = false
+			p.mu.Unlock()
+			p.logger.Error("Failed to generate nonces for the pool", "chain_id", p.chainID, "error", err)
+			return
+		}
+		if p.stopped {
+			p.refilling = false
+			p.mu.Unlock()
+			return
+		}
+		p.nonces = append(p.nonces, nonces)
+		noncePoolDepth.WithLabelValues(p.chainID).Set(float64(len(p.nonces)))
+		if len(p.nonces) >= p.size {
+			p.refilling = false
+			p.mu.Unlock()
+			return
+		}
+		p.mu.Unlock()
+	}
+}
+
+// stop discards the unused nonces and waits for a refill in progress to finish.
+// Nonces are generated on demand afterwards.
+func (p *noncePool) stop() {
+	p.mu.Lock()
+	p.stopped = true
+	for i := range p.nonces {
+		p.nonces[i] = Nonces{}
+	}
+	p.nonces = nil
+	p.mu.Unlock()
+
+	p.wg.Wait()
+
+	noncePoolDepth.WithLabelValues(p.chainID).Set(0)
+}
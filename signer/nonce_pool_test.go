@@ -0,0 +1,125 @@
+package signer
+
+import (
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	comet "github.com/cometbft/cometbft/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoncePool(t *testing.T) {
+	const chainID = "nonce-pool"
+
+	var generated atomic.Int64
+	generate := func() (Nonces, error) {
+		pubKey := make([]byte, 8)
+		binary.BigEndian.PutUint64(pubKey, uint64(generated.Add(1)))
+		return Nonces{PubKey: pubKey}, nil
+	}
+
+	pool := newNoncePool(cometlog.NewNopLogger(), chainID, 5, generate)
+
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(noncePoolDepth.prom.WithLabelValues(chainID)) == 5
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, int64(5), generated.Load())
+
+	hits := testutil.ToFloat64(totalNoncePoolHits.prom.WithLabelValues(chainID))
+	misses := testutil.ToFloat64(totalNoncePoolMisses.prom.WithLabelValues(chainID))
+
+	// each nonce is handed out at most once, whether it is served from the pool or generated on demand.
+	var mu sync.Mutex
+	seen := make(map[string]struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonces, err := pool.get()
+			require.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			_, ok := seen[string(nonces.PubKey)]
+			require.False(t, ok, "nonce handed out twice")
+			seen[string(nonces.PubKey)] = struct{}{}
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, seen, 50)
+	require.Equal(t, 50.0, testutil.ToFloat64(totalNoncePoolHits.prom.WithLabelValues(chainID))-hits+
+		testutil.ToFloat64(totalNoncePoolMisses.prom.WithLabelValues(chainID))-misses)
+	require.GreaterOrEqual(t, testutil.ToFloat64(totalNoncePoolHits.prom.WithLabelValues(chainID))-hits, 5.0)
+
+	pool.stop()
+	require.Zero(t, testutil.ToFloat64(noncePoolDepth.prom.WithLabelValues(chainID)))
+
+	// the pool is not refilled once stopped.
+	generatedBefore := generated.Load()
+	_, err := pool.get()
+	require.NoError(t, err)
+	require.Equal(t, generatedBefore+1, generated.Load())
+}
+
+func TestLocalCosignerNoncePool(t *testing.T) {
+	cosigners, pubKey := getTestLocalCosigners(t, 2, 2)
+	for _, c := range cosigners {
+		c.noncePoolSize = 3
+	}
+
+	leader := &MockLeader{id: 1}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{cosigners[1]},
+		leader,
+	)
+	defer cosigners[1].waitForSignStatesToFlushToDisk()
+
+	leader.leader = validator
+
+	require.NoError(t, validator.LoadSignStateIfNecessary(testChainID))
+	require.NoError(t, cosigners[1].LoadSignStateIfNecessary(testChainID))
+
+	for _, c := range cosigners {
+		ccs, err := c.getChainState(testChainID)
+		require.NoError(t, err)
+		require.NotNil(t, ccs.noncePool)
+	}
+
+	hits := testutil.ToFloat64(totalNoncePoolHits.prom.WithLabelValues(testChainID))
+
+	for i := int64(1); i <= 5; i++ {
+		proposal := cometproto.Proposal{
+			Height: i,
+			Round:  0,
+			Type:   cometproto.ProposalType,
+		}
+		require.NoError(t, validator.SignProposal(testChainID, &proposal))
+		require.True(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID, &proposal), proposal.Signature))
+	}
+
+	require.Greater(t, testutil.ToFloat64(totalNoncePoolHits.prom.WithLabelValues(testChainID)), hits)
+
+	validator.Stop()
+
+	ccs, err := cosigners[0].getChainState(testChainID)
+	require.NoError(t, err)
+	ccs.noncePool.mu.Lock()
+	defer ccs.noncePool.mu.Unlock()
+	require.True(t, ccs.noncePool.stopped)
+	require.Empty(t, ccs.noncePool.nonces)
+}
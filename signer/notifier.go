@@ -0,0 +1,317 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+const (
+	defaultNotifierTimeout              = 5 * time.Second
+	defaultNotifierMinInterval          = time.Minute
+	defaultNotifierSignFailureThreshold = 5
+)
+
+// NotificationEvent is the kind of event that a notification is sent for.
+type NotificationEvent string
+
+const (
+	// NotificationDoubleSignPrevented is sent when a block is refused because it regresses or conflicts
+	// with the sign state.
+	NotificationDoubleSignPrevented NotificationEvent = "double_sign_prevented"
+	// NotificationSignFailures is sent when the sign requests of a chain fail repeatedly.
+	NotificationSignFailures NotificationEvent = "sign_failures"
+	// NotificationLeaderChanged is sent by a cosigner when it becomes the raft leader.
+	NotificationLeaderChanged NotificationEvent = "leader_changed"
+)
+
+// Notification is the JSON payload of a notification. The height, round and step are those of the block
+// of the sign request, or 0 for a leader change.
+type Notification struct {
+	Event NotificationEvent `json:"event"`
+	Time  time.Time         `json:"time"`
+	// ShardID is the shard ID of the cosigner that sent the notification.
+	ShardID int    `json:"shard_id"`
+	ChainID string `json:"chain_id"`
+	Height  int64  `json:"height"`
+	Round   int64  `json:"round"`
+	Step    int8   `json:"step"`
+	// Failures is the number of consecutive sign failures of the chain.
+	Failures int `json:"failures,omitempty"`
+	// LeaderID is the shard ID of the new raft leader.
+	LeaderID int `json:"leader_id,omitempty"`
+	// Suppressed is the number of notifications of the same event and chain that were rate limited
+	// since the last one was sent.
+	Suppressed int `json:"suppressed,omitempty"`
+	// Text describes the event, as the text field of the message of a Slack incoming webhook.
+	Text string `json:"text"`
+}
+
+// Notifier sends notifications of the events that operators must act on.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// WebhookNotifier posts each notification as a JSON object to a URL.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (w WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	bz, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %s", res.Status)
+	}
+	return nil
+}
+
+// NotifierConfig sends notifications of double signs prevented, repeated sign failures and leader changes
+// to a webhook.
+type NotifierConfig struct {
+	// WebhookURL is the http or https URL that notifications are posted to.
+	WebhookURL string `yaml:"webhookURL"`
+	// Timeout bounds each notification request. Defaults to 5s.
+	Timeout string `yaml:"timeout,omitempty"`
+	// MinInterval is the least time between two notifications of the same event and chain,
+	// the notifications in between are dropped. Defaults to 1m.
+	MinInterval string `yaml:"minInterval,omitempty"`
+	// SignFailureThreshold is the number of consecutive sign failures of a chain from which notifications
+	// are sent. Defaults to 5.
+	SignFailureThreshold int `yaml:"signFailureThreshold,omitempty"`
+}
+
+func (c *NotifierConfig) Validate() error {
+	u, err := url.Parse(c.WebhookURL)
+	if err != nil {
+		return fmt.Errorf("invalid notifier webhookURL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("notifier webhookURL %q must be an http or https URL", c.WebhookURL)
+	}
+	for _, d := range []struct {
+		name  string
+		value string
+	}{
+		{"timeout", c.Timeout},
+		{"minInterval", c.MinInterval},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if v, err := time.ParseDuration(d.value); err != nil {
+			return fmt.Errorf("invalid notifier %s: %w", d.name, err)
+		} else if v <= 0 {
+			return fmt.Errorf("notifier %s (%s) must be greater than 0", d.name, v)
+		}
+	}
+	if c.SignFailureThreshold < 0 {
+		return fmt.Errorf("notifier signFailureThreshold (%d) must not be negative", c.SignFailureThreshold)
+	}
+	return nil
+}
+
+func (c *NotifierConfig) params() (timeout, minInterval time.Duration, signFailureThreshold int) {
+	timeout, minInterval, signFailureThreshold =
+		defaultNotifierTimeout, defaultNotifierMinInterval, defaultNotifierSignFailureThreshold
+
+	// Validated prior in ValidateThresholdModeConfig
+	if d, err := time.ParseDuration(c.Timeout); err == nil {
+		timeout = d
+	}
+	if d, err := time.ParseDuration(c.MinInterval); err == nil {
+		minInterval = d
+	}
+	if c.SignFailureThreshold > 0 {
+		signFailureThreshold = c.SignFailureThreshold
+	}
+	return timeout, minInterval, signFailureThreshold
+}
+
+// notificationKey is what notifications are rate limited by.
+type notificationKey struct {
+	event   NotificationEvent
+	chainID string
+}
+
+// notificationLimit is the time of the last notification sent for a key, and the number dropped since.
+type notificationLimit struct {
+	sent       time.Time
+	suppressed int
+}
+
+// notifications rate limits the notifications of a cosigner and sends them in the background,
+// so that neither a storm of events nor a slow webhook holds up signing.
+type notifications struct {
+	logger  log.Logger
+	sink    Notifier
+	shardID int
+
+	timeout              time.Duration
+	minInterval          time.Duration
+	signFailureThreshold int
+
+	mu       sync.Mutex
+	limits   map[notificationKey]*notificationLimit
+	failures map[string]int
+	now      func() time.Time
+
+	// pending are the notifications being sent.
+	pending sync.WaitGroup
+}
+
+// newNotifications returns nil if the notifier is not configured, in which case nothing is sent.
+func newNotifications(logger log.Logger, config *RuntimeConfig, shardID int) *notifications {
+	if config == nil || config.Config.ThresholdModeConfig == nil || config.Config.ThresholdModeConfig.Notifier == nil {
+		return nil
+	}
+	c := config.Config.ThresholdModeConfig.Notifier
+	timeout, minInterval, signFailureThreshold := c.params()
+	return &notifications{
+		logger:               logger,
+		sink:                 WebhookNotifier{URL: c.WebhookURL, Client: &http.Client{Timeout: timeout}},
+		shardID:              shardID,
+		timeout:              timeout,
+		minInterval:          minInterval,
+		signFailureThreshold: signFailureThreshold,
+		limits:               make(map[notificationKey]*notificationLimit),
+		failures:             make(map[string]int),
+		now:                  time.Now,
+	}
+}
+
+// signResult notifies of a sign request of the chain that is refused because it would double sign,
+// or that failed for the signFailureThreshold time in a row or more.
+func (ns *notifications) signResult(chainID string, block *Block, err error) {
+	if ns == nil {
+		return
+	}
+
+	n := Notification{
+		ChainID: chainID,
+		Height:  block.Height,
+		Round:   block.Round,
+		Step:    block.Step,
+	}
+
+	switch {
+	case err == nil:
+		ns.mu.Lock()
+		delete(ns.failures, chainID)
+		ns.mu.Unlock()
+		return
+	case isDoubleSignPrevented(err):
+		n.Event = NotificationDoubleSignPrevented
+		n.Text = fmt.Sprintf("horcrux cosigner %d refused to double sign %s at height %d, round %d, step %d: %v",
+			ns.shardID, chainID, block.Height, block.Round, block.Step, err)
+	case errors.Is(err, errSigningPaused), errors.Is(err, errShuttingDown):
+		// the cosigner is not signing on purpose.
+		return
+	case isSignStateRefusal(err):
+		// the block is already signed or moved past, e.g. when it is requested by several sentries.
+		return
+	default:
+		ns.mu.Lock()
+		ns.failures[chainID]++
+		n.Failures = ns.failures[chainID]
+		ns.mu.Unlock()
+		if n.Failures < ns.signFailureThreshold {
+			return
+		}
+		n.Event = NotificationSignFailures
+		n.Text = fmt.Sprintf("horcrux cosigner %d failed to sign %s %d times in a row, last at height %d, "+
+			"round %d, step %d: %v", ns.shardID, chainID, n.Failures, block.Height, block.Round, block.Step, err)
+	}
+
+	ns.notify(n)
+}
+
+// leaderChanged notifies that this cosigner became the raft leader.
+func (ns *notifications) leaderChanged(leaderID int) {
+	if ns == nil {
+		return
+	}
+	ns.notify(Notification{
+		Event:    NotificationLeaderChanged,
+		LeaderID: leaderID,
+		Text:     fmt.Sprintf("horcrux cosigner %d became the raft leader", leaderID),
+	})
+}
+
+// notify sends the notification in the background, unless a notification of the same event and chain
+// was sent less than minInterval ago, in which case it is dropped.
+func (ns *notifications) notify(n Notification) {
+	key := notificationKey{event: n.Event, chainID: n.ChainID}
+	now := ns.now()
+
+	ns.mu.Lock()
+	limit, ok := ns.limits[key]
+	if !ok {
+		limit = &notificationLimit{}
+		ns.limits[key] = limit
+	}
+	if !limit.sent.IsZero() && now.Sub(limit.sent) < ns.minInterval {
+		limit.suppressed++
+		ns.mu.Unlock()
+		totalNotificationsSuppressed.WithLabelValues(string(n.Event)).Inc()
+		return
+	}
+	n.Suppressed = limit.suppressed
+	limit.sent, limit.suppressed = now, 0
+	ns.mu.Unlock()
+
+	n.Time = now
+	n.ShardID = ns.shardID
+
+	ns.pending.Add(1)
+	go func() {
+		defer ns.pending.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), ns.timeout)
+		defer cancel()
+		if err := ns.sink.Notify(ctx, n); err != nil {
+			totalNotificationErrors.WithLabelValues(string(n.Event)).Inc()
+			ns.logger.Error(
+				"Failed to send notification",
+				"event", n.Event,
+				"chain_id", n.ChainID,
+				"error", err,
+			)
+		}
+	}()
+}
+
+// wait waits for the notifications being sent.
+func (ns *notifications) wait() {
+	if ns == nil {
+		return
+	}
+	ns.pending.Wait()
+}
@@ -0,0 +1,162 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// testNotifier records the notifications it is sent.
+type testNotifier struct {
+	mu            sync.Mutex
+	notifications []Notification
+}
+
+func (n *testNotifier) Notify(_ context.Context, notification Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func (n *testNotifier) sent() []Notification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Notification(nil), n.notifications...)
+}
+
+func TestNotifierConfig(t *testing.T) {
+	c := &NotifierConfig{WebhookURL: "https://hooks.example.com/horcrux"}
+	require.NoError(t, c.Validate())
+	timeout, minInterval, signFailureThreshold := c.params()
+	require.Equal(t, 5*time.Second, timeout)
+	require.Equal(t, time.Minute, minInterval)
+	require.Equal(t, 5, signFailureThreshold)
+
+	c = &NotifierConfig{WebhookURL: "http://localhost:8080", Timeout: "1s", MinInterval: "10s", SignFailureThreshold: 3}
+	require.NoError(t, c.Validate())
+	timeout, minInterval, signFailureThreshold = c.params()
+	require.Equal(t, time.Second, timeout)
+	require.Equal(t, 10*time.Second, minInterval)
+	require.Equal(t, 3, signFailureThreshold)
+
+	require.EqualError(t, (&NotifierConfig{WebhookURL: "hooks.example.com"}).Validate(),
+		`notifier webhookURL "hooks.example.com" must be an http or https URL`)
+	require.EqualError(t, (&NotifierConfig{WebhookURL: "https://hooks.example.com", MinInterval: "0s"}).Validate(),
+		"notifier minInterval (0s) must be greater than 0")
+	require.ErrorContains(t, (&NotifierConfig{WebhookURL: "https://hooks.example.com", Timeout: "soon"}).Validate(),
+		"invalid notifier timeout")
+	require.EqualError(t, (&NotifierConfig{WebhookURL: "https://hooks.example.com", SignFailureThreshold: -1}).Validate(),
+		"notifier signFailureThreshold (-1) must not be negative")
+}
+
+func TestNotifications(t *testing.T) {
+	sink := &testNotifier{}
+	now := time.Now()
+	ns := &notifications{
+		logger:               cometlog.NewNopLogger(),
+		sink:                 sink,
+		shardID:              2,
+		timeout:              time.Second,
+		minInterval:          time.Minute,
+		signFailureThreshold: 2,
+		limits:               make(map[notificationKey]*notificationLimit),
+		failures:             make(map[string]int),
+		now:                  func() time.Time { return now },
+	}
+
+	block := &Block{Height: 10, Round: 1, Step: stepPrevote}
+	regression := newRegressionError("regression not allowed")
+
+	// a double sign prevented is notified, rate limited for each chain.
+	ns.signResult(testChainID, block, regression)
+	ns.signResult(testChainID, block, regression)
+	ns.signResult("other-chain", block, regression)
+	ns.wait()
+	sent := sink.sent()
+	require.Len(t, sent, 2)
+	require.Equal(t, NotificationDoubleSignPrevented, sent[0].Event)
+	require.Equal(t, 2, sent[0].ShardID)
+	require.Equal(t, int64(10), sent[0].Height)
+	require.Equal(t, int64(1), sent[0].Round)
+	require.Equal(t, int8(stepPrevote), sent[0].Step)
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		totalNotificationsSuppressed.prom.WithLabelValues(string(NotificationDoubleSignPrevented))))
+
+	// the count of suppressed notifications is sent with the next one.
+	now = now.Add(time.Minute)
+	ns.signResult(testChainID, block, regression)
+	ns.wait()
+	sent = sink.sent()
+	require.Len(t, sent, 3)
+	require.Equal(t, testChainID, sent[2].ChainID)
+	require.Equal(t, 1, sent[2].Suppressed)
+
+	// a block that is already signed or moved past is routine with several sentries, and is not notified.
+	ns.signResult(testChainID, block, &BeyondBlockError{msg: "Progress already started on block 10.1.3"})
+	ns.signResult(testChainID, block, newSameHRSError(block.HRSKey()))
+	ns.signResult(testChainID, block, newSameHRSError(block.HRSKey()))
+	ns.wait()
+	require.Len(t, sink.sent(), 3)
+
+	// sign failures are notified from the threshold of consecutive failures.
+	failed := errors.New("failed to get nonces")
+	ns.signResult(testChainID, block, failed)
+	ns.signResult(testChainID, block, nil)
+	ns.signResult(testChainID, block, failed)
+	ns.signResult(testChainID, block, errSigningPaused)
+	ns.wait()
+	require.Len(t, sink.sent(), 3)
+
+	ns.signResult(testChainID, block, failed)
+	ns.wait()
+	sent = sink.sent()
+	require.Len(t, sent, 4)
+	require.Equal(t, NotificationSignFailures, sent[3].Event)
+	require.Equal(t, 2, sent[3].Failures)
+
+	ns.leaderChanged(2)
+	ns.wait()
+	sent = sink.sent()
+	require.Len(t, sent, 5)
+	require.Equal(t, NotificationLeaderChanged, sent[4].Event)
+	require.Equal(t, 2, sent[4].LeaderID)
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	received := make(chan Notification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var n Notification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil || n.ChainID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- n
+	}))
+	defer server.Close()
+
+	webhook := WebhookNotifier{URL: server.URL}
+	require.NoError(t, webhook.Notify(context.Background(), Notification{
+		Event:   NotificationDoubleSignPrevented,
+		ChainID: testChainID,
+		Height:  10,
+		Text:    "refused to double sign",
+	}))
+	n := <-received
+	require.Equal(t, NotificationDoubleSignPrevented, n.Event)
+	require.Equal(t, int64(10), n.Height)
+	require.Equal(t, "refused to double sign", n.Text)
+
+	require.EqualError(t, webhook.Notify(context.Background(), Notification{}),
+		"webhook responded with status 400 Bad Request")
+}
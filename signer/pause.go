@@ -0,0 +1,111 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const pausedFileName = "PAUSED"
+
+var errSigningPaused = errors.New("signing paused")
+
+// signingPause is whether the threshold validator refuses to sign for maintenance. It is persisted as the
+// paused file in the state directory, so that a paused cosigner is still paused after a restart.
+type signingPause struct {
+	path string
+
+	mu     sync.RWMutex
+	paused bool
+}
+
+// newSigningPause returns the pause of the state directory, paused if the paused file exists.
+func newSigningPause(stateDir string) *signingPause {
+	path := filepath.Join(stateDir, pausedFileName)
+	_, err := os.Stat(path)
+	// unless the file is known to be missing, stay paused rather than resume signing by mistake.
+	p := &signingPause{path: path, paused: !os.IsNotExist(err)}
+	setPausedGauge(p.paused)
+	return p
+}
+
+// isPaused returns whether signing is paused. A nil signingPause is never paused.
+func (p *signingPause) isPaused() bool {
+	if p == nil {
+		return false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.paused
+}
+
+// set pauses or resumes signing and persists it. Signing is paused even if the paused file can not be written,
+// and stays paused if it can not be removed.
+func (p *signingPause) set(paused bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if paused {
+		p.paused = true
+		setPausedGauge(true)
+		if err := os.WriteFile(p.path, nil, 0600); err != nil {
+			return fmt.Errorf("failed to write paused file: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Remove(p.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove paused file: %w", err)
+	}
+	p.paused = false
+	setPausedGauge(false)
+	return nil
+}
+
+func setPausedGauge(paused bool) {
+	if paused {
+		signerPaused.Set(1)
+	} else {
+		signerPaused.Set(0)
+	}
+}
+
+// Paused returns whether signing is paused on this cosigner.
+func (pv *ThresholdValidator) Paused() bool {
+	return pv.pause.isPaused()
+}
+
+// SetPaused pauses or resumes signing on this cosigner. The cluster is paused through
+// RaftStore.SetPaused, which replicates it to every cosigner.
+func (pv *ThresholdValidator) SetPaused(paused bool) error {
+	if paused == pv.pause.isPaused() {
+		return nil
+	}
+	if err := pv.pause.set(paused); err != nil {
+		return err
+	}
+	if paused {
+		pv.logger.Info("Signing paused, sign requests are refused until resumed")
+	} else {
+		pv.logger.Info("Signing resumed")
+	}
+	return nil
+}
+
+// SetPaused pauses or resumes signing on every cosigner, it must be called on the leader.
+// The paused state is retained in the raft store, so it is also applied to cosigners that catch up later.
+func (s *RaftStore) SetPaused(paused bool) error {
+	return s.Emit(raftEventPaused, paused)
+}
+
+// syncPaused applies the paused state replicated before the threshold validator was set.
+func (s *RaftStore) syncPaused() {
+	s.mu.Lock()
+	value, ok := s.m[raftEventPaused]
+	s.mu.Unlock()
+	if ok {
+		(*fsm)(s).handlePausedEvent(value)
+	}
+}
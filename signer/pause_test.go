@@ -0,0 +1,73 @@
+package signer
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPauseValidator(cosigner *LocalCosigner) *ThresholdValidator {
+	return NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigner.config,
+		2,
+		time.Second,
+		1,
+		cosigner,
+		nil,
+		&MockLeader{id: 1},
+	)
+}
+
+func TestThresholdValidatorPaused(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	pausedFile := filepath.Join(cosigners[0].config.StateDir, pausedFileName)
+
+	validator := newTestPauseValidator(cosigners[0])
+	require.False(t, validator.Paused())
+
+	require.NoError(t, validator.SetPaused(true))
+	require.True(t, validator.Paused())
+	require.FileExists(t, pausedFile)
+
+	_, _, err := validator.SignBlock(context.Background(), testChainID, &Block{Height: 1})
+	require.ErrorIs(t, err, errSigningPaused)
+
+	// a restarted cosigner is still paused.
+	validator = newTestPauseValidator(cosigners[0])
+	require.True(t, validator.Paused())
+
+	require.NoError(t, validator.SetPaused(false))
+	require.False(t, validator.Paused())
+	require.NoFileExists(t, pausedFile)
+
+	validator = newTestPauseValidator(cosigners[0])
+	require.False(t, validator.Paused())
+}
+
+func TestRaftStorePausedEvent(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	validator := newTestPauseValidator(cosigners[0])
+
+	s := &RaftStore{logger: cometlog.NewNopLogger(), m: make(map[string]string)}
+	f := (*fsm)(s)
+
+	// replicated before the threshold validator is set, e.g. while replaying the log at startup.
+	f.applySet(raftEventPaused, "true")
+	require.False(t, validator.Paused())
+	s.SetThresholdValidator(validator)
+	require.True(t, validator.Paused())
+
+	f.applySet(raftEventPaused, "false")
+	require.False(t, validator.Paused())
+
+	// the paused state is retained in snapshots and applied when one is restored.
+	require.NoError(t, f.Restore(io.NopCloser(strings.NewReader(`{"PAUSED":"true"}`))))
+	require.True(t, validator.Paused())
+}
@@ -0,0 +1,69 @@
+package signer
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// defaultPeerClockTolerance is used when the peerClockTolerance is not configured.
+	defaultPeerClockTolerance = time.Second
+
+	// peerClockAheadExcludeAfter is the number of consecutive sign responses with a timestamp
+	// ahead of the leader's clock after which the peer is excluded from nonce requests.
+	peerClockAheadExcludeAfter = 3
+
+	// peerClockAheadExcludeDuration is how long a peer with a clock ahead is excluded before it is asked again.
+	peerClockAheadExcludeDuration = time.Minute
+)
+
+// peerClockTracker detects peer cosigners that consistently report timestamps ahead of the leader's clock.
+type peerClockTracker struct {
+	mu    sync.Mutex
+	peers map[int]*peerClockState
+}
+
+type peerClockState struct {
+	consecutiveAhead int
+	excludedUntil    time.Time
+}
+
+func newPeerClockTracker() *peerClockTracker {
+	return &peerClockTracker{peers: make(map[int]*peerClockState)}
+}
+
+// observe records whether the clock of the peer with the given shard ID was ahead of ours,
+// and returns true if the peer has just become excluded.
+func (t *peerClockTracker) observe(id int, isAhead bool, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.peers[id]
+	if !ok {
+		state = new(peerClockState)
+		t.peers[id] = state
+	}
+
+	if !isAhead {
+		state.consecutiveAhead = 0
+		return false
+	}
+
+	state.consecutiveAhead++
+	if state.consecutiveAhead < peerClockAheadExcludeAfter || now.Before(state.excludedUntil) {
+		return false
+	}
+
+	state.consecutiveAhead = 0
+	state.excludedUntil = now.Add(peerClockAheadExcludeDuration)
+	return true
+}
+
+// excluded returns true if the peer with the given shard ID is currently excluded because its clock is ahead.
+func (t *peerClockTracker) excluded(id int, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.peers[id]
+	return ok && now.Before(state.excludedUntil)
+}
@@ -0,0 +1,282 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+const (
+	// peerLivenessWindow is the number of the most recent nonce and sign requests to each peer
+	// that its success ratio is reported over.
+	peerLivenessWindow = 20
+
+	// peerUnresponsiveFailures is the number of consecutive failed requests after which a peer
+	// is unresponsive, until a request to it succeeds.
+	peerUnresponsiveFailures = 3
+
+	// peerLivenessCheckInterval is how often the leader checks the health of every peer, so that
+	// peers which are not asked for nonces, e.g. backups, are tracked too.
+	peerLivenessCheckInterval = 5 * time.Second
+)
+
+// peerResults are the outcomes of the most recent nonce and sign requests to a peer.
+type peerResults struct {
+	address string
+
+	// results is a ring of the last peerLivenessWindow outcomes, true for a success.
+	results [peerLivenessWindow]bool
+	count   int
+	next    int
+
+	consecutiveFailures int
+}
+
+func (r *peerResults) add(ok bool) {
+	r.results[r.next] = ok
+	r.next = (r.next + 1) % peerLivenessWindow
+	if r.count < peerLivenessWindow {
+		r.count++
+	}
+	if ok {
+		r.consecutiveFailures = 0
+	} else {
+		r.consecutiveFailures++
+	}
+}
+
+func (r *peerResults) successRatio() float64 {
+	if r.count == 0 {
+		return 1
+	}
+	successes := 0
+	for _, ok := range r.results[:r.count] {
+		if ok {
+			successes++
+		}
+	}
+	return float64(successes) / float64(r.count)
+}
+
+func (r *peerResults) responsive() bool {
+	return r.consecutiveFailures < peerUnresponsiveFailures
+}
+
+// peerLiveness tracks which peer cosigners answer the nonce, sign and health requests of the leader,
+// to report when the cluster is one cosigner failure away from not reaching the threshold.
+// Only the leader exports the liveness metrics.
+type peerLiveness struct {
+	logger    log.Logger
+	threshold int
+
+	mu    sync.Mutex
+	peers map[int]*peerResults
+
+	// leader is true while this cosigner is the leader and tracks its peers.
+	leader bool
+
+	// responsive is the number of responsive cosigners, including this one.
+	responsive int
+}
+
+// newPeerLiveness does not track the peers until setLeader is called.
+func newPeerLiveness(logger log.Logger, threshold int, peers []Cosigner) *peerLiveness {
+	l := &peerLiveness{
+		logger:    logger,
+		threshold: threshold,
+		peers:     make(map[int]*peerResults, len(peers)),
+	}
+	for _, peer := range peers {
+		l.peers[peer.GetID()] = &peerResults{address: peer.GetAddress()}
+	}
+	return l
+}
+
+// setLeader starts tracking the peers with every peer responsive, until requests to it fail, when this
+// cosigner becomes the leader, and stops exporting the liveness metrics when it is no longer the leader.
+func (l *peerLiveness) setLeader(leader bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if leader == l.leader {
+		return
+	}
+	l.leader = leader
+
+	if !leader {
+		for _, r := range l.peers {
+			cosignerResponsive.WithLabelValues(r.address).Delete()
+			cosignerSuccessRatio.WithLabelValues(r.address).Delete()
+		}
+		responsiveCosigners.Delete()
+		quorumHealthy.Delete()
+		return
+	}
+
+	for id, r := range l.peers {
+		l.peers[id] = &peerResults{address: r.address}
+		cosignerResponsive.WithLabelValues(r.address).Set(1)
+		cosignerSuccessRatio.WithLabelValues(r.address).Set(1)
+	}
+	l.responsive = len(l.peers) + 1
+	l.updateQuorumMetrics()
+}
+
+// record adds the outcome of a nonce, sign or health request to the peer, this cosigner is not tracked.
+// A request abandoned because the sign request was finished or cancelled is not counted against the peer,
+// nor is a request that finishes after this cosigner lost the leadership.
+func (l *peerLiveness) record(ctx context.Context, peer Cosigner, err error) {
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r, ok := l.peers[peer.GetID()]
+	if !ok || !l.leader {
+		return
+	}
+
+	wasResponsive := r.responsive()
+	r.add(err == nil)
+	cosignerSuccessRatio.WithLabelValues(r.address).Set(r.successRatio())
+	if r.responsive() == wasResponsive {
+		return
+	}
+
+	if r.responsive() {
+		cosignerResponsive.WithLabelValues(r.address).Set(1)
+		l.responsive++
+		l.logger.Info("Cosigner is responsive again", "cosigner", peer.GetID(), "responsive", l.responsive)
+	} else {
+		cosignerResponsive.WithLabelValues(r.address).Set(0)
+		l.responsive--
+		l.logger.Error(
+			"Cosigner is unresponsive",
+			"cosigner", peer.GetID(),
+			"consecutive_failures", r.consecutiveFailures,
+			"responsive", l.responsive,
+			"threshold", l.threshold,
+		)
+	}
+	l.updateQuorumMetrics()
+
+	switch {
+	case l.responsive == l.threshold:
+		l.logger.Error(
+			"Only the threshold of cosigners are responsive, one more failure prevents signing",
+			"responsive", l.responsive,
+			"threshold", l.threshold,
+			"unresponsive_cosigners", l.unresponsive(),
+		)
+	case l.responsive < l.threshold:
+		l.logger.Error(
+			"Fewer than the threshold of cosigners are responsive, blocks can not be signed",
+			"responsive", l.responsive,
+			"threshold", l.threshold,
+			"unresponsive_cosigners", l.unresponsive(),
+		)
+	}
+}
+
+// unresponsive returns the shard IDs of the unresponsive peers.
+func (l *peerLiveness) unresponsive() (ids []int) {
+	for id, r := range l.peers {
+		if !r.responsive() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (l *peerLiveness) updateQuorumMetrics() {
+	responsiveCosigners.Set(float64(l.responsive))
+	if l.responsive >= l.threshold {
+		quorumHealthy.Set(1)
+	} else {
+		quorumHealthy.Set(0)
+	}
+}
+
+// healthPeer is a cosigner whose health service can be checked.
+type healthPeer interface {
+	Cosigner
+	CheckHealth(ctx context.Context) error
+}
+
+var _ service.Service = &PeerLivenessMonitor{}
+
+// PeerLivenessMonitor periodically checks the health of every peer while this cosigner is the leader,
+// and records the results in the peer liveness of the threshold validator.
+type PeerLivenessMonitor struct {
+	service.BaseService
+
+	pv *ThresholdValidator
+
+	quit chan struct{}
+}
+
+// NewPeerLivenessMonitor returns a monitor of the peers of the threshold validator.
+func NewPeerLivenessMonitor(logger log.Logger, pv *ThresholdValidator) *PeerLivenessMonitor {
+	m := &PeerLivenessMonitor{
+		pv:   pv,
+		quit: make(chan struct{}),
+	}
+	m.BaseService = *service.NewBaseService(logger, "PeerLivenessMonitor", m)
+	return m
+}
+
+func (m *PeerLivenessMonitor) OnStart() error {
+	go m.loop()
+	return nil
+}
+
+func (m *PeerLivenessMonitor) OnStop() {
+	close(m.quit)
+}
+
+func (m *PeerLivenessMonitor) loop() {
+	ticker := time.NewTicker(peerLivenessCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.quit:
+			return
+		case <-ticker.C:
+			m.check()
+		}
+	}
+}
+
+// check starts or stops tracking the peers as the leadership changes, and checks the health of every peer
+// as the leader.
+func (m *PeerLivenessMonitor) check() {
+	leader := m.pv.leader.IsLeader()
+	m.pv.peerLiveness.setLeader(leader)
+	if !leader {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, peer := range m.pv.peerCosigners {
+		p, ok := peer.(healthPeer)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), m.pv.grpcTimeout)
+			defer cancel()
+			m.pv.peerLiveness.record(ctx, p, p.CheckHealth(ctx))
+		}()
+	}
+	wg.Wait()
+}
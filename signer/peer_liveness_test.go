@@ -0,0 +1,136 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerLiveness(t *testing.T) {
+	peer2 := NewRemoteCosigner(2, "tcp://liveness-2:2222")
+	peer3 := NewRemoteCosigner(3, "tcp://liveness-3:2222")
+	l := newPeerLiveness(cometlog.NewNopLogger(), 2, []Cosigner{peer2, peer3})
+	l.setLeader(true)
+
+	responsive := func(peer Cosigner) float64 {
+		return testutil.ToFloat64(cosignerResponsive.prom.WithLabelValues(peer.GetAddress()))
+	}
+
+	require.Equal(t, 1.0, responsive(peer2))
+	require.Equal(t, 3.0, testutil.ToFloat64(responsiveCosigners.vec.prom.WithLabelValues()))
+	require.Equal(t, 1.0, testutil.ToFloat64(quorumHealthy.vec.prom.WithLabelValues()))
+
+	ctx := context.Background()
+	failed := errors.New("unavailable")
+
+	// a peer is unresponsive after consecutive failures.
+	l.record(ctx, peer2, nil)
+	for i := 0; i < peerUnresponsiveFailures-1; i++ {
+		l.record(ctx, peer2, failed)
+	}
+	require.Equal(t, 1.0, responsive(peer2))
+	l.record(ctx, peer2, failed)
+	require.Equal(t, 0.0, responsive(peer2))
+	require.Equal(t, 0.25, testutil.ToFloat64(cosignerSuccessRatio.prom.WithLabelValues(peer2.GetAddress())))
+	require.Equal(t, 2.0, testutil.ToFloat64(responsiveCosigners.vec.prom.WithLabelValues()))
+	require.Equal(t, 1.0, testutil.ToFloat64(quorumHealthy.vec.prom.WithLabelValues()))
+	require.Equal(t, []int{2}, l.unresponsive())
+
+	// requests abandoned once the sign request is finished are not counted.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	for i := 0; i < peerUnresponsiveFailures; i++ {
+		l.record(cancelled, peer3, failed)
+	}
+	require.Equal(t, 1.0, responsive(peer3))
+
+	// the quorum is lost below the threshold of responsive cosigners.
+	for i := 0; i < peerUnresponsiveFailures; i++ {
+		l.record(ctx, peer3, failed)
+	}
+	require.Equal(t, 1.0, testutil.ToFloat64(responsiveCosigners.vec.prom.WithLabelValues()))
+	require.Equal(t, 0.0, testutil.ToFloat64(quorumHealthy.vec.prom.WithLabelValues()))
+
+	// a peer is responsive again on its first success.
+	l.record(ctx, peer2, nil)
+	require.Equal(t, 1.0, responsive(peer2))
+	require.Equal(t, 2.0, testutil.ToFloat64(responsiveCosigners.vec.prom.WithLabelValues()))
+	require.Equal(t, 1.0, testutil.ToFloat64(quorumHealthy.vec.prom.WithLabelValues()))
+	require.Equal(t, []int{3}, l.unresponsive())
+
+	// the metrics are not exported off the leader, and requests finishing after the leadership was lost
+	// are not recorded.
+	l.setLeader(false)
+	l.record(ctx, peer2, nil)
+	require.Zero(t, testutil.CollectAndCount(cosignerResponsive.prom))
+	require.Zero(t, testutil.CollectAndCount(quorumHealthy.vec.prom))
+
+	// a new leadership starts with every peer responsive.
+	l.setLeader(true)
+	require.Equal(t, 1.0, responsive(peer3))
+	require.Equal(t, 3.0, testutil.ToFloat64(responsiveCosigners.vec.prom.WithLabelValues()))
+	require.Empty(t, l.unresponsive())
+}
+
+type livenessTestLeader struct {
+	Leader
+	leader bool
+}
+
+func (l *livenessTestLeader) IsLeader() bool {
+	return l.leader
+}
+
+type livenessTestCosigner struct {
+	Cosigner
+	id  int
+	err error
+}
+
+func (c *livenessTestCosigner) GetID() int {
+	return c.id
+}
+
+func (c *livenessTestCosigner) GetAddress() string {
+	return fmt.Sprintf("tcp://liveness-monitor-%d:2222", c.id)
+}
+
+func (c *livenessTestCosigner) CheckHealth(context.Context) error {
+	return c.err
+}
+
+func TestPeerLivenessMonitorCheck(t *testing.T) {
+	backup := &livenessTestCosigner{id: 3, err: errors.New("not serving")}
+	peers := []Cosigner{&livenessTestCosigner{id: 2}, backup}
+	leader := new(livenessTestLeader)
+	pv := &ThresholdValidator{
+		leader:        leader,
+		peerCosigners: peers,
+		grpcTimeout:   time.Second,
+		peerLiveness:  newPeerLiveness(cometlog.NewNopLogger(), 2, peers),
+	}
+	m := NewPeerLivenessMonitor(cometlog.NewNopLogger(), pv)
+
+	// followers do not check their peers.
+	m.check()
+	require.False(t, pv.peerLiveness.leader)
+	require.Equal(t, 0, pv.peerLiveness.peers[3].count)
+
+	// the leader checks every peer, including those it does not ask for nonces.
+	leader.leader = true
+	for i := 0; i < peerUnresponsiveFailures; i++ {
+		m.check()
+	}
+	require.Equal(t, []int{3}, pv.peerLiveness.unresponsive())
+	require.Equal(t, 0.0, testutil.ToFloat64(cosignerResponsive.prom.WithLabelValues(backup.GetAddress())))
+
+	leader.leader = false
+	m.check()
+	require.False(t, pv.peerLiveness.leader)
+}
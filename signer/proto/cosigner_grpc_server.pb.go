@@ -30,6 +30,12 @@ type Block struct {
 	Step      int32  `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
 	SignBytes []byte `protobuf:"bytes,4,opt,name=signBytes,proto3" json:"signBytes,omitempty"`
 	Timestamp int64  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// deadline is an optional hint, in unix nanoseconds, after which the signature is no longer useful.
+	Deadline int64 `protobuf:"varint,6,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// blockID and polRound are the remaining fields of the canonical proposal or vote,
+	// so that the sign bytes can be reconstructed by the leader.
+	BlockID  *BlockID `protobuf:"bytes,7,opt,name=blockID,proto3" json:"blockID,omitempty"`
+	PolRound int64    `protobuf:"varint,8,opt,name=polRound,proto3" json:"polRound,omitempty"`
 }
 
 func (x *Block) Reset() {
@@ -99,6 +105,137 @@ func (x *Block) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *Block) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *Block) GetBlockID() *BlockID {
+	if x != nil {
+		return x.BlockID
+	}
+	return nil
+}
+
+func (x *Block) GetPolRound() int64 {
+	if x != nil {
+		return x.PolRound
+	}
+	return 0
+}
+
+type PartSetHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total uint32 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Hash  []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *PartSetHeader) Reset() {
+	*x = PartSetHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PartSetHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartSetHeader) ProtoMessage() {}
+
+func (x *PartSetHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartSetHeader.ProtoReflect.Descriptor instead.
+func (*PartSetHeader) Descriptor() ([]byte, []int) {
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PartSetHeader) GetTotal() uint32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PartSetHeader) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+type BlockID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash          []byte         `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	PartSetHeader *PartSetHeader `protobuf:"bytes,2,opt,name=partSetHeader,proto3" json:"partSetHeader,omitempty"`
+}
+
+func (x *BlockID) Reset() {
+	*x = BlockID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockID) ProtoMessage() {}
+
+func (x *BlockID) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockID.ProtoReflect.Descriptor instead.
+func (*BlockID) Descriptor() ([]byte, []int) {
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlockID) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *BlockID) GetPartSetHeader() *PartSetHeader {
+	if x != nil {
+		return x.PartSetHeader
+	}
+	return nil
+}
+
 type CosignerGRPCSignBlockRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -111,7 +248,7 @@ type CosignerGRPCSignBlockRequest struct {
 func (x *CosignerGRPCSignBlockRequest) Reset() {
 	*x = CosignerGRPCSignBlockRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[1]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -124,7 +261,7 @@ func (x *CosignerGRPCSignBlockRequest) String() string {
 func (*CosignerGRPCSignBlockRequest) ProtoMessage() {}
 
 func (x *CosignerGRPCSignBlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[1]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -137,7 +274,7 @@ func (x *CosignerGRPCSignBlockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CosignerGRPCSignBlockRequest.ProtoReflect.Descriptor instead.
 func (*CosignerGRPCSignBlockRequest) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{1}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CosignerGRPCSignBlockRequest) GetChainID() string {
@@ -165,7 +302,7 @@ type CosignerGRPCSignBlockResponse struct {
 func (x *CosignerGRPCSignBlockResponse) Reset() {
 	*x = CosignerGRPCSignBlockResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[2]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -178,7 +315,7 @@ func (x *CosignerGRPCSignBlockResponse) String() string {
 func (*CosignerGRPCSignBlockResponse) ProtoMessage() {}
 
 func (x *CosignerGRPCSignBlockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[2]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -191,7 +328,7 @@ func (x *CosignerGRPCSignBlockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CosignerGRPCSignBlockResponse.ProtoReflect.Descriptor instead.
 func (*CosignerGRPCSignBlockResponse) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{2}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CosignerGRPCSignBlockResponse) GetSignature() []byte {
@@ -216,7 +353,7 @@ type Nonce struct {
 func (x *Nonce) Reset() {
 	*x = Nonce{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[3]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -229,7 +366,7 @@ func (x *Nonce) String() string {
 func (*Nonce) ProtoMessage() {}
 
 func (x *Nonce) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[3]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -242,7 +379,7 @@ func (x *Nonce) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Nonce.ProtoReflect.Descriptor instead.
 func (*Nonce) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{3}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Nonce) GetSourceID() int32 {
@@ -294,7 +431,7 @@ type HRST struct {
 func (x *HRST) Reset() {
 	*x = HRST{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[4]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -307,7 +444,7 @@ func (x *HRST) String() string {
 func (*HRST) ProtoMessage() {}
 
 func (x *HRST) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[4]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -320,7 +457,7 @@ func (x *HRST) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HRST.ProtoReflect.Descriptor instead.
 func (*HRST) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{4}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *HRST) GetHeight() int64 {
@@ -365,7 +502,7 @@ type CosignerGRPCSetNoncesAndSignRequest struct {
 func (x *CosignerGRPCSetNoncesAndSignRequest) Reset() {
 	*x = CosignerGRPCSetNoncesAndSignRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[5]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -378,7 +515,7 @@ func (x *CosignerGRPCSetNoncesAndSignRequest) String() string {
 func (*CosignerGRPCSetNoncesAndSignRequest) ProtoMessage() {}
 
 func (x *CosignerGRPCSetNoncesAndSignRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[5]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +528,7 @@ func (x *CosignerGRPCSetNoncesAndSignRequest) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use CosignerGRPCSetNoncesAndSignRequest.ProtoReflect.Descriptor instead.
 func (*CosignerGRPCSetNoncesAndSignRequest) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{5}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CosignerGRPCSetNoncesAndSignRequest) GetNonces() []*Nonce {
@@ -430,12 +567,13 @@ type CosignerGRPCSetNoncesAndSignResponse struct {
 	NoncePublic []byte `protobuf:"bytes,1,opt,name=noncePublic,proto3" json:"noncePublic,omitempty"`
 	Timestamp   int64  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Signature   []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Hrst        *HRST  `protobuf:"bytes,4,opt,name=hrst,proto3" json:"hrst,omitempty"`
 }
 
 func (x *CosignerGRPCSetNoncesAndSignResponse) Reset() {
 	*x = CosignerGRPCSetNoncesAndSignResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[6]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -448,7 +586,7 @@ func (x *CosignerGRPCSetNoncesAndSignResponse) String() string {
 func (*CosignerGRPCSetNoncesAndSignResponse) ProtoMessage() {}
 
 func (x *CosignerGRPCSetNoncesAndSignResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[6]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,7 +599,7 @@ func (x *CosignerGRPCSetNoncesAndSignResponse) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use CosignerGRPCSetNoncesAndSignResponse.ProtoReflect.Descriptor instead.
 func (*CosignerGRPCSetNoncesAndSignResponse) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{6}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CosignerGRPCSetNoncesAndSignResponse) GetNoncePublic() []byte {
@@ -485,6 +623,13 @@ func (x *CosignerGRPCSetNoncesAndSignResponse) GetSignature() []byte {
 	return nil
 }
 
+func (x *CosignerGRPCSetNoncesAndSignResponse) GetHrst() *HRST {
+	if x != nil {
+		return x.Hrst
+	}
+	return nil
+}
+
 type CosignerGRPCGetNoncesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -497,7 +642,7 @@ type CosignerGRPCGetNoncesRequest struct {
 func (x *CosignerGRPCGetNoncesRequest) Reset() {
 	*x = CosignerGRPCGetNoncesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[7]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -510,7 +655,7 @@ func (x *CosignerGRPCGetNoncesRequest) String() string {
 func (*CosignerGRPCGetNoncesRequest) ProtoMessage() {}
 
 func (x *CosignerGRPCGetNoncesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[7]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,7 +668,7 @@ func (x *CosignerGRPCGetNoncesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CosignerGRPCGetNoncesRequest.ProtoReflect.Descriptor instead.
 func (*CosignerGRPCGetNoncesRequest) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{7}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CosignerGRPCGetNoncesRequest) GetHrst() *HRST {
@@ -551,7 +696,7 @@ type CosignerGRPCGetNoncesResponse struct {
 func (x *CosignerGRPCGetNoncesResponse) Reset() {
 	*x = CosignerGRPCGetNoncesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[8]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -564,7 +709,7 @@ func (x *CosignerGRPCGetNoncesResponse) String() string {
 func (*CosignerGRPCGetNoncesResponse) ProtoMessage() {}
 
 func (x *CosignerGRPCGetNoncesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[8]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -577,7 +722,7 @@ func (x *CosignerGRPCGetNoncesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CosignerGRPCGetNoncesResponse.ProtoReflect.Descriptor instead.
 func (*CosignerGRPCGetNoncesResponse) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{8}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CosignerGRPCGetNoncesResponse) GetNonces() []*Nonce {
@@ -598,7 +743,7 @@ type CosignerGRPCTransferLeadershipRequest struct {
 func (x *CosignerGRPCTransferLeadershipRequest) Reset() {
 	*x = CosignerGRPCTransferLeadershipRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[9]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -611,7 +756,7 @@ func (x *CosignerGRPCTransferLeadershipRequest) String() string {
 func (*CosignerGRPCTransferLeadershipRequest) ProtoMessage() {}
 
 func (x *CosignerGRPCTransferLeadershipRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[9]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -624,7 +769,7 @@ func (x *CosignerGRPCTransferLeadershipRequest) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use CosignerGRPCTransferLeadershipRequest.ProtoReflect.Descriptor instead.
 func (*CosignerGRPCTransferLeadershipRequest) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{9}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CosignerGRPCTransferLeadershipRequest) GetLeaderID() string {
@@ -646,7 +791,7 @@ type CosignerGRPCTransferLeadershipResponse struct {
 func (x *CosignerGRPCTransferLeadershipResponse) Reset() {
 	*x = CosignerGRPCTransferLeadershipResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[10]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -659,7 +804,7 @@ func (x *CosignerGRPCTransferLeadershipResponse) String() string {
 func (*CosignerGRPCTransferLeadershipResponse) ProtoMessage() {}
 
 func (x *CosignerGRPCTransferLeadershipResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[10]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -672,7 +817,7 @@ func (x *CosignerGRPCTransferLeadershipResponse) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use CosignerGRPCTransferLeadershipResponse.ProtoReflect.Descriptor instead.
 func (*CosignerGRPCTransferLeadershipResponse) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{10}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CosignerGRPCTransferLeadershipResponse) GetLeaderID() string {
@@ -698,7 +843,7 @@ type CosignerGRPCGetLeaderRequest struct {
 func (x *CosignerGRPCGetLeaderRequest) Reset() {
 	*x = CosignerGRPCGetLeaderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[11]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -711,7 +856,7 @@ func (x *CosignerGRPCGetLeaderRequest) String() string {
 func (*CosignerGRPCGetLeaderRequest) ProtoMessage() {}
 
 func (x *CosignerGRPCGetLeaderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[11]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -724,7 +869,7 @@ func (x *CosignerGRPCGetLeaderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CosignerGRPCGetLeaderRequest.ProtoReflect.Descriptor instead.
 func (*CosignerGRPCGetLeaderRequest) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{11}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{13}
 }
 
 type CosignerGRPCGetLeaderResponse struct {
@@ -738,7 +883,7 @@ type CosignerGRPCGetLeaderResponse struct {
 func (x *CosignerGRPCGetLeaderResponse) Reset() {
 	*x = CosignerGRPCGetLeaderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[12]
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -751,7 +896,7 @@ func (x *CosignerGRPCGetLeaderResponse) String() string {
 func (*CosignerGRPCGetLeaderResponse) ProtoMessage() {}
 
 func (x *CosignerGRPCGetLeaderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[12]
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,7 +909,7 @@ func (x *CosignerGRPCGetLeaderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CosignerGRPCGetLeaderResponse.ProtoReflect.Descriptor instead.
 func (*CosignerGRPCGetLeaderResponse) Descriptor() ([]byte, []int) {
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{12}
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CosignerGRPCGetLeaderResponse) GetLeader() string {
@@ -774,202 +919,691 @@ func (x *CosignerGRPCGetLeaderResponse) GetLeader() string {
 	return ""
 }
 
-var File_signer_proto_cosigner_grpc_server_proto protoreflect.FileDescriptor
+type CosignerGRPCGetStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
 
-var file_signer_proto_cosigner_grpc_server_proto_rawDesc = []byte{
-	0x0a, 0x27, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
-	0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0x85, 0x01, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65,
-	0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x1c, 0x0a, 0x09,
-	0x73, 0x69, 0x67, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x09, 0x73, 0x69, 0x67, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x5c, 0x0a, 0x1c, 0x43, 0x6f, 0x73, 0x69,
-	0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x49, 0x44, 0x12, 0x22, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
-	0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x3d, 0x0a, 0x1d, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e,
-	0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
-	0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x95, 0x01, 0x0a, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12,
-	0x1a, 0x0a, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x64,
-	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
-	0x44, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61,
-	0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x66, 0x0a,
-	0x04, 0x48, 0x52, 0x53, 0x54, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x72, 0x6f,
-	0x75, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xa4, 0x01, 0x0a, 0x23, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e,
-	0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x41,
-	0x6e, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a,
-	0x06, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x06, 0x6e, 0x6f, 0x6e,
-	0x63, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x04, 0x68, 0x72, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x52, 0x53, 0x54, 0x52, 0x04,
-	0x68, 0x72, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x42, 0x79, 0x74, 0x65,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x42, 0x79, 0x74,
-	0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x22, 0x84, 0x01, 0x0a,
-	0x24, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x65, 0x74,
-	0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x41, 0x6e, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x50, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x6e, 0x6f, 0x6e, 0x63,
-	0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x22, 0x59, 0x0a, 0x1c, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47,
-	0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x68, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x52, 0x53, 0x54, 0x52, 0x04,
-	0x68, 0x72, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x22, 0x45,
-	0x0a, 0x1d, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65,
-	0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x24, 0x0a, 0x06, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x06, 0x6e,
-	0x6f, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x43, 0x0a, 0x25, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65,
-	0x72, 0x47, 0x52, 0x50, 0x43, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
-	0x0a, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x44, 0x22, 0x6a, 0x0a, 0x26, 0x43, 0x6f,
-	0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
-	0x65, 0x72, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x44,
-	0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x41,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x1e, 0x0a, 0x1c, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e,
-	0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x37, 0x0a, 0x1d, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e,
-	0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x32,
-	0x80, 0x04, 0x0a, 0x0c, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43,
-	0x12, 0x58, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x23, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52,
-	0x50, 0x43, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67,
-	0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6d, 0x0a, 0x10, 0x53, 0x65,
-	0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x41, 0x6e, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x2a,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47,
-	0x52, 0x50, 0x43, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x41, 0x6e, 0x64, 0x53,
-	0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53,
-	0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x41, 0x6e, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x09, 0x47, 0x65, 0x74,
-	0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
-	0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x4e, 0x6f,
-	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43,
-	0x47, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x73, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x66, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4c,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
-	0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47,
-	0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x73, 0x74, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x6c, 0x6f, 0x76, 0x65, 0x2d, 0x76, 0x65, 0x6e,
-	0x74, 0x75, 0x72, 0x65, 0x73, 0x2f, 0x68, 0x6f, 0x72, 0x63, 0x72, 0x75, 0x78, 0x2f, 0x73, 0x69,
-	0x67, 0x6e, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+func (x *CosignerGRPCGetStatusRequest) Reset() {
+	*x = CosignerGRPCGetStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var (
-	file_signer_proto_cosigner_grpc_server_proto_rawDescOnce sync.Once
-	file_signer_proto_cosigner_grpc_server_proto_rawDescData = file_signer_proto_cosigner_grpc_server_proto_rawDesc
-)
+func (x *CosignerGRPCGetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP() []byte {
-	file_signer_proto_cosigner_grpc_server_proto_rawDescOnce.Do(func() {
-		file_signer_proto_cosigner_grpc_server_proto_rawDescData = protoimpl.X.CompressGZIP(file_signer_proto_cosigner_grpc_server_proto_rawDescData)
-	})
-	return file_signer_proto_cosigner_grpc_server_proto_rawDescData
+func (*CosignerGRPCGetStatusRequest) ProtoMessage() {}
+
+func (x *CosignerGRPCGetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_signer_proto_cosigner_grpc_server_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
-var file_signer_proto_cosigner_grpc_server_proto_goTypes = []interface{}{
-	(*Block)(nil),                                  // 0: proto.Block
-	(*CosignerGRPCSignBlockRequest)(nil),           // 1: proto.CosignerGRPCSignBlockRequest
-	(*CosignerGRPCSignBlockResponse)(nil),          // 2: proto.CosignerGRPCSignBlockResponse
-	(*Nonce)(nil),                                  // 3: proto.Nonce
-	(*HRST)(nil),                                   // 4: proto.HRST
-	(*CosignerGRPCSetNoncesAndSignRequest)(nil),    // 5: proto.CosignerGRPCSetNoncesAndSignRequest
-	(*CosignerGRPCSetNoncesAndSignResponse)(nil),   // 6: proto.CosignerGRPCSetNoncesAndSignResponse
-	(*CosignerGRPCGetNoncesRequest)(nil),           // 7: proto.CosignerGRPCGetNoncesRequest
-	(*CosignerGRPCGetNoncesResponse)(nil),          // 8: proto.CosignerGRPCGetNoncesResponse
-	(*CosignerGRPCTransferLeadershipRequest)(nil),  // 9: proto.CosignerGRPCTransferLeadershipRequest
-	(*CosignerGRPCTransferLeadershipResponse)(nil), // 10: proto.CosignerGRPCTransferLeadershipResponse
-	(*CosignerGRPCGetLeaderRequest)(nil),           // 11: proto.CosignerGRPCGetLeaderRequest
-	(*CosignerGRPCGetLeaderResponse)(nil),          // 12: proto.CosignerGRPCGetLeaderResponse
+// Deprecated: Use CosignerGRPCGetStatusRequest.ProtoReflect.Descriptor instead.
+func (*CosignerGRPCGetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{15}
 }
-var file_signer_proto_cosigner_grpc_server_proto_depIdxs = []int32{
-	0,  // 0: proto.CosignerGRPCSignBlockRequest.block:type_name -> proto.Block
-	3,  // 1: proto.CosignerGRPCSetNoncesAndSignRequest.nonces:type_name -> proto.Nonce
-	4,  // 2: proto.CosignerGRPCSetNoncesAndSignRequest.hrst:type_name -> proto.HRST
-	4,  // 3: proto.CosignerGRPCGetNoncesRequest.hrst:type_name -> proto.HRST
-	3,  // 4: proto.CosignerGRPCGetNoncesResponse.nonces:type_name -> proto.Nonce
-	1,  // 5: proto.CosignerGRPC.SignBlock:input_type -> proto.CosignerGRPCSignBlockRequest
-	5,  // 6: proto.CosignerGRPC.SetNoncesAndSign:input_type -> proto.CosignerGRPCSetNoncesAndSignRequest
-	7,  // 7: proto.CosignerGRPC.GetNonces:input_type -> proto.CosignerGRPCGetNoncesRequest
-	9,  // 8: proto.CosignerGRPC.TransferLeadership:input_type -> proto.CosignerGRPCTransferLeadershipRequest
-	11, // 9: proto.CosignerGRPC.GetLeader:input_type -> proto.CosignerGRPCGetLeaderRequest
-	2,  // 10: proto.CosignerGRPC.SignBlock:output_type -> proto.CosignerGRPCSignBlockResponse
-	6,  // 11: proto.CosignerGRPC.SetNoncesAndSign:output_type -> proto.CosignerGRPCSetNoncesAndSignResponse
-	8,  // 12: proto.CosignerGRPC.GetNonces:output_type -> proto.CosignerGRPCGetNoncesResponse
-	10, // 13: proto.CosignerGRPC.TransferLeadership:output_type -> proto.CosignerGRPCTransferLeadershipResponse
-	12, // 14: proto.CosignerGRPC.GetLeader:output_type -> proto.CosignerGRPCGetLeaderResponse
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+
+type CosignerGRPCGetStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StateDirFreeBytes uint64 `protobuf:"varint,1,opt,name=stateDirFreeBytes,proto3" json:"stateDirFreeBytes,omitempty"`
+	// ready is false if the free space of the state directory is below the configured minimum.
+	Ready bool `protobuf:"varint,2,opt,name=ready,proto3" json:"ready,omitempty"`
+	// time is the wall-clock time of the cosigner in unix nanoseconds, to detect clock skew between cosigners.
+	Time int64 `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
 }
 
-func init() { file_signer_proto_cosigner_grpc_server_proto_init() }
-func file_signer_proto_cosigner_grpc_server_proto_init() {
-	if File_signer_proto_cosigner_grpc_server_proto != nil {
-		return
+func (x *CosignerGRPCGetStatusResponse) Reset() {
+	*x = CosignerGRPCGetStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_signer_proto_cosigner_grpc_server_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Block); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+}
+
+func (x *CosignerGRPCGetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CosignerGRPCGetStatusResponse) ProtoMessage() {}
+
+func (x *CosignerGRPCGetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_signer_proto_cosigner_grpc_server_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CosignerGRPCSignBlockRequest); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CosignerGRPCGetStatusResponse.ProtoReflect.Descriptor instead.
+func (*CosignerGRPCGetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CosignerGRPCGetStatusResponse) GetStateDirFreeBytes() uint64 {
+	if x != nil {
+		return x.StateDirFreeBytes
+	}
+	return 0
+}
+
+func (x *CosignerGRPCGetStatusResponse) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *CosignerGRPCGetStatusResponse) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type CosignerGRPCDrillKillRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// duration in nanoseconds that the cosigner stops participating for.
+	Duration int64 `protobuf:"varint,1,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *CosignerGRPCDrillKillRequest) Reset() {
+	*x = CosignerGRPCDrillKillRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CosignerGRPCDrillKillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CosignerGRPCDrillKillRequest) ProtoMessage() {}
+
+func (x *CosignerGRPCDrillKillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CosignerGRPCDrillKillRequest.ProtoReflect.Descriptor instead.
+func (*CosignerGRPCDrillKillRequest) Descriptor() ([]byte, []int) {
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CosignerGRPCDrillKillRequest) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type CosignerGRPCDrillKillResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// until is when the cosigner recovers, in unix nanoseconds.
+	Until int64 `protobuf:"varint,1,opt,name=until,proto3" json:"until,omitempty"`
+}
+
+func (x *CosignerGRPCDrillKillResponse) Reset() {
+	*x = CosignerGRPCDrillKillResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CosignerGRPCDrillKillResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CosignerGRPCDrillKillResponse) ProtoMessage() {}
+
+func (x *CosignerGRPCDrillKillResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CosignerGRPCDrillKillResponse.ProtoReflect.Descriptor instead.
+func (*CosignerGRPCDrillKillResponse) Descriptor() ([]byte, []int) {
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CosignerGRPCDrillKillResponse) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+type CosignerGRPCGetSignStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainID string `protobuf:"bytes,1,opt,name=chainID,proto3" json:"chainID,omitempty"`
+}
+
+func (x *CosignerGRPCGetSignStateRequest) Reset() {
+	*x = CosignerGRPCGetSignStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CosignerGRPCGetSignStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CosignerGRPCGetSignStateRequest) ProtoMessage() {}
+
+func (x *CosignerGRPCGetSignStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CosignerGRPCGetSignStateRequest.ProtoReflect.Descriptor instead.
+func (*CosignerGRPCGetSignStateRequest) Descriptor() ([]byte, []int) {
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CosignerGRPCGetSignStateRequest) GetChainID() string {
+	if x != nil {
+		return x.ChainID
+	}
+	return ""
+}
+
+type CosignerGRPCGetSignStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int64 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Step   int32 `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+}
+
+func (x *CosignerGRPCGetSignStateResponse) Reset() {
+	*x = CosignerGRPCGetSignStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CosignerGRPCGetSignStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CosignerGRPCGetSignStateResponse) ProtoMessage() {}
+
+func (x *CosignerGRPCGetSignStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CosignerGRPCGetSignStateResponse.ProtoReflect.Descriptor instead.
+func (*CosignerGRPCGetSignStateResponse) Descriptor() ([]byte, []int) {
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CosignerGRPCGetSignStateResponse) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *CosignerGRPCGetSignStateResponse) GetRound() int64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *CosignerGRPCGetSignStateResponse) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+type CosignerGRPCSetPausedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// paused is true to stop signing, false to resume.
+	Paused bool `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+}
+
+func (x *CosignerGRPCSetPausedRequest) Reset() {
+	*x = CosignerGRPCSetPausedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CosignerGRPCSetPausedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CosignerGRPCSetPausedRequest) ProtoMessage() {}
+
+func (x *CosignerGRPCSetPausedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CosignerGRPCSetPausedRequest.ProtoReflect.Descriptor instead.
+func (*CosignerGRPCSetPausedRequest) Descriptor() ([]byte, []int) {
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CosignerGRPCSetPausedRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type CosignerGRPCSetPausedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CosignerGRPCSetPausedResponse) Reset() {
+	*x = CosignerGRPCSetPausedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CosignerGRPCSetPausedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CosignerGRPCSetPausedResponse) ProtoMessage() {}
+
+func (x *CosignerGRPCSetPausedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_cosigner_grpc_server_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CosignerGRPCSetPausedResponse.ProtoReflect.Descriptor instead.
+func (*CosignerGRPCSetPausedResponse) Descriptor() ([]byte, []int) {
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP(), []int{22}
+}
+
+var File_signer_proto_cosigner_grpc_server_proto protoreflect.FileDescriptor
+
+var file_signer_proto_cosigner_grpc_server_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
+	0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xe7, 0x01, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x70, 0x6f, 0x6c, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x39, 0x0a, 0x0d, 0x50, 0x61,
+	0x72, 0x74, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x59, 0x0a, 0x07, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44,
+	0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x12, 0x3a, 0x0a, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x53, 0x65, 0x74, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x22, 0x5c, 0x0a, 0x1c, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43,
+	0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x05, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x3d,
+	0x0a, 0x1d, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x69,
+	0x67, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x95, 0x01,
+	0x0a, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62,
+	0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x66, 0x0a, 0x04, 0x48, 0x52, 0x53, 0x54, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x74, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xa4, 0x01,
+	0x0a, 0x23, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x65,
+	0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x41, 0x6e, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f,
+	0x6e, 0x63, 0x65, 0x52, 0x06, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x04, 0x68,
+	0x72, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x48, 0x52, 0x53, 0x54, 0x52, 0x04, 0x68, 0x72, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x49, 0x44, 0x22, 0xa5, 0x01, 0x0a, 0x24, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x41, 0x6e,
+	0x64, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0b, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x68,
+	0x72, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x48, 0x52, 0x53, 0x54, 0x52, 0x04, 0x68, 0x72, 0x73, 0x74, 0x22, 0x59, 0x0a, 0x1c,
+	0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x4e,
+	0x6f, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x04,
+	0x68, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x48, 0x52, 0x53, 0x54, 0x52, 0x04, 0x68, 0x72, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x22, 0x45, 0x0a, 0x1d, 0x43, 0x6f, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x06, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x43,
+	0x0a, 0x25, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x49, 0x44, 0x22, 0x6a, 0x0a, 0x26, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47,
+	0x52, 0x50, 0x43, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
+	0x1e, 0x0a, 0x1c, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47,
+	0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x37, 0x0a, 0x1d, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47,
+	0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x22, 0x1e, 0x0a, 0x1c, 0x43, 0x6f, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x77, 0x0a, 0x1d, 0x43, 0x6f, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x44, 0x69, 0x72, 0x46, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x73, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x46, 0x72,
+	0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x22, 0x3a, 0x0a, 0x1c, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50,
+	0x43, 0x44, 0x72, 0x69, 0x6c, 0x6c, 0x4b, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x35, 0x0a,
+	0x1d, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x44, 0x72, 0x69,
+	0x6c, 0x6c, 0x4b, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75,
+	0x6e, 0x74, 0x69, 0x6c, 0x22, 0x3b, 0x0a, 0x1f, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49,
+	0x44, 0x22, 0x64, 0x0a, 0x20, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50,
+	0x43, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x22, 0x36, 0x0a, 0x1c, 0x43, 0x6f, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x65, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x22,
+	0x1f, 0x0a, 0x1d, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53,
+	0x65, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x32, 0xf1, 0x06, 0x0a, 0x0c, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50,
+	0x43, 0x12, 0x58, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x23,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47,
+	0x52, 0x50, 0x43, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6d, 0x0a, 0x10, 0x53,
+	0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x41, 0x6e, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x12,
+	0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x47, 0x52, 0x50, 0x43, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x41, 0x6e, 0x64,
+	0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43,
+	0x53, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x41, 0x6e, 0x64, 0x53, 0x69, 0x67, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x4e,
+	0x6f, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50,
+	0x43, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x73, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x2c, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69,
+	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x09, 0x47, 0x65, 0x74,
+	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x4c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43,
+	0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a,
+	0x09, 0x44, 0x72, 0x69, 0x6c, 0x6c, 0x4b, 0x69, 0x6c, 0x6c, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x44,
+	0x72, 0x69, 0x6c, 0x6c, 0x4b, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x47, 0x52, 0x50, 0x43, 0x44, 0x72, 0x69, 0x6c, 0x6c, 0x4b, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53, 0x69,
+	0x67, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x53,
+	0x69, 0x67, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x47, 0x52, 0x50, 0x43, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x09, 0x53, 0x65,
+	0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x65, 0x74, 0x50,
+	0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x47, 0x52, 0x50,
+	0x43, 0x53, 0x65, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x6c, 0x6f, 0x76, 0x65, 0x2d, 0x76,
+	0x65, 0x6e, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2f, 0x68, 0x6f, 0x72, 0x63, 0x72, 0x75, 0x78, 0x2f,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_signer_proto_cosigner_grpc_server_proto_rawDescOnce sync.Once
+	file_signer_proto_cosigner_grpc_server_proto_rawDescData = file_signer_proto_cosigner_grpc_server_proto_rawDesc
+)
+
+func file_signer_proto_cosigner_grpc_server_proto_rawDescGZIP() []byte {
+	file_signer_proto_cosigner_grpc_server_proto_rawDescOnce.Do(func() {
+		file_signer_proto_cosigner_grpc_server_proto_rawDescData = protoimpl.X.CompressGZIP(file_signer_proto_cosigner_grpc_server_proto_rawDescData)
+	})
+	return file_signer_proto_cosigner_grpc_server_proto_rawDescData
+}
+
+var file_signer_proto_cosigner_grpc_server_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_signer_proto_cosigner_grpc_server_proto_goTypes = []interface{}{
+	(*Block)(nil),                                  // 0: proto.Block
+	(*PartSetHeader)(nil),                          // 1: proto.PartSetHeader
+	(*BlockID)(nil),                                // 2: proto.BlockID
+	(*CosignerGRPCSignBlockRequest)(nil),           // 3: proto.CosignerGRPCSignBlockRequest
+	(*CosignerGRPCSignBlockResponse)(nil),          // 4: proto.CosignerGRPCSignBlockResponse
+	(*Nonce)(nil),                                  // 5: proto.Nonce
+	(*HRST)(nil),                                   // 6: proto.HRST
+	(*CosignerGRPCSetNoncesAndSignRequest)(nil),    // 7: proto.CosignerGRPCSetNoncesAndSignRequest
+	(*CosignerGRPCSetNoncesAndSignResponse)(nil),   // 8: proto.CosignerGRPCSetNoncesAndSignResponse
+	(*CosignerGRPCGetNoncesRequest)(nil),           // 9: proto.CosignerGRPCGetNoncesRequest
+	(*CosignerGRPCGetNoncesResponse)(nil),          // 10: proto.CosignerGRPCGetNoncesResponse
+	(*CosignerGRPCTransferLeadershipRequest)(nil),  // 11: proto.CosignerGRPCTransferLeadershipRequest
+	(*CosignerGRPCTransferLeadershipResponse)(nil), // 12: proto.CosignerGRPCTransferLeadershipResponse
+	(*CosignerGRPCGetLeaderRequest)(nil),           // 13: proto.CosignerGRPCGetLeaderRequest
+	(*CosignerGRPCGetLeaderResponse)(nil),          // 14: proto.CosignerGRPCGetLeaderResponse
+	(*CosignerGRPCGetStatusRequest)(nil),           // 15: proto.CosignerGRPCGetStatusRequest
+	(*CosignerGRPCGetStatusResponse)(nil),          // 16: proto.CosignerGRPCGetStatusResponse
+	(*CosignerGRPCDrillKillRequest)(nil),           // 17: proto.CosignerGRPCDrillKillRequest
+	(*CosignerGRPCDrillKillResponse)(nil),          // 18: proto.CosignerGRPCDrillKillResponse
+	(*CosignerGRPCGetSignStateRequest)(nil),        // 19: proto.CosignerGRPCGetSignStateRequest
+	(*CosignerGRPCGetSignStateResponse)(nil),       // 20: proto.CosignerGRPCGetSignStateResponse
+	(*CosignerGRPCSetPausedRequest)(nil),           // 21: proto.CosignerGRPCSetPausedRequest
+	(*CosignerGRPCSetPausedResponse)(nil),          // 22: proto.CosignerGRPCSetPausedResponse
+}
+var file_signer_proto_cosigner_grpc_server_proto_depIdxs = []int32{
+	2,  // 0: proto.Block.blockID:type_name -> proto.BlockID
+	1,  // 1: proto.BlockID.partSetHeader:type_name -> proto.PartSetHeader
+	0,  // 2: proto.CosignerGRPCSignBlockRequest.block:type_name -> proto.Block
+	5,  // 3: proto.CosignerGRPCSetNoncesAndSignRequest.nonces:type_name -> proto.Nonce
+	6,  // 4: proto.CosignerGRPCSetNoncesAndSignRequest.hrst:type_name -> proto.HRST
+	6,  // 5: proto.CosignerGRPCSetNoncesAndSignResponse.hrst:type_name -> proto.HRST
+	6,  // 6: proto.CosignerGRPCGetNoncesRequest.hrst:type_name -> proto.HRST
+	5,  // 7: proto.CosignerGRPCGetNoncesResponse.nonces:type_name -> proto.Nonce
+	3,  // 8: proto.CosignerGRPC.SignBlock:input_type -> proto.CosignerGRPCSignBlockRequest
+	7,  // 9: proto.CosignerGRPC.SetNoncesAndSign:input_type -> proto.CosignerGRPCSetNoncesAndSignRequest
+	9,  // 10: proto.CosignerGRPC.GetNonces:input_type -> proto.CosignerGRPCGetNoncesRequest
+	11, // 11: proto.CosignerGRPC.TransferLeadership:input_type -> proto.CosignerGRPCTransferLeadershipRequest
+	13, // 12: proto.CosignerGRPC.GetLeader:input_type -> proto.CosignerGRPCGetLeaderRequest
+	15, // 13: proto.CosignerGRPC.GetStatus:input_type -> proto.CosignerGRPCGetStatusRequest
+	17, // 14: proto.CosignerGRPC.DrillKill:input_type -> proto.CosignerGRPCDrillKillRequest
+	19, // 15: proto.CosignerGRPC.GetSignState:input_type -> proto.CosignerGRPCGetSignStateRequest
+	21, // 16: proto.CosignerGRPC.SetPaused:input_type -> proto.CosignerGRPCSetPausedRequest
+	4,  // 17: proto.CosignerGRPC.SignBlock:output_type -> proto.CosignerGRPCSignBlockResponse
+	8,  // 18: proto.CosignerGRPC.SetNoncesAndSign:output_type -> proto.CosignerGRPCSetNoncesAndSignResponse
+	10, // 19: proto.CosignerGRPC.GetNonces:output_type -> proto.CosignerGRPCGetNoncesResponse
+	12, // 20: proto.CosignerGRPC.TransferLeadership:output_type -> proto.CosignerGRPCTransferLeadershipResponse
+	14, // 21: proto.CosignerGRPC.GetLeader:output_type -> proto.CosignerGRPCGetLeaderResponse
+	16, // 22: proto.CosignerGRPC.GetStatus:output_type -> proto.CosignerGRPCGetStatusResponse
+	18, // 23: proto.CosignerGRPC.DrillKill:output_type -> proto.CosignerGRPCDrillKillResponse
+	20, // 24: proto.CosignerGRPC.GetSignState:output_type -> proto.CosignerGRPCGetSignStateResponse
+	22, // 25: proto.CosignerGRPC.SetPaused:output_type -> proto.CosignerGRPCSetPausedResponse
+	17, // [17:26] is the sub-list for method output_type
+	8,  // [8:17] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_signer_proto_cosigner_grpc_server_proto_init() }
+func file_signer_proto_cosigner_grpc_server_proto_init() {
+	if File_signer_proto_cosigner_grpc_server_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Block); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PartSetHeader); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -981,7 +1615,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CosignerGRPCSignBlockResponse); i {
+			switch v := v.(*BlockID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -993,7 +1627,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Nonce); i {
+			switch v := v.(*CosignerGRPCSignBlockRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1005,7 +1639,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HRST); i {
+			switch v := v.(*CosignerGRPCSignBlockResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1017,7 +1651,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CosignerGRPCSetNoncesAndSignRequest); i {
+			switch v := v.(*Nonce); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1029,7 +1663,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CosignerGRPCSetNoncesAndSignResponse); i {
+			switch v := v.(*HRST); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1041,7 +1675,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CosignerGRPCGetNoncesRequest); i {
+			switch v := v.(*CosignerGRPCSetNoncesAndSignRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1053,7 +1687,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CosignerGRPCGetNoncesResponse); i {
+			switch v := v.(*CosignerGRPCSetNoncesAndSignResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1065,7 +1699,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CosignerGRPCTransferLeadershipRequest); i {
+			switch v := v.(*CosignerGRPCGetNoncesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1077,7 +1711,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CosignerGRPCTransferLeadershipResponse); i {
+			switch v := v.(*CosignerGRPCGetNoncesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1089,7 +1723,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CosignerGRPCGetLeaderRequest); i {
+			switch v := v.(*CosignerGRPCTransferLeadershipRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1101,6 +1735,30 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			}
 		}
 		file_signer_proto_cosigner_grpc_server_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CosignerGRPCTransferLeadershipResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CosignerGRPCGetLeaderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CosignerGRPCGetLeaderResponse); i {
 			case 0:
 				return &v.state
@@ -1112,6 +1770,102 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 				return nil
 			}
 		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CosignerGRPCGetStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CosignerGRPCGetStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CosignerGRPCDrillKillRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CosignerGRPCDrillKillResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CosignerGRPCGetSignStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CosignerGRPCGetSignStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CosignerGRPCSetPausedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_cosigner_grpc_server_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CosignerGRPCSetPausedResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1119,7 +1873,7 @@ func file_signer_proto_cosigner_grpc_server_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_signer_proto_cosigner_grpc_server_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,6 +27,10 @@ type CosignerGRPCClient interface {
 	GetNonces(ctx context.Context, in *CosignerGRPCGetNoncesRequest, opts ...grpc.CallOption) (*CosignerGRPCGetNoncesResponse, error)
 	TransferLeadership(ctx context.Context, in *CosignerGRPCTransferLeadershipRequest, opts ...grpc.CallOption) (*CosignerGRPCTransferLeadershipResponse, error)
 	GetLeader(ctx context.Context, in *CosignerGRPCGetLeaderRequest, opts ...grpc.CallOption) (*CosignerGRPCGetLeaderResponse, error)
+	GetStatus(ctx context.Context, in *CosignerGRPCGetStatusRequest, opts ...grpc.CallOption) (*CosignerGRPCGetStatusResponse, error)
+	DrillKill(ctx context.Context, in *CosignerGRPCDrillKillRequest, opts ...grpc.CallOption) (*CosignerGRPCDrillKillResponse, error)
+	GetSignState(ctx context.Context, in *CosignerGRPCGetSignStateRequest, opts ...grpc.CallOption) (*CosignerGRPCGetSignStateResponse, error)
+	SetPaused(ctx context.Context, in *CosignerGRPCSetPausedRequest, opts ...grpc.CallOption) (*CosignerGRPCSetPausedResponse, error)
 }
 
 type cosignerGRPCClient struct {
@@ -82,6 +86,42 @@ func (c *cosignerGRPCClient) GetLeader(ctx context.Context, in *CosignerGRPCGetL
 	return out, nil
 }
 
+func (c *cosignerGRPCClient) GetStatus(ctx context.Context, in *CosignerGRPCGetStatusRequest, opts ...grpc.CallOption) (*CosignerGRPCGetStatusResponse, error) {
+	out := new(CosignerGRPCGetStatusResponse)
+	err := c.cc.Invoke(ctx, "/proto.CosignerGRPC/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cosignerGRPCClient) DrillKill(ctx context.Context, in *CosignerGRPCDrillKillRequest, opts ...grpc.CallOption) (*CosignerGRPCDrillKillResponse, error) {
+	out := new(CosignerGRPCDrillKillResponse)
+	err := c.cc.Invoke(ctx, "/proto.CosignerGRPC/DrillKill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cosignerGRPCClient) GetSignState(ctx context.Context, in *CosignerGRPCGetSignStateRequest, opts ...grpc.CallOption) (*CosignerGRPCGetSignStateResponse, error) {
+	out := new(CosignerGRPCGetSignStateResponse)
+	err := c.cc.Invoke(ctx, "/proto.CosignerGRPC/GetSignState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cosignerGRPCClient) SetPaused(ctx context.Context, in *CosignerGRPCSetPausedRequest, opts ...grpc.CallOption) (*CosignerGRPCSetPausedResponse, error) {
+	out := new(CosignerGRPCSetPausedResponse)
+	err := c.cc.Invoke(ctx, "/proto.CosignerGRPC/SetPaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CosignerGRPCServer is the server API for CosignerGRPC service.
 // All implementations must embed UnimplementedCosignerGRPCServer
 // for forward compatibility
@@ -91,6 +131,10 @@ type CosignerGRPCServer interface {
 	GetNonces(context.Context, *CosignerGRPCGetNoncesRequest) (*CosignerGRPCGetNoncesResponse, error)
 	TransferLeadership(context.Context, *CosignerGRPCTransferLeadershipRequest) (*CosignerGRPCTransferLeadershipResponse, error)
 	GetLeader(context.Context, *CosignerGRPCGetLeaderRequest) (*CosignerGRPCGetLeaderResponse, error)
+	GetStatus(context.Context, *CosignerGRPCGetStatusRequest) (*CosignerGRPCGetStatusResponse, error)
+	DrillKill(context.Context, *CosignerGRPCDrillKillRequest) (*CosignerGRPCDrillKillResponse, error)
+	GetSignState(context.Context, *CosignerGRPCGetSignStateRequest) (*CosignerGRPCGetSignStateResponse, error)
+	SetPaused(context.Context, *CosignerGRPCSetPausedRequest) (*CosignerGRPCSetPausedResponse, error)
 	mustEmbedUnimplementedCosignerGRPCServer()
 }
 
@@ -113,6 +157,18 @@ func (UnimplementedCosignerGRPCServer) TransferLeadership(context.Context, *Cosi
 func (UnimplementedCosignerGRPCServer) GetLeader(context.Context, *CosignerGRPCGetLeaderRequest) (*CosignerGRPCGetLeaderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLeader not implemented")
 }
+func (UnimplementedCosignerGRPCServer) GetStatus(context.Context, *CosignerGRPCGetStatusRequest) (*CosignerGRPCGetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedCosignerGRPCServer) DrillKill(context.Context, *CosignerGRPCDrillKillRequest) (*CosignerGRPCDrillKillResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrillKill not implemented")
+}
+func (UnimplementedCosignerGRPCServer) GetSignState(context.Context, *CosignerGRPCGetSignStateRequest) (*CosignerGRPCGetSignStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSignState not implemented")
+}
+func (UnimplementedCosignerGRPCServer) SetPaused(context.Context, *CosignerGRPCSetPausedRequest) (*CosignerGRPCSetPausedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPaused not implemented")
+}
 func (UnimplementedCosignerGRPCServer) mustEmbedUnimplementedCosignerGRPCServer() {}
 
 // UnsafeCosignerGRPCServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +272,78 @@ func _CosignerGRPC_GetLeader_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CosignerGRPC_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CosignerGRPCGetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CosignerGRPCServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.CosignerGRPC/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CosignerGRPCServer).GetStatus(ctx, req.(*CosignerGRPCGetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CosignerGRPC_DrillKill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CosignerGRPCDrillKillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CosignerGRPCServer).DrillKill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.CosignerGRPC/DrillKill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CosignerGRPCServer).DrillKill(ctx, req.(*CosignerGRPCDrillKillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CosignerGRPC_GetSignState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CosignerGRPCGetSignStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CosignerGRPCServer).GetSignState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.CosignerGRPC/GetSignState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CosignerGRPCServer).GetSignState(ctx, req.(*CosignerGRPCGetSignStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CosignerGRPC_SetPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CosignerGRPCSetPausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CosignerGRPCServer).SetPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.CosignerGRPC/SetPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CosignerGRPCServer).SetPaused(ctx, req.(*CosignerGRPCSetPausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CosignerGRPC_ServiceDesc is the grpc.ServiceDesc for CosignerGRPC service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,6 +371,22 @@ var CosignerGRPC_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLeader",
 			Handler:    _CosignerGRPC_GetLeader_Handler,
 		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _CosignerGRPC_GetStatus_Handler,
+		},
+		{
+			MethodName: "DrillKill",
+			Handler:    _CosignerGRPC_DrillKill_Handler,
+		},
+		{
+			MethodName: "GetSignState",
+			Handler:    _CosignerGRPC_GetSignState_Handler,
+		},
+		{
+			MethodName: "SetPaused",
+			Handler:    _CosignerGRPC_SetPaused_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "signer/proto/cosigner_grpc_server.proto",
@@ -0,0 +1,224 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cometrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+)
+
+const (
+	// protocolVersionCheckInterval is how long a verified block protocol version is trusted
+	// while the chain nodes cannot be queried.
+	protocolVersionCheckInterval = time.Minute
+
+	// protocolVersionRefreshInterval is how often the chain nodes are queried in the background,
+	// so that a chain upgrade is noticed.
+	protocolVersionRefreshInterval = 15 * time.Second
+
+	protocolVersionQueryTimeout = time.Second
+)
+
+var _ PrivValidator = &ProtocolVersionPrivValidator{}
+
+// ProtocolVersionPrivValidator rejects sign requests while a chain node running the chain reports
+// a block protocol version that is not in the configured blockProtocolVersions.
+// The privval connection does not carry the protocol version, so it is queried from the RPC of the chain nodes
+// in the background, and sign requests only read the result of the last query.
+type ProtocolVersionPrivValidator struct {
+	PrivValidator
+
+	logger   cometlog.Logger
+	nodes    ChainNodes
+	versions map[uint64]bool
+	status   func(ctx context.Context, rpcAddr string) (*cometrpctypes.ResultStatus, error)
+
+	mu     sync.Mutex
+	chains map[string]protocolVersionState
+
+	quit     chan struct{}
+	stopOnce sync.Once
+}
+
+// protocolVersionState is the result of the last block protocol version queries for a chain.
+type protocolVersionState struct {
+	// verified is when a chain node last reported an expected version, zero after an unexpected one.
+	verified time.Time
+	// err is the error of the last query, if it failed.
+	err error
+	// unexpected is true if err is an unexpected block protocol version.
+	unexpected bool
+}
+
+func NewProtocolVersionPrivValidator(
+	logger cometlog.Logger,
+	pv PrivValidator,
+	config Config,
+) *ProtocolVersionPrivValidator {
+	versions := make(map[uint64]bool, len(config.BlockProtocolVersions))
+	for _, v := range config.BlockProtocolVersions {
+		versions[v] = true
+	}
+
+	p := &ProtocolVersionPrivValidator{
+		PrivValidator: pv,
+		logger:        logger,
+		nodes:         append(append(ChainNodes{}, config.ChainNodes...), config.BackupChainNodes...),
+		versions:      versions,
+		status:        queryChainNodeStatus,
+		chains:        make(map[string]protocolVersionState),
+		quit:          make(chan struct{}),
+	}
+	go p.loop()
+	return p
+}
+
+// SignVote implements types.PrivValidator
+func (pv *ProtocolVersionPrivValidator) SignVote(chainID string, vote *cometproto.Vote) error {
+	if err := pv.check(chainID, time.Now()); err != nil {
+		return err
+	}
+	return pv.PrivValidator.SignVote(chainID, vote)
+}
+
+// SignProposal implements types.PrivValidator
+func (pv *ProtocolVersionPrivValidator) SignProposal(chainID string, proposal *cometproto.Proposal) error {
+	if err := pv.check(chainID, time.Now()); err != nil {
+		return err
+	}
+	return pv.PrivValidator.SignProposal(chainID, proposal)
+}
+
+// Stop stops the background queries and the wrapped PrivValidator. It is called once per remote signer.
+func (pv *ProtocolVersionPrivValidator) Stop() {
+	pv.stopOnce.Do(func() { close(pv.quit) })
+	pv.PrivValidator.Stop()
+}
+
+func (pv *ProtocolVersionPrivValidator) loop() {
+	ticker := time.NewTicker(protocolVersionRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pv.quit:
+			return
+		case now := <-ticker.C:
+			pv.mu.Lock()
+			chainIDs := make([]string, 0, len(pv.chains))
+			for chainID := range pv.chains {
+				chainIDs = append(chainIDs, chainID)
+			}
+			pv.mu.Unlock()
+
+			for _, chainID := range chainIDs {
+				pv.refresh(chainID, now)
+			}
+		}
+	}
+}
+
+// check returns an error unless the block protocol version of chainID was verified within the check interval.
+// Only the first sign request for a chain waits for the chain nodes to be queried, later ones read the result
+// of the background queries.
+func (pv *ProtocolVersionPrivValidator) check(chainID string, now time.Time) error {
+	pv.mu.Lock()
+	state, ok := pv.chains[chainID]
+	pv.mu.Unlock()
+
+	if !ok {
+		pv.refresh(chainID, now)
+
+		pv.mu.Lock()
+		state = pv.chains[chainID]
+		pv.mu.Unlock()
+	}
+
+	if state.unexpected {
+		totalUnexpectedProtocolVersions.Inc()
+		return state.err
+	}
+	if now.Sub(state.verified) < protocolVersionCheckInterval {
+		return nil
+	}
+	if state.err != nil {
+		return state.err
+	}
+	return fmt.Errorf("block protocol version of chain %s was not verified within %s, refusing to sign",
+		chainID, protocolVersionCheckInterval)
+}
+
+// refresh queries the chain nodes for the block protocol version of chainID, without holding the lock,
+// and records the result. Failing to reach the chain nodes keeps the last verification.
+func (pv *ProtocolVersionPrivValidator) refresh(chainID string, now time.Time) {
+	unexpected, err := pv.query(chainID)
+
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+
+	state := pv.chains[chainID]
+	switch {
+	case err == nil:
+		state = protocolVersionState{verified: now}
+	case unexpected:
+		state = protocolVersionState{err: err, unexpected: true}
+	default:
+		state.err, state.unexpected = err, false
+	}
+	pv.chains[chainID] = state
+}
+
+// query returns an error if a chain node reports an unexpected version, in which case unexpected is true,
+// or if no chain node running chainID responds.
+func (pv *ProtocolVersionPrivValidator) query(chainID string) (unexpected bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), protocolVersionQueryTimeout)
+	defer cancel()
+
+	var (
+		found bool
+		errs  []error
+	)
+	for _, node := range pv.nodes {
+		if node.RPCAddr == "" {
+			continue
+		}
+
+		status, err := pv.status(ctx, node.RPCAddr)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if status.NodeInfo.Network != chainID {
+			continue
+		}
+
+		block := status.NodeInfo.ProtocolVersion.Block
+		if !pv.versions[block] {
+			pv.logger.Error(
+				"Chain node speaks an unexpected block protocol version",
+				"chain_id", chainID,
+				"node", node.RPCAddr,
+				"version", block,
+			)
+			return true, fmt.Errorf("chain node %s speaks block protocol version %d for chain %s, "+
+				"which is not in blockProtocolVersions, refusing to sign", node.RPCAddr, block, chainID)
+		}
+		found = true
+	}
+
+	if !found {
+		err := fmt.Errorf("no chain node is running chain %s", chainID)
+		if len(errs) > 0 {
+			err = errors.Join(errs...)
+		}
+		return false, fmt.Errorf("failed to verify the block protocol version of chain %s, refusing to sign: %w",
+			chainID, err)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,72 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	cometp2p "github.com/cometbft/cometbft/p2p"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cometrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtocolVersionPrivValidator(t *testing.T) {
+	inner := new(countingPrivValidator)
+	pv := NewProtocolVersionPrivValidator(cometlog.NewNopLogger(), inner, Config{
+		ChainNodes: ChainNodes{
+			{PrivValAddr: "tcp://127.0.0.1:1234", RPCAddr: "tcp://127.0.0.1:26657"},
+			{PrivValAddr: "tcp://127.0.0.1:1235"},
+		},
+		BlockProtocolVersions: []uint64{11},
+	})
+
+	var (
+		block   uint64 = 11
+		network        = testChainID
+		err     error
+		queries int
+	)
+	pv.status = func(_ context.Context, rpcAddr string) (*cometrpctypes.ResultStatus, error) {
+		require.Equal(t, "tcp://127.0.0.1:26657", rpcAddr)
+		queries++
+		status := &cometrpctypes.ResultStatus{NodeInfo: cometp2p.DefaultNodeInfo{Network: network}}
+		status.NodeInfo.ProtocolVersion.Block = block
+		return status, err
+	}
+
+	t.Cleanup(pv.Stop)
+
+	require.NoError(t, pv.SignVote(testChainID, new(cometproto.Vote)))
+	require.NoError(t, pv.SignProposal(testChainID, new(cometproto.Proposal)))
+	require.Equal(t, 2, inner.signs)
+
+	// only the first sign request for the chain queries the chain nodes.
+	require.Equal(t, 1, queries)
+
+	now := time.Now().Add(protocolVersionRefreshInterval)
+	block = 12
+	pv.refresh(testChainID, now)
+	require.ErrorContains(t, pv.check(testChainID, now), "speaks block protocol version 12")
+
+	block, err = 11, errors.New("connection refused")
+	pv.refresh(testChainID, now)
+	require.ErrorContains(t, pv.check(testChainID, now), "connection refused")
+
+	network, err = "other-chain", nil
+	pv.refresh(testChainID, now)
+	require.ErrorContains(t, pv.check(testChainID, now), "no chain node is running chain "+testChainID)
+
+	network = testChainID
+	pv.refresh(testChainID, now)
+	require.NoError(t, pv.check(testChainID, now))
+
+	// the verified version is trusted for the check interval while the chain nodes are unreachable.
+	err = errors.New("connection refused")
+	pv.refresh(testChainID, now.Add(protocolVersionRefreshInterval))
+	require.NoError(t, pv.check(testChainID, now.Add(protocolVersionRefreshInterval)))
+	require.ErrorContains(t, pv.check(testChainID, now.Add(protocolVersionCheckInterval)), "connection refused")
+	require.Equal(t, 6, queries)
+}
@@ -0,0 +1,108 @@
+package signer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+const (
+	// the shortest timeouts that raft accepts.
+	minRaftTimeout       = 5 * time.Millisecond
+	minRaftCommitTimeout = time.Millisecond
+
+	// raftTimeoutWarnThreshold is the timeout below which a round trip between cosigners may exceed it,
+	// causing leader elections whenever the network is briefly slow.
+	raftTimeoutWarnThreshold = 100 * time.Millisecond
+)
+
+// RaftConfig configures the timeouts of the raft cluster of the cosigners. The defaults suit cosigners
+// in a single region, use longer timeouts for cosigners across regions to avoid spurious leader elections.
+type RaftConfig struct {
+	// HeartbeatTimeout is how long a follower waits for contact from the leader before starting an
+	// election. Defaults to 1s.
+	HeartbeatTimeout string `yaml:"heartbeatTimeout,omitempty"`
+	// ElectionTimeout is how long a candidate waits for the votes of an election before starting
+	// another. Must be greater than the heartbeatTimeout, if either is set. Defaults to 1s.
+	ElectionTimeout string `yaml:"electionTimeout,omitempty"`
+	// LeaderLeaseTimeout is how long the leader stays leader without contact from a quorum of the
+	// cosigners. Must not be greater than the heartbeatTimeout. Defaults to 500ms.
+	LeaderLeaseTimeout string `yaml:"leaderLeaseTimeout,omitempty"`
+	// CommitTimeout is how long the leader waits without new entries before it sends a heartbeat
+	// to confirm the commit index. Defaults to 50ms.
+	CommitTimeout string `yaml:"commitTimeout,omitempty"`
+}
+
+func (c *RaftConfig) Validate() error {
+	for _, d := range []struct {
+		name  string
+		value string
+		min   time.Duration
+	}{
+		{"heartbeatTimeout", c.HeartbeatTimeout, minRaftTimeout},
+		{"electionTimeout", c.ElectionTimeout, minRaftTimeout},
+		{"leaderLeaseTimeout", c.LeaderLeaseTimeout, minRaftTimeout},
+		{"commitTimeout", c.CommitTimeout, minRaftCommitTimeout},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if v, err := time.ParseDuration(d.value); err != nil {
+			return fmt.Errorf("invalid raft %s: %w", d.name, err)
+		} else if v < d.min {
+			return fmt.Errorf("raft %s (%s) must be at least %s", d.name, v, d.min)
+		}
+	}
+
+	cfg := c.apply(raft.DefaultConfig())
+	if (c.HeartbeatTimeout != "" || c.ElectionTimeout != "") && cfg.ElectionTimeout <= cfg.HeartbeatTimeout {
+		return fmt.Errorf("raft electionTimeout (%s) must be greater than heartbeatTimeout (%s)",
+			cfg.ElectionTimeout, cfg.HeartbeatTimeout)
+	}
+	if cfg.LeaderLeaseTimeout > cfg.HeartbeatTimeout {
+		return fmt.Errorf("raft leaderLeaseTimeout (%s) must not be greater than heartbeatTimeout (%s)",
+			cfg.LeaderLeaseTimeout, cfg.HeartbeatTimeout)
+	}
+	return nil
+}
+
+// apply sets the configured timeouts on the raft config, the timeouts that are not configured are left as is.
+func (c *RaftConfig) apply(cfg *raft.Config) *raft.Config {
+	if c == nil {
+		return cfg
+	}
+
+	// Validated prior in ValidateThresholdModeConfig
+	if d, err := time.ParseDuration(c.HeartbeatTimeout); err == nil {
+		cfg.HeartbeatTimeout = d
+	}
+	if d, err := time.ParseDuration(c.ElectionTimeout); err == nil {
+		cfg.ElectionTimeout = d
+	}
+	if d, err := time.ParseDuration(c.LeaderLeaseTimeout); err == nil {
+		cfg.LeaderLeaseTimeout = d
+	}
+	if d, err := time.ParseDuration(c.CommitTimeout); err == nil {
+		cfg.CommitTimeout = d
+	}
+	return cfg
+}
+
+// lowRaftTimeouts returns the names of the heartbeat, election and leader lease timeouts of the raft config
+// that are below raftTimeoutWarnThreshold.
+func lowRaftTimeouts(cfg *raft.Config) (low []string) {
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"heartbeatTimeout", cfg.HeartbeatTimeout},
+		{"electionTimeout", cfg.ElectionTimeout},
+		{"leaderLeaseTimeout", cfg.LeaderLeaseTimeout},
+	} {
+		if d.value < raftTimeoutWarnThreshold {
+			low = append(low, d.name)
+		}
+	}
+	return low
+}
@@ -0,0 +1,55 @@
+package signer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRaftConfig(t *testing.T) {
+	// the raft defaults are used when not configured.
+	var c *RaftConfig
+	cfg := c.apply(raft.DefaultConfig())
+	require.Equal(t, raft.DefaultConfig().HeartbeatTimeout, cfg.HeartbeatTimeout)
+	require.Equal(t, raft.DefaultConfig().ElectionTimeout, cfg.ElectionTimeout)
+	require.Empty(t, lowRaftTimeouts(cfg))
+
+	c = &RaftConfig{
+		HeartbeatTimeout:   "2s",
+		ElectionTimeout:    "3s",
+		LeaderLeaseTimeout: "1s",
+		CommitTimeout:      "100ms",
+	}
+	require.NoError(t, c.Validate())
+	cfg = c.apply(raft.DefaultConfig())
+	require.Equal(t, 2*time.Second, cfg.HeartbeatTimeout)
+	require.Equal(t, 3*time.Second, cfg.ElectionTimeout)
+	require.Equal(t, time.Second, cfg.LeaderLeaseTimeout)
+	require.Equal(t, 100*time.Millisecond, cfg.CommitTimeout)
+	cfg.LocalID = "1"
+	require.NoError(t, raft.ValidateConfig(cfg))
+
+	// only the configured timeouts are changed.
+	c = &RaftConfig{CommitTimeout: "10ms"}
+	require.NoError(t, c.Validate())
+	cfg = c.apply(raft.DefaultConfig())
+	require.Equal(t, raft.DefaultConfig().HeartbeatTimeout, cfg.HeartbeatTimeout)
+	require.Equal(t, 10*time.Millisecond, cfg.CommitTimeout)
+
+	require.EqualError(t, (&RaftConfig{HeartbeatTimeout: "2s"}).Validate(),
+		"raft electionTimeout (1s) must be greater than heartbeatTimeout (2s)")
+	require.EqualError(t, (&RaftConfig{HeartbeatTimeout: "1s", ElectionTimeout: "1s"}).Validate(),
+		"raft electionTimeout (1s) must be greater than heartbeatTimeout (1s)")
+	require.EqualError(t, (&RaftConfig{LeaderLeaseTimeout: "2s"}).Validate(),
+		"raft leaderLeaseTimeout (2s) must not be greater than heartbeatTimeout (1s)")
+	require.EqualError(t, (&RaftConfig{HeartbeatTimeout: "1ms"}).Validate(),
+		"raft heartbeatTimeout (1ms) must be at least 5ms")
+	require.ErrorContains(t, (&RaftConfig{CommitTimeout: "soon"}).Validate(), "invalid raft commitTimeout")
+
+	// low timeouts are valid, but warned about.
+	c = &RaftConfig{HeartbeatTimeout: "50ms", ElectionTimeout: "200ms", LeaderLeaseTimeout: "50ms"}
+	require.NoError(t, c.Validate())
+	require.Equal(t, []string{"heartbeatTimeout", "leaderLeaseTimeout"}, lowRaftTimeouts(c.apply(raft.DefaultConfig())))
+}
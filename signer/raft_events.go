@@ -1,22 +1,29 @@
 package signer
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"path/filepath"
 	"time"
 
+	"github.com/hashicorp/raft"
+	boltdb "github.com/hashicorp/raft-boltdb/v2"
 	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"go.etcd.io/bbolt"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 const (
-	raftEventLSS = "LSS"
+	raftEventLSS    = "LSS"
+	raftEventPaused = "PAUSED"
 )
 
 func (f *fsm) getEventHandler(key string) func(string) {
 	return map[string]func(string){
-		raftEventLSS: f.handleLSSEvent,
+		raftEventLSS:    f.handleLSSEvent,
+		raftEventPaused: f.handlePausedEvent,
 	}[key]
 }
 
@@ -47,6 +54,28 @@ func (f *fsm) handleLSSEvent(value string) {
 	_ = f.cosigner.SaveLastSignedState(lss.ChainID, lss.SignStateConsensus)
 }
 
+func (f *fsm) handlePausedEvent(value string) {
+	var paused bool
+	if err := json.Unmarshal([]byte(value), &paused); err != nil {
+		f.logger.Error(
+			"Paused Unmarshal Error",
+			"error", err,
+		)
+		return
+	}
+	// applied by SetThresholdValidator once it is set, the paused state is retained in the store.
+	if f.thresholdValidator == nil {
+		return
+	}
+	if err := f.thresholdValidator.SetPaused(paused); err != nil {
+		f.logger.Error(
+			"Error persisting paused state during raft replication",
+			"paused", paused,
+			"error", err,
+		)
+	}
+}
+
 func (s *RaftStore) getLeaderGRPCClient() (proto.CosignerGRPCClient, *grpc.ClientConn, error) {
 	var leader string
 	for i := 0; i < 30; i++ {
@@ -60,20 +89,21 @@ func (s *RaftStore) getLeaderGRPCClient() (proto.CosignerGRPCClient, *grpc.Clien
 		totalRaftLeaderElectiontimeout.Inc()
 		return nil, nil, errors.New("timed out waiting for leader election to complete")
 	}
-	conn, err := grpc.Dial(leader, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(leader, append(drillDialOptions(),
+		grpc.WithTransportCredentials(s.creds), s.keepalive.dialOption(), tracingDialOption())...)
 	if err != nil {
 		return nil, nil, err
 	}
 	return proto.NewCosignerGRPCClient(conn), conn, nil
 }
 
-func (s *RaftStore) SignBlock(req CosignerSignBlockRequest) (*CosignerSignBlockResponse, error) {
+func (s *RaftStore) SignBlock(ctx context.Context, req CosignerSignBlockRequest) (*CosignerSignBlockResponse, error) {
 	client, conn, err := s.getLeaderGRPCClient()
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
-	context, cancelFunc := getContext()
+	context, cancelFunc := getContext(ctx)
 	defer cancelFunc()
 	res, err := client.SignBlock(context, &proto.CosignerGRPCSignBlockRequest{
 		ChainID: req.ChainID,
@@ -86,3 +116,65 @@ func (s *RaftStore) SignBlock(req CosignerSignBlockRequest) (*CosignerSignBlockR
 		Signature: res.GetSignature(),
 	}, nil
 }
+
+// LastSignStateFromRaftLog scans the raft log in raftDir for the highest last sign state
+// replicated for chainID. The last sign state is only handled as an event, so it is not
+// included in raft snapshots and can only be recovered from the entries remaining in the log.
+// The log is opened read-only, and will fail to open while the signer is running.
+func LastSignStateFromRaftLog(raftDir string, chainID string) (*SignStateConsensus, error) {
+	logStore, err := boltdb.New(boltdb.Options{
+		Path: filepath.Join(raftDir, "logs.dat"),
+		BoltOptions: &bbolt.Options{
+			ReadOnly: true,
+			Timeout:  time.Second,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open raft log: %w", err)
+	}
+	defer logStore.Close()
+
+	first, err := logStore.FirstIndex()
+	if err != nil {
+		return nil, err
+	}
+	last, err := logStore.LastIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var highest *SignStateConsensus
+	for i := first; i != 0 && i <= last; i++ {
+		var l raft.Log
+		if err := logStore.GetLog(i, &l); err != nil {
+			if errors.Is(err, raft.ErrLogNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read raft log index %d: %w", i, err)
+		}
+		if l.Type != raft.LogCommand {
+			continue
+		}
+
+		var c command
+		if err := json.Unmarshal(l.Data, &c); err != nil || c.Op != "set" || c.Key != raftEventLSS {
+			continue
+		}
+
+		var lss ChainSignStateConsensus
+		if err := json.Unmarshal([]byte(c.Value), &lss); err != nil || lss.ChainID != chainID {
+			continue
+		}
+
+		if highest == nil || lss.SignStateConsensus.HRSKey().GreaterThan(highest.HRSKey()) {
+			ssc := lss.SignStateConsensus
+			highest = &ssc
+		}
+	}
+
+	if highest == nil {
+		return nil, fmt.Errorf("no sign state found for chain %s in raft log (index %d - %d)", chainID, first, last)
+	}
+
+	return highest, nil
+}
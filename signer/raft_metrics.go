@@ -0,0 +1,47 @@
+package signer
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+// reportLeadership updates the raft leadership metrics on each leader change until the store is stopped,
+// and notifies when this cosigner becomes the leader.
+func (s *RaftStore) reportLeadership() {
+	observations := make(chan raft.Observation, 16)
+	observer := raft.NewObserver(observations, false, func(o *raft.Observation) bool {
+		_, ok := o.Data.(raft.LeaderObservation)
+		return ok
+	})
+	s.raft.RegisterObserver(observer)
+	defer s.raft.DeregisterObserver(observer)
+
+	s.updateLeadershipMetrics(time.Now())
+	for {
+		select {
+		case <-s.Quit():
+			return
+		case <-observations:
+			s.updateLeadershipMetrics(time.Now())
+			if s.raft.State() == raft.Leader {
+				leaderID, _ := strconv.Atoi(s.NodeID)
+				s.notifications.leaderChanged(leaderID)
+			}
+		}
+	}
+}
+
+func (s *RaftStore) updateLeadershipMetrics(now time.Time) {
+	// the raft server ID of each cosigner is its shard ID.
+	_, id := s.raft.LeaderWithID()
+	leaderID, _ := strconv.Atoi(string(id))
+	raftLeaderID.Set(float64(leaderID))
+
+	if term, err := strconv.ParseUint(s.raft.Stats()["term"], 10, 64); err == nil {
+		raftTerm.Set(float64(term))
+	}
+
+	metricsTimeKeeper.SetPreviousLeaderChange(now)
+}
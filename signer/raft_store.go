@@ -12,9 +12,9 @@ import (
 	"fmt"
 	"io"
 	"net"
-	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
@@ -23,11 +23,16 @@ import (
 	"github.com/Jille/raftadmin"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/libs/service"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/raft"
 	boltdb "github.com/hashicorp/raft-boltdb/v2"
+	"github.com/strangelove-ventures/horcrux/client"
 	"github.com/strangelove-ventures/horcrux/signer/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -43,6 +48,9 @@ type command struct {
 	Value string `json:"value,omitempty"`
 }
 
+// defaultRaftApplyLatencyThreshold is used when the raftApplyLatencyThreshold is not configured.
+const defaultRaftApplyLatencyThreshold = 100 * time.Millisecond
+
 // Store is a simple key-value store, where all changes are made via Raft consensus.
 type RaftStore struct {
 	service.BaseService
@@ -56,26 +64,96 @@ type RaftStore struct {
 	mu sync.Mutex
 	m  map[string]string // The key-value store for the system.
 
-	raft *raft.Raft // The consensus mechanism
+	raft       *raft.Raft // The consensus mechanism
+	grpcServer *grpc.Server
 
 	logger             log.Logger
 	cosigner           *LocalCosigner
 	thresholdValidator *ThresholdValidator
+
+	// applies slower than this are logged as a warning
+	applyLatencyThreshold time.Duration
+
+	// creds are used by the gRPC server and to connect to the other cosigners.
+	creds credentials.TransportCredentials
+
+	// keepalive of the gRPC server and of the connections to the other cosigners.
+	keepalive *CosignerKeepaliveConfig
+
+	// rateLimit of the cosigner gRPC requests from each peer for each chain.
+	rateLimit *CosignerRateLimitConfig
+
+	// raftConfig holds the configured raft timeouts.
+	raftConfig *RaftConfig
+
+	// leaderTransferByLatency transfers leadership to the peer with the lowest round trip time,
+	// rather than to the next candidate, when no cosigner is requested.
+	leaderTransferByLatency bool
+
+	// notifications notifies when this cosigner becomes the raft leader, nil if disabled.
+	notifications *notifications
+
+	// jsonLogs makes raft log in JSON rather than in the hclog text format.
+	jsonLogs bool
+
+	// requests are the cosigner gRPC requests in flight, drained for up to drainTimeout on shutdown.
+	requests     requestDrain
+	drainTimeout time.Duration
+
+	health *health.Server
 }
 
 // New returns a new Store.
 func NewRaftStore(
 	nodeID string, directory string, bindAddress string, timeout time.Duration,
 	logger log.Logger, cosigner *LocalCosigner, cosigners []Cosigner) *RaftStore {
+	applyLatencyThreshold := defaultRaftApplyLatencyThreshold
+	drainTimeout := defaultShutdownDrainTimeout
+	var keepalive *CosignerKeepaliveConfig
+	var rateLimit *CosignerRateLimitConfig
+	var raftConfig *RaftConfig
+	var leaderTransferByLatency bool
+	var notifications *notifications
+	if cosigner != nil {
+		notifications = newNotifications(logger, cosigner.config, cosigner.GetID())
+		if tc := cosigner.config.Config.ThresholdModeConfig; tc != nil {
+			if tc.RaftApplyLatencyThreshold != "" {
+				// Validated prior in ValidateThresholdModeConfig
+				if d, err := time.ParseDuration(tc.RaftApplyLatencyThreshold); err == nil {
+					applyLatencyThreshold = d
+				}
+			}
+			if tc.ShutdownDrainTimeout != "" {
+				// Validated prior in ValidateThresholdModeConfig
+				if d, err := time.ParseDuration(tc.ShutdownDrainTimeout); err == nil {
+					drainTimeout = d
+				}
+			}
+			keepalive = tc.Keepalive
+			rateLimit = tc.RateLimit
+			raftConfig = tc.Raft
+			leaderTransferByLatency = tc.LeaderTransferByLatency
+		}
+	}
+
 	cosignerRaftStore := &RaftStore{
-		NodeID:      nodeID,
-		RaftDir:     directory,
-		RaftBind:    bindAddress,
-		RaftTimeout: timeout,
-		m:           make(map[string]string),
-		logger:      logger,
-		cosigner:    cosigner,
-		Cosigners:   cosigners,
+		NodeID:                  nodeID,
+		RaftDir:                 directory,
+		RaftBind:                bindAddress,
+		RaftTimeout:             timeout,
+		m:                       make(map[string]string),
+		logger:                  logger,
+		cosigner:                cosigner,
+		Cosigners:               cosigners,
+		applyLatencyThreshold:   applyLatencyThreshold,
+		creds:                   insecure.NewCredentials(),
+		keepalive:               keepalive,
+		rateLimit:               rateLimit,
+		raftConfig:              raftConfig,
+		leaderTransferByLatency: leaderTransferByLatency,
+		notifications:           notifications,
+		drainTimeout:            drainTimeout,
+		health:                  newCosignerHealthServer(),
 	}
 
 	cosignerRaftStore.BaseService = *service.NewBaseService(logger, "CosignerRaftStore", cosignerRaftStore)
@@ -84,15 +162,32 @@ func NewRaftStore(
 
 func (s *RaftStore) SetThresholdValidator(thresholdValidator *ThresholdValidator) {
 	s.thresholdValidator = thresholdValidator
+	s.syncPaused()
+}
+
+// SetTransportCredentials sets the credentials of the gRPC server and of the connections to the other cosigners,
+// insecure by default. It must be called before the store is started.
+func (s *RaftStore) SetTransportCredentials(creds credentials.TransportCredentials) {
+	s.creds = creds
+}
+
+// SetJSONLogs makes raft log in JSON, to match the format of the other logs. It must be called before
+// the store is started.
+func (s *RaftStore) SetJSONLogs(jsonLogs bool) {
+	s.jsonLogs = jsonLogs
 }
 
 func (s *RaftStore) init() error {
-	host := p2pURLToRaftAddress(s.RaftBind)
+	host, err := client.SanitizeAddress(s.RaftBind)
+	if err != nil {
+		return fmt.Errorf("failed to parse local address: %w", err)
+	}
 	_, port, err := net.SplitHostPort(host)
 	if err != nil {
 		return fmt.Errorf("failed to parse local address: %s, %v", host, err)
 	}
 	s.logger.Info("Local Raft Listening", "port", port)
+	s.logRaftTimeouts()
 	sock, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
 	if err != nil {
 		return err
@@ -101,13 +196,23 @@ func (s *RaftStore) init() error {
 	if err != nil {
 		return err
 	}
-	grpcServer := grpc.NewServer()
-	proto.RegisterCosignerGRPCServer(grpcServer, NewGRPCServer(s.cosigner, s.thresholdValidator, s))
-	transportManager.Register(grpcServer)
-	leaderhealth.Setup(s.raft, grpcServer, []string{"Leader"})
-	raftadmin.Register(grpcServer, s.raft)
-	reflection.Register(grpcServer)
-	return grpcServer.Serve(sock)
+	serverOptions := append(drillServerOptions(),
+		s.rateLimit.serverOption(),
+		grpc.ChainUnaryInterceptor(s.requests.unaryServerInterceptor),
+		tracingServerOption(),
+		grpcMetricsServerOption(),
+		grpc.Creds(s.creds),
+	)
+	s.grpcServer = grpc.NewServer(append(serverOptions, s.keepalive.serverOptions()...)...)
+	proto.RegisterCosignerGRPCServer(s.grpcServer, NewGRPCServer(s.cosigner, s.thresholdValidator, s))
+	transportManager.Register(s.grpcServer)
+	leaderhealth.Report(s.raft, s.health, []string{"Leader"})
+	grpc_health_v1.RegisterHealthServer(s.grpcServer, s.health)
+	go s.reportHealth()
+	go s.reportLeadership()
+	raftadmin.Register(s.grpcServer, s.raft)
+	reflection.Register(s.grpcServer)
+	return s.grpcServer.Serve(sock)
 }
 
 // OnStart starts the raft server
@@ -122,12 +227,82 @@ func (s *RaftStore) OnStart() error {
 	return nil
 }
 
-func p2pURLToRaftAddress(p2pURL string) string {
-	url, err := url.Parse(p2pURL)
+// OnStop hands off leadership, drains the requests in flight, then stops the gRPC server
+// and shuts down the raft server
+func (s *RaftStore) OnStop() {
+	// report NOT_SERVING first so that load balancers drain the cosigner.
+	s.health.Shutdown()
+	if s.raft != nil {
+		s.drain()
+	}
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+	if s.raft != nil {
+		if err := s.raft.Shutdown().Error(); err != nil {
+			s.logger.Error("Failed to shut down raft", "error", err)
+		}
+	}
+}
+
+// drain transfers leadership away from this cosigner if it is the leader, so that the cluster does not
+// have to detect its failure to elect a new one, then waits up to the drain timeout for the sign requests
+// and cosigner gRPC requests in flight to finish. New requests are refused.
+func (s *RaftStore) drain() {
+	deadline := time.Now().Add(s.drainTimeout)
+
+	if s.IsLeader() {
+		s.logger.Info("Transferring leadership before shutdown")
+		if err := s.raft.LeadershipTransfer().Error(); err != nil {
+			s.logger.Error("Failed to transfer leadership before shutdown", "error", err)
+		}
+	}
+
+	if s.thresholdValidator != nil && !s.thresholdValidator.signs.drain(time.Until(deadline)) {
+		s.logger.Error("Timed out waiting for sign requests in flight to finish", "timeout", s.drainTimeout)
+	}
+
+	if !s.requests.drain(time.Until(deadline)) {
+		s.logger.Error("Timed out waiting for cosigner requests in flight to finish", "timeout", s.drainTimeout)
+	}
+}
+
+// RaftServerID returns the raft server ID of the cosigner with the given shard ID.
+// The shard ID is used as the raft server ID for the membership, leadership transfers and logs,
+// so that it is stable across restarts.
+func RaftServerID(shardID int) raft.ServerID {
+	return raft.ServerID(strconv.Itoa(shardID))
+}
+
+// peerServer returns the raft membership entry of a peer cosigner.
+func peerServer(c Cosigner) (raft.Server, error) {
+	address, err := client.SanitizeAddress(c.GetAddress())
 	if err != nil {
-		return p2pURL
+		return raft.Server{}, fmt.Errorf("invalid raft address of cosigner %d: %w", c.GetID(), err)
+	}
+	return raft.Server{
+		ID:      RaftServerID(c.GetID()),
+		Address: raft.ServerAddress(address),
+	}, nil
+}
+
+// logRaftTimeouts logs the raft timeouts in effect, with an error if they are low.
+func (s *RaftStore) logRaftTimeouts() {
+	config := s.raftConfig.apply(raft.DefaultConfig())
+	s.logger.Info(
+		"Raft timeouts",
+		"heartbeat_timeout", config.HeartbeatTimeout,
+		"election_timeout", config.ElectionTimeout,
+		"leader_lease_timeout", config.LeaderLeaseTimeout,
+		"commit_timeout", config.CommitTimeout,
+	)
+	if low := lowRaftTimeouts(config); len(low) > 0 {
+		s.logger.Error(
+			"Raft timeouts are low, leader elections may be triggered whenever the network between cosigners is slow",
+			"timeouts", low,
+			"recommended_minimum", raftTimeoutWarnThreshold,
+		)
 	}
-	return url.Host
 }
 
 // Open opens the store. If enableSingle is set, and there are no existing peers,
@@ -135,9 +310,17 @@ func p2pURLToRaftAddress(p2pURL string) string {
 // localID should be the server identifier for this node.
 func (s *RaftStore) Open() (*raftgrpctransport.Manager, error) {
 	// Setup Raft configuration.
-	config := raft.DefaultConfig()
+	config := s.raftConfig.apply(raft.DefaultConfig())
 	config.LocalID = raft.ServerID(s.NodeID)
 	config.LogLevel = "ERROR"
+	if s.jsonLogs {
+		config.Logger = hclog.New(&hclog.LoggerOptions{
+			Name:       "raft",
+			Level:      hclog.Error,
+			Output:     os.Stderr,
+			JSONFormat: true,
+		})
+	}
 
 	// Create the snapshot store. This allows the Raft to truncate the log.
 	snapshots, err := raft.NewFileSnapshotStore(s.RaftDir, retainSnapshotCount, os.Stderr)
@@ -158,12 +341,16 @@ func (s *RaftStore) Open() (*raftgrpctransport.Manager, error) {
 		return nil, fmt.Errorf(`boltdb.NewBoltStore(%q): %v`, stableStoreFile, err)
 	}
 
-	raftAddress := raft.ServerAddress(p2pURLToRaftAddress(s.RaftBind))
+	bindAddress, err := client.SanitizeAddress(s.RaftBind)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raft bind address: %w", err)
+	}
+	raftAddress := raft.ServerAddress(bindAddress)
 
 	// Setup Raft communication.
-	transportManager := raftgrpctransport.New(raftAddress, []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	})
+	transportManager := raftgrpctransport.New(raftAddress, append(drillDialOptions(),
+		grpc.WithTransportCredentials(s.creds), s.keepalive.dialOption(),
+	))
 
 	// Instantiate the Raft systems.
 	ra, err := raft.NewRaft(config, (*fsm)(s), logStore, stableStore, snapshots, transportManager.Transport())
@@ -181,10 +368,11 @@ func (s *RaftStore) Open() (*raftgrpctransport.Manager, error) {
 		},
 	}
 	for _, c := range s.Cosigners {
-		configuration.Servers = append(configuration.Servers, raft.Server{
-			ID:      raft.ServerID(fmt.Sprint(c.GetID())),
-			Address: raft.ServerAddress(p2pURLToRaftAddress(c.GetAddress())),
-		})
+		srv, err := peerServer(c)
+		if err != nil {
+			return nil, err
+		}
+		configuration.Servers = append(configuration.Servers, srv)
 	}
 	s.raft.BootstrapCluster(configuration)
 
@@ -222,8 +410,7 @@ func (s *RaftStore) Set(key, value string) error {
 		return err
 	}
 
-	f := s.raft.Apply(b, s.RaftTimeout)
-	return f.Error()
+	return s.apply(c, b)
 }
 
 // Delete deletes the given key.
@@ -241,8 +428,27 @@ func (s *RaftStore) Delete(key string) error {
 		return err
 	}
 
-	f := s.raft.Apply(b, s.RaftTimeout)
-	return f.Error()
+	return s.apply(c, b)
+}
+
+// apply commits the command to the raft log and waits for it to be applied to the local FSM.
+func (s *RaftStore) apply(c *command, b []byte) error {
+	start := time.Now()
+	err := s.raft.Apply(b, s.RaftTimeout).Error()
+	latency := time.Since(start)
+
+	timedRaftApplyLag.Observe(latency.Seconds())
+	if s.applyLatencyThreshold > 0 && latency > s.applyLatencyThreshold {
+		s.logger.Error(
+			"Raft apply latency exceeded threshold",
+			"op", c.Op,
+			"key", c.Key,
+			"latency", latency,
+			"threshold", s.applyLatencyThreshold,
+		)
+	}
+
+	return err
 }
 
 // Join joins a node, identified by nodeID and located at addr, to this store.
@@ -287,6 +493,20 @@ func (s *RaftStore) IsLeader() bool {
 	return s.raft.State() == raft.Leader
 }
 
+// TransferLeadershipTo transfers leadership to the cosigner with the given shard ID.
+func (s *RaftStore) TransferLeadershipTo(shardID int) error {
+	for _, c := range s.Cosigners {
+		if c.GetID() == shardID {
+			srv, err := peerServer(c)
+			if err != nil {
+				return err
+			}
+			return s.raft.LeadershipTransferToServer(srv.ID, srv.Address).Error()
+		}
+	}
+	return fmt.Errorf("cosigner with shard ID %d is not a peer", shardID)
+}
+
 func (s *RaftStore) GetLeader() raft.ServerAddress {
 	if s == nil || s.raft == nil {
 		return ""
@@ -342,6 +562,11 @@ func (f *fsm) Restore(rc io.ReadCloser) error {
 	// Set the state from the snapshot, no lock required according to
 	// Hashicorp docs.
 	f.m = o
+
+	// the snapshot may have been taken after the last change of the paused state was applied.
+	if value, ok := o[raftEventPaused]; ok {
+		f.handlePausedEvent(value)
+	}
 	return nil
 }
 
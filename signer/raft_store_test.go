@@ -1,16 +1,30 @@
 package signer
 
 import (
+	"context"
 	"crypto/rand"
+	"fmt"
+	"net"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	cometcryptoed25519 "github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/privval"
 	"github.com/ethereum/go-ethereum/crypto/ecies"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/hashicorp/raft"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/strangelove-ventures/horcrux/client"
+	"github.com/strangelove-ventures/horcrux/signer/proto"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 // Test_StoreInMemOpenSingleNode tests that a command can be applied to the log
@@ -50,12 +64,18 @@ func Test_StoreInMemOpenSingleNode(t *testing.T) {
 		m:           make(map[string]string),
 		logger:      nil,
 		cosigner:    cosigner,
+		creds:       insecure.NewCredentials(),
+		raftConfig:  &RaftConfig{HeartbeatTimeout: "500ms", ElectionTimeout: "750ms"},
 	}
 
 	if _, err := s.Open(); err != nil {
 		t.Fatalf("failed to open store: %s", err)
 	}
 
+	// the configured timeouts are applied.
+	require.Equal(t, 500*time.Millisecond, s.raft.ReloadableConfig().HeartbeatTimeout)
+	require.Equal(t, 750*time.Millisecond, s.raft.ReloadableConfig().ElectionTimeout)
+
 	// Simple way to ensure there is a leader.
 	time.Sleep(3 * time.Second)
 
@@ -87,3 +107,283 @@ func Test_StoreInMemOpenSingleNode(t *testing.T) {
 		t.Fatalf("key has wrong value: %s", value)
 	}
 }
+
+// startTestRaftCluster starts an in-process raft cluster of the given size, with each node
+// serving the cosigner gRPC API on a local port. It returns the stores and their p2p addresses.
+// The options are applied to each store before it is started.
+func startTestRaftCluster(t *testing.T, size int, opts ...func(*RaftStore)) ([]*RaftStore, []string) {
+	addrs := make([]string, size)
+	for i := range addrs {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addrs[i] = fmt.Sprintf("tcp://%s", l.Addr().String())
+		require.NoError(t, l.Close())
+	}
+
+	tmpDir := t.TempDir()
+
+	stores := make([]*RaftStore, size)
+	for i := range stores {
+		var peers []Cosigner
+		for j, addr := range addrs {
+			if i != j {
+				peers = append(peers, NewRemoteCosigner(j+1, addr))
+			}
+		}
+
+		raftDir := filepath.Join(tmpDir, fmt.Sprintf("raft_%d", i+1))
+		require.NoError(t, os.MkdirAll(raftDir, 0700))
+
+		stores[i] = NewRaftStore(fmt.Sprint(i+1), raftDir, addrs[i], time.Second, log.NewNopLogger(), nil, peers)
+		for _, opt := range opts {
+			opt(stores[i])
+		}
+		require.NoError(t, stores[i].Start())
+	}
+
+	t.Cleanup(func() {
+		for _, s := range stores {
+			_ = s.Stop()
+		}
+	})
+
+	return stores, addrs
+}
+
+// testRaftAddress returns the raft address of the cosigner with the given p2p address.
+func testRaftAddress(t *testing.T, addr string) string {
+	address, err := client.SanitizeAddress(addr)
+	require.NoError(t, err)
+	return address
+}
+
+// getTestLeader asks the cosigner at the p2p address for the current raft leader.
+// It returns an empty string if the cosigner could not be reached.
+func getTestLeader(t *testing.T, addr string) string {
+	conn, err := grpc.Dial(testRaftAddress(t, addr), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	res, err := proto.NewCosignerGRPCClient(conn).GetLeader(ctx, &proto.CosignerGRPCGetLeaderRequest{})
+	if err != nil {
+		return ""
+	}
+	return res.GetLeader()
+}
+
+// waitForTestLeader waits until every reachable cosigner agrees on a leader that is not excluded.
+func waitForTestLeader(t *testing.T, addrs []string, timeout time.Duration, exclude ...string) string {
+	var leader string
+	require.Eventually(t, func() bool {
+		leader = ""
+		for _, addr := range addrs {
+			l := getTestLeader(t, addr)
+			if l == "" {
+				continue
+			}
+			if leader != "" && l != leader {
+				return false
+			}
+			leader = l
+		}
+		for _, e := range exclude {
+			if leader == e {
+				return false
+			}
+		}
+		return leader != ""
+	}, timeout, 100*time.Millisecond)
+	return leader
+}
+
+func transferTestLeadership(t *testing.T, addr string, leaderID string) *proto.CosignerGRPCTransferLeadershipResponse {
+	conn, err := grpc.Dial(testRaftAddress(t, addr), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := proto.NewCosignerGRPCClient(conn).TransferLeadership(
+		ctx,
+		&proto.CosignerGRPCTransferLeadershipRequest{LeaderID: leaderID},
+	)
+	require.NoError(t, err)
+	return res
+}
+
+func testLeaderIndex(t *testing.T, addrs []string, leader string) int {
+	for i, addr := range addrs {
+		if testRaftAddress(t, addr) == leader {
+			return i
+		}
+	}
+	t.Fatalf("leader %s is not a member of the cluster", leader)
+	return -1
+}
+
+func TestRaftStoreTransferLeadership(t *testing.T) {
+	_, addrs := startTestRaftCluster(t, 3)
+
+	leader := waitForTestLeader(t, addrs, 15*time.Second)
+	leaderIdx := testLeaderIndex(t, addrs, leader)
+
+	targetIdx := (leaderIdx + 1) % len(addrs)
+	targetID := fmt.Sprint(targetIdx + 1)
+	targetAddr := testRaftAddress(t, addrs[targetIdx])
+
+	// an unknown shard ID must not move leadership to the next candidate.
+	conn, err := grpc.Dial(testRaftAddress(t, addrs[leaderIdx]), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = proto.NewCosignerGRPCClient(conn).TransferLeadership(
+		context.Background(),
+		&proto.CosignerGRPCTransferLeadershipRequest{LeaderID: "9"},
+	)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, leader, waitForTestLeader(t, addrs, time.Second))
+
+	res := transferTestLeadership(t, addrs[leaderIdx], targetID)
+	require.Equal(t, targetID, res.GetLeaderID())
+	require.Equal(t, targetAddr, res.GetLeaderAddress())
+
+	require.Equal(t, targetAddr, waitForTestLeader(t, addrs, 5*time.Second, leader))
+}
+
+func TestRaftStoreTransferLeadershipUnavailableTarget(t *testing.T) {
+	stores, addrs := startTestRaftCluster(t, 3)
+
+	leader := waitForTestLeader(t, addrs, 15*time.Second)
+	leaderIdx := testLeaderIndex(t, addrs, leader)
+
+	targetIdx := (leaderIdx + 1) % len(addrs)
+	targetAddr := testRaftAddress(t, addrs[targetIdx])
+
+	// take the target out of the cluster so that it can't become leader.
+	require.NoError(t, stores[targetIdx].Stop())
+
+	transferTestLeadership(t, addrs[leaderIdx], fmt.Sprint(targetIdx+1))
+
+	remaining := make([]string, 0, len(addrs)-1)
+	for i, addr := range addrs {
+		if i != targetIdx {
+			remaining = append(remaining, addr)
+		}
+	}
+
+	// the cluster must keep a leader, and it must never be the unavailable target.
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		require.NotEqual(t, targetAddr, waitForTestLeader(t, remaining, 5*time.Second))
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+func TestRaftStoreStopTransfersLeadership(t *testing.T) {
+	stores, addrs := startTestRaftCluster(t, 3)
+
+	leader := waitForTestLeader(t, addrs, 15*time.Second)
+	leaderIdx := testLeaderIndex(t, addrs, leader)
+
+	require.NoError(t, stores[leaderIdx].Stop())
+
+	remaining := make([]string, 0, len(addrs)-1)
+	for i, addr := range addrs {
+		if i != leaderIdx {
+			remaining = append(remaining, addr)
+		}
+	}
+
+	// leadership was handed off before the leader stopped, the followers don't have to wait
+	// for the heartbeat timeout to elect a new leader.
+	waitForTestLeader(t, remaining, 500*time.Millisecond, leader)
+}
+
+func TestRaftStoreServerIDConsistent(t *testing.T) {
+	stores, addrs := startTestRaftCluster(t, 3)
+
+	leader := waitForTestLeader(t, addrs, 15*time.Second)
+	leaderIdx := testLeaderIndex(t, addrs, leader)
+	leaderStore := stores[leaderIdx]
+
+	targetIdx := (leaderIdx + 1) % len(addrs)
+	targetShardID := targetIdx + 1
+
+	// the membership must identify the target with the same server ID used for the transfer.
+	configFuture := leaderStore.raft.GetConfiguration()
+	require.NoError(t, configFuture.Error())
+	require.Contains(t, configFuture.Configuration().Servers, raft.Server{
+		Suffrage: raft.Voter,
+		ID:       RaftServerID(targetShardID),
+		Address:  raft.ServerAddress(testRaftAddress(t, addrs[targetIdx])),
+	})
+
+	require.NoError(t, leaderStore.TransferLeadershipTo(targetShardID))
+
+	require.Eventually(t, func() bool {
+		_, id := leaderStore.raft.LeaderWithID()
+		return id == RaftServerID(targetShardID)
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func getTestHealth(t *testing.T, addr string, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	conn, err := grpc.Dial(testRaftAddress(t, addr), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	res, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return res.Status
+}
+
+func TestRaftStoreHealth(t *testing.T) {
+	_, addrs := startTestRaftCluster(t, 3)
+
+	leader := waitForTestLeader(t, addrs, 15*time.Second)
+	leaderIdx := testLeaderIndex(t, addrs, leader)
+
+	require.Eventually(t, func() bool {
+		return getTestHealth(t, addrs[leaderIdx], "Leader") == grpc_health_v1.HealthCheckResponse_SERVING
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// the test cluster has no local cosigners with key shards, so it is not ready to sign.
+	time.Sleep(2 * cosignerHealthReportInterval)
+	for _, addr := range addrs {
+		require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, getTestHealth(t, addr, ""))
+		require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+			getTestHealth(t, addr, proto.CosignerGRPC_ServiceDesc.ServiceName))
+	}
+}
+
+func TestLocalCosignerHasKeyShard(t *testing.T) {
+	config := &RuntimeConfig{HomeDir: t.TempDir()}
+	cosigner := NewLocalCosigner(log.NewNopLogger(), config, nil, "")
+	require.False(t, cosigner.hasKeyShard())
+
+	privateKey := cometcryptoed25519.GenPrivKey()
+	shards := CreateCosignerEd25519Shards(privval.FilePVKey{
+		Address: privateKey.PubKey().Address(),
+		PubKey:  privateKey.PubKey(),
+		PrivKey: privateKey,
+	}, 2, 3)
+	require.NoError(t, WriteCosignerEd25519ShardFile(shards[0], config.KeyFilePathCosigner(testChainID)))
+	require.True(t, cosigner.hasKeyShard())
+}
+
+func TestRaftStoreLeadershipMetrics(t *testing.T) {
+	_, addrs := startTestRaftCluster(t, 3)
+
+	leader := waitForTestLeader(t, addrs, 15*time.Second)
+	leaderIdx := testLeaderIndex(t, addrs, leader)
+
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(raftLeaderID.vec.prom.WithLabelValues()) == float64(leaderIdx+1)
+	}, 5*time.Second, 100*time.Millisecond)
+	require.GreaterOrEqual(t, testutil.ToFloat64(raftTerm.vec.prom.WithLabelValues()), 1.0)
+}
@@ -3,19 +3,25 @@ package signer
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"time"
 
 	cometcrypto "github.com/cometbft/cometbft/crypto"
+	"github.com/strangelove-ventures/horcrux/client"
 	"github.com/strangelove-ventures/horcrux/signer/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // RemoteCosigner uses CosignerGRPC to request signing from a remote cosigner
 type RemoteCosigner struct {
 	id      int
 	address string
+	creds   credentials.TransportCredentials
+
+	keepalive *CosignerKeepaliveConfig
+	retry     *CosignerRetryConfig
 }
 
 // NewRemoteCosigner returns a newly initialized RemoteCosigner
@@ -24,16 +30,32 @@ func NewRemoteCosigner(id int, address string) *RemoteCosigner {
 	cosigner := &RemoteCosigner{
 		id:      id,
 		address: address,
+		creds:   insecure.NewCredentials(),
 	}
 	return cosigner
 }
 
+// SetTransportCredentials sets the credentials used to connect to the remote cosigner, insecure by default.
+func (cosigner *RemoteCosigner) SetTransportCredentials(creds credentials.TransportCredentials) {
+	cosigner.creds = creds
+}
+
+// SetKeepalive sets the keepalive of the connections to the remote cosigner, the defaults if nil.
+func (cosigner *RemoteCosigner) SetKeepalive(keepalive *CosignerKeepaliveConfig) {
+	cosigner.keepalive = keepalive
+}
+
+// SetRetry sets the retries of the nonce and sign requests to the remote cosigner, the defaults if nil.
+func (cosigner *RemoteCosigner) SetRetry(retry *CosignerRetryConfig) {
+	cosigner.retry = retry
+}
+
 const (
 	rpcTimeout = 4 * time.Second
 )
 
-func getContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), rpcTimeout)
+func getContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, rpcTimeout)
 }
 
 // GetID returns the ID of the remote cosigner
@@ -61,14 +83,12 @@ func (cosigner *RemoteCosigner) VerifySignature(_ string, _, _ []byte) bool {
 }
 
 func (cosigner *RemoteCosigner) getGRPCClient() (proto.CosignerGRPCClient, *grpc.ClientConn, error) {
-	var grpcAddress string
-	url, err := url.Parse(cosigner.address)
+	grpcAddress, err := client.SanitizeAddress(cosigner.address)
 	if err != nil {
-		grpcAddress = cosigner.address
-	} else {
-		grpcAddress = url.Host
+		return nil, nil, err
 	}
-	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(grpcAddress, append(drillDialOptions(),
+		grpc.WithTransportCredentials(cosigner.creds), cosigner.keepalive.dialOption(), tracingDialOption())...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -77,6 +97,19 @@ func (cosigner *RemoteCosigner) getGRPCClient() (proto.CosignerGRPCClient, *grpc
 
 // Implements the cosigner interface
 func (cosigner *RemoteCosigner) GetNonces(
+	ctx context.Context,
+	chainID string,
+	req HRSTKey,
+) (res *CosignerNoncesResponse, err error) {
+	err = cosigner.retry.retry(ctx, cosigner.address, func() error {
+		res, err = cosigner.getNonces(ctx, chainID, req)
+		return err
+	})
+	return res, err
+}
+
+func (cosigner *RemoteCosigner) getNonces(
+	ctx context.Context,
 	chainID string,
 	req HRSTKey,
 ) (*CosignerNoncesResponse, error) {
@@ -85,7 +118,7 @@ func (cosigner *RemoteCosigner) GetNonces(
 		return nil, err
 	}
 	defer conn.Close()
-	context, cancelFunc := getContext()
+	context, cancelFunc := getContext(ctx)
 	defer cancelFunc()
 	res, err := client.GetNonces(context, &proto.CosignerGRPCGetNoncesRequest{
 		ChainID: chainID,
@@ -101,13 +134,26 @@ func (cosigner *RemoteCosigner) GetNonces(
 
 // Implements the cosigner interface
 func (cosigner *RemoteCosigner) SetNoncesAndSign(
-	req CosignerSetNoncesAndSignRequest) (*CosignerSignResponse, error) {
+	ctx context.Context,
+	req CosignerSetNoncesAndSignRequest,
+) (res *CosignerSignResponse, err error) {
+	err = cosigner.retry.retry(ctx, cosigner.address, func() error {
+		res, err = cosigner.setNoncesAndSign(ctx, req)
+		return err
+	})
+	return res, err
+}
+
+func (cosigner *RemoteCosigner) setNoncesAndSign(
+	ctx context.Context,
+	req CosignerSetNoncesAndSignRequest,
+) (*CosignerSignResponse, error) {
 	client, conn, err := cosigner.getGRPCClient()
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
-	context, cancelFunc := getContext()
+	context, cancelFunc := getContext(ctx)
 	defer cancelFunc()
 	res, err := client.SetNoncesAndSign(context, &proto.CosignerGRPCSetNoncesAndSignRequest{
 		ChainID:   req.ChainID,
@@ -122,5 +168,91 @@ func (cosigner *RemoteCosigner) SetNoncesAndSign(
 		NoncePublic: res.GetNoncePublic(),
 		Timestamp:   time.Unix(0, res.GetTimestamp()),
 		Signature:   res.GetSignature(),
+		HRST:        HRSTKeyFromProto(res.GetHrst()),
 	}, nil
 }
+
+// GetSignState returns the HRS of the last share signed by the remote cosigner for the chain.
+func (cosigner *RemoteCosigner) GetSignState(ctx context.Context, chainID string) (HRSKey, error) {
+	client, conn, err := cosigner.getGRPCClient()
+	if err != nil {
+		return HRSKey{}, err
+	}
+	defer conn.Close()
+	context, cancelFunc := getContext(ctx)
+	defer cancelFunc()
+	res, err := client.GetSignState(context, &proto.CosignerGRPCGetSignStateRequest{
+		ChainID: chainID,
+	})
+	if err != nil {
+		return HRSKey{}, err
+	}
+	return HRSKey{Height: res.GetHeight(), Round: res.GetRound(), Step: int8(res.GetStep())}, nil
+}
+
+// ClockOffset returns how far the clock of the remote cosigner is ahead of ours, negative if it is behind.
+// The remote time is assumed to be read halfway through the round trip.
+func (cosigner *RemoteCosigner) ClockOffset(ctx context.Context) (time.Duration, error) {
+	client, conn, err := cosigner.getGRPCClient()
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	context, cancelFunc := getContext(ctx)
+	defer cancelFunc()
+	start := time.Now()
+	res, err := client.GetStatus(context, &proto.CosignerGRPCGetStatusRequest{})
+	if err != nil {
+		return 0, err
+	}
+	rtt := time.Since(start)
+	if res.GetTime() == 0 {
+		return 0, fmt.Errorf("cosigner %d does not report its time", cosigner.id)
+	}
+	return time.Unix(0, res.GetTime()).Sub(start.Add(rtt / 2)), nil
+}
+
+// CheckHealth returns an error unless the health service of the remote cosigner reports it is serving.
+func (cosigner *RemoteCosigner) CheckHealth(ctx context.Context) error {
+	_, conn, err := cosigner.getGRPCClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	context, cancelFunc := getContext(ctx)
+	defer cancelFunc()
+	res, err := grpc_health_v1.NewHealthClient(conn).Check(context, &grpc_health_v1.HealthCheckRequest{
+		Service: proto.CosignerGRPC_ServiceDesc.ServiceName,
+	})
+	if err != nil {
+		return err
+	}
+	if res.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("cosigner %d is %s", cosigner.id, res.GetStatus())
+	}
+	return nil
+}
+
+// Ping returns the lowest round trip time of a few requests to the remote cosigner. The requests are sent
+// on the same connection, so that the time taken to establish it is not counted.
+func (cosigner *RemoteCosigner) Ping(ctx context.Context) (time.Duration, error) {
+	client, conn, err := cosigner.getGRPCClient()
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	context, cancelFunc := getContext(ctx)
+	defer cancelFunc()
+
+	var rtt time.Duration
+	for i := 0; i < pingSamples; i++ {
+		start := time.Now()
+		if _, err := client.GetLeader(context, &proto.CosignerGRPCGetLeaderRequest{}); err != nil {
+			return 0, err
+		}
+		if d := time.Since(start); i == 0 || d < rtt {
+			rtt = d
+		}
+	}
+	return rtt, nil
+}
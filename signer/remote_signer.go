@@ -2,8 +2,13 @@ package signer
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	cometcrypto "github.com/cometbft/cometbft/crypto"
@@ -39,6 +44,15 @@ type ReconnRemoteSigner struct {
 	privVal PrivValidator
 
 	dialer net.Dialer
+
+	// tlsConfig wraps tcp connections in TLS if set.
+	tlsConfig *tls.Config
+
+	// connected is true while a connection to the chain node is established.
+	connected atomic.Bool
+
+	// cancel stops reconnecting to the chain node.
+	cancel context.CancelFunc
 }
 
 // NewReconnRemoteSigner return a ReconnRemoteSigner that will dial using the given
@@ -63,14 +77,23 @@ func NewReconnRemoteSigner(
 	return rs
 }
 
+// SetTLSConfig wraps the tcp connection to the chain node in TLS, inside of which the secret connection
+// is made. It must be called before the remote signer is started.
+func (rs *ReconnRemoteSigner) SetTLSConfig(tlsConfig *tls.Config) {
+	rs.tlsConfig = tlsConfig
+}
+
 // OnStart implements cmn.Service.
 func (rs *ReconnRemoteSigner) OnStart() error {
-	go rs.loop(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	rs.cancel = cancel
+	go rs.loop(ctx)
 	return nil
 }
 
 // OnStop implements cmn.Service.
 func (rs *ReconnRemoteSigner) OnStop() {
+	rs.cancel()
 	rs.privVal.Stop()
 }
 
@@ -84,6 +107,20 @@ func (rs *ReconnRemoteSigner) establishConnection(ctx context.Context) (net.Conn
 		return nil, fmt.Errorf("dial error: %w", err)
 	}
 
+	// CometBFT only uses a secret connection for tcp, the socket file permissions protect a unix connection.
+	if proto == "unix" {
+		return netConn, nil
+	}
+
+	if rs.tlsConfig != nil {
+		tlsConn := tls.Client(netConn, rs.tlsConfigFor(address))
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			netConn.Close()
+			return nil, fmt.Errorf("tls handshake error: %w", err)
+		}
+		netConn = tlsConn
+	}
+
 	conn, err := cometp2pconn.MakeSecretConnection(netConn, rs.privKey)
 	if err != nil {
 		netConn.Close()
@@ -93,12 +130,35 @@ func (rs *ReconnRemoteSigner) establishConnection(ctx context.Context) (net.Conn
 	return conn, nil
 }
 
+// setConnected records whether the connection to the chain node is established.
+func (rs *ReconnRemoteSigner) setConnected(connected bool) {
+	rs.connected.Store(connected)
+	if connected {
+		chainNodeConnected.WithLabelValues(rs.address).Set(1)
+	} else {
+		chainNodeConnected.WithLabelValues(rs.address).Set(0)
+	}
+}
+
+// tlsConfigFor returns the TLS config of the connection to the given address, verifying the host of the
+// address if no server name is configured.
+func (rs *ReconnRemoteSigner) tlsConfigFor(address string) *tls.Config {
+	cfg := rs.tlsConfig.Clone()
+	if cfg.ServerName == "" {
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			cfg.ServerName = host
+		}
+	}
+	return cfg
+}
+
 // main loop for ReconnRemoteSigner
 func (rs *ReconnRemoteSigner) loop(ctx context.Context) {
 	var conn net.Conn
 	for {
 		if !rs.IsRunning() {
 			rs.closeConn(conn)
+			rs.setConnected(false)
 			return
 		}
 
@@ -110,6 +170,7 @@ func (rs *ReconnRemoteSigner) loop(ctx context.Context) {
 			if err == nil {
 				sentryConnectTries.Set(0)
 				timer.Stop()
+				rs.setConnected(true)
 				rs.Logger.Info("Connected to Sentry", "address", rs.address)
 				break
 			}
@@ -135,6 +196,7 @@ func (rs *ReconnRemoteSigner) loop(ctx context.Context) {
 		// since dialing can take time, we check running again
 		if !rs.IsRunning() {
 			rs.closeConn(conn)
+			rs.setConnected(false)
 			return
 		}
 
@@ -147,6 +209,7 @@ func (rs *ReconnRemoteSigner) loop(ctx context.Context) {
 			)
 			rs.closeConn(conn)
 			conn = nil
+			rs.setConnected(false)
 			continue
 		}
 
@@ -162,6 +225,7 @@ func (rs *ReconnRemoteSigner) loop(ctx context.Context) {
 			)
 			rs.closeConn(conn)
 			conn = nil
+			rs.setConnected(false)
 		}
 	}
 }
@@ -383,29 +447,92 @@ func getRemoteSignerError(err error) *cometprotoprivval.RemoteSignerError {
 	}
 }
 
+// StartRemoteSigners starts a remote signer for each of the chain nodes. If backup chain nodes are provided,
+// a ChainNodeFailover is also started to connect to them while all of the chain nodes are unreachable,
+// one at a time in order if orderedBackupNodes is set.
 func StartRemoteSigners(
 	services []cometservice.Service,
 	logger cometlog.Logger,
 	privVal PrivValidator,
 	nodes []string,
+	backupNodes []string,
+	orderedBackupNodes bool,
+	tlsConfig *tls.Config,
 ) ([]cometservice.Service, error) {
-	var err error
+	for _, node := range append(nodes, backupNodes...) {
+		if err := checkUnixSocket(node); err != nil {
+			return nil, err
+		}
+	}
+
 	go StartMetrics()
+	primaries := make([]*ReconnRemoteSigner, 0, len(nodes))
 	for _, node := range nodes {
-		// CometBFT requires a connection within 3 seconds of start or crashes
-		// A long timeout such as 30 seconds would cause the sentry to fail in loops
-		// Use a short timeout and dial often to connect within 3 second window
-		dialer := net.Dialer{Timeout: 2 * time.Second}
-		s := NewReconnRemoteSigner(node, logger, privVal, dialer)
-
-		err = s.Start()
-		if err != nil {
+		s := newChainNodeRemoteSigner(node, logger, privVal, tlsConfig)
+		if err := s.Start(); err != nil {
 			return nil, err
 		}
 
 		services = append(services, s)
+		primaries = append(primaries, s)
+	}
+
+	if len(backupNodes) == 0 {
+		return services, nil
+	}
+
+	failover := NewChainNodeFailover(logger, privVal, primaries, backupNodes)
+	failover.SetOrdered(orderedBackupNodes)
+	failover.SetTLSConfig(tlsConfig)
+	if err := failover.Start(); err != nil {
+		return nil, err
+	}
+	return append(services, failover), nil
+}
+
+func newChainNodeRemoteSigner(
+	node string,
+	logger cometlog.Logger,
+	privVal PrivValidator,
+	tlsConfig *tls.Config,
+) *ReconnRemoteSigner {
+	// CometBFT requires a connection within 3 seconds of start or crashes
+	// A long timeout such as 30 seconds would cause the sentry to fail in loops
+	// Use a short timeout and dial often to connect within 3 second window
+	dialer := net.Dialer{Timeout: 2 * time.Second}
+	rs := NewReconnRemoteSigner(node, logger, privVal, dialer)
+	rs.SetTLSConfig(tlsConfig)
+	return rs
+}
+
+// checkUnixSocket returns an error if the chain node privval address is a unix socket that can never be
+// dialed. The chain node creates the socket when it starts, so the socket may not exist yet, but its
+// directory must, and any existing file at the path must be a socket.
+func checkUnixSocket(node string) error {
+	proto, socketPath := cometnet.ProtocolAndAddress(node)
+	if proto != "unix" {
+		return nil
+	}
+
+	dir, err := os.Stat(filepath.Dir(socketPath))
+	if err != nil {
+		return fmt.Errorf("chain node %s socket directory is not accessible: %w", node, err)
+	}
+	if !dir.IsDir() {
+		return fmt.Errorf("chain node %s socket directory %s is not a directory", node, filepath.Dir(socketPath))
+	}
+
+	fi, err := os.Stat(socketPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("chain node %s socket is not accessible: %w", node, err)
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("chain node %s socket path %s is not a socket", node, socketPath)
 	}
-	return services, err
+	return nil
 }
 
 func (rs *ReconnRemoteSigner) closeConn(conn net.Conn) {
@@ -0,0 +1,148 @@
+package signer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cometcryptoed25519 "github.com/cometbft/cometbft/crypto/ed25519"
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	cometp2pconn "github.com/cometbft/cometbft/p2p/conn"
+	"github.com/cometbft/cometbft/privval"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconnRemoteSignerUnixSocket(t *testing.T) {
+	logger := cometlog.NewNopLogger()
+	addr := "unix://" + filepath.Join(t.TempDir(), "privval.sock")
+
+	// the chain node listens on the socket, without a secret connection.
+	listener, err := privval.NewSignerListener(addr, logger)
+	require.NoError(t, err)
+	require.NoError(t, checkUnixSocket(addr))
+
+	privVal := new(countingPrivValidator)
+	rs := NewReconnRemoteSigner(addr, logger, privVal, net.Dialer{Timeout: time.Second})
+	require.NoError(t, rs.Start())
+	t.Cleanup(func() { _ = rs.Stop() })
+
+	require.NoError(t, listener.Start())
+	t.Cleanup(func() { _ = listener.Stop() })
+
+	client, err := privval.NewSignerClient(listener, testChainID)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SignVote(testChainID, &cometproto.Vote{Height: 1, Type: cometproto.PrevoteType}))
+	require.Equal(t, 1, privVal.signs)
+	require.True(t, rs.connected.Load())
+}
+
+// secretConnListener accepts secret connections like the CometBFT privval listener, over any listener.
+type secretConnListener struct {
+	net.Listener
+	key cometcryptoed25519.PrivKey
+}
+
+func (l *secretConnListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return cometp2pconn.MakeSecretConnection(conn, l.key)
+}
+
+func TestReconnRemoteSignerTLS(t *testing.T) {
+	logger := cometlog.NewNopLogger()
+
+	// the certificates are valid for 127.0.0.1.
+	caPEM, certs, err := CreateCosignerTLSCerts(CosignersConfig{{ShardID: 1, P2PAddr: "tcp://127.0.0.1:2222"}})
+	require.NoError(t, err)
+	cert, err := tls.X509KeyPair(certs[0].Cert, certs[0].Key)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caPEM))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := "tcp://" + ln.Addr().String()
+
+	// a TLS terminating proxy in front of the chain node, which makes the secret connection inside of TLS.
+	tlsListener := tls.NewListener(ln, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+	listener := privval.NewSignerListenerEndpoint(logger, &secretConnListener{
+		Listener: tlsListener,
+		key:      cometcryptoed25519.GenPrivKey(),
+	})
+
+	privVal := new(countingPrivValidator)
+	rs := NewReconnRemoteSigner(addr, logger, privVal, net.Dialer{Timeout: time.Second})
+	rs.SetTLSConfig(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	})
+	require.NoError(t, rs.Start())
+	t.Cleanup(func() { _ = rs.Stop() })
+
+	require.NoError(t, listener.Start())
+	t.Cleanup(func() { _ = listener.Stop() })
+
+	client, err := privval.NewSignerClient(listener, testChainID)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SignVote(testChainID, &cometproto.Vote{Height: 1, Type: cometproto.PrevoteType}))
+	require.Equal(t, 1, privVal.signs)
+}
+
+func TestCheckUnixSocket(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, checkUnixSocket("tcp://sentry-1:1234"))
+
+	// the chain node may not have created the socket yet.
+	require.NoError(t, checkUnixSocket("unix://"+filepath.Join(dir, "privval.sock")))
+
+	require.ErrorContains(t, checkUnixSocket("unix://"+filepath.Join(dir, "missing", "privval.sock")),
+		"socket directory is not accessible")
+
+	file := filepath.Join(dir, "privval.json")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	require.ErrorContains(t, checkUnixSocket("unix://"+file), "is not a socket")
+}
+
+func TestChainNodeTLSConfig(t *testing.T) {
+	require.EqualError(t, (&ChainNodeTLSConfig{CertFile: "client.crt"}).Validate(),
+		"chainNodeTLS caFile is required\nchainNodeTLS certFile and keyFile must be configured together")
+
+	homeDir := t.TempDir()
+	config := RuntimeConfig{HomeDir: homeDir}
+
+	tlsConfig, err := config.ChainNodeTLSConfig()
+	require.NoError(t, err)
+	require.Nil(t, tlsConfig)
+
+	caPEM, certs, err := CreateCosignerTLSCerts(CosignersConfig{{ShardID: 1, P2PAddr: "tcp://127.0.0.1:2222"}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, "ca.crt"), caPEM, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, "client.crt"), certs[0].Cert, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, "client.key"), certs[0].Key, 0600))
+
+	config.Config.ChainNodeTLS = &ChainNodeTLSConfig{CAFile: "ca.crt", CertFile: "client.crt", KeyFile: "client.key"}
+	require.NoError(t, config.Config.ChainNodeTLS.Validate())
+	tlsConfig, err = config.ChainNodeTLSConfig()
+	require.NoError(t, err)
+	require.Len(t, tlsConfig.Certificates, 1)
+
+	config.Config.ChainNodeTLS = &ChainNodeTLSConfig{CAFile: "client.key"}
+	_, err = config.ChainNodeTLSConfig()
+	require.ErrorContains(t, err, "no certificates found in chain node tls ca")
+}
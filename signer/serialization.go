@@ -26,19 +26,27 @@ func WriteMsg(writer io.Writer, msg cometprotoprivval.Message) (err error) {
 
 // UnpackHRS deserializes sign bytes and gets the height, round, and step
 func UnpackHRST(signBytes []byte) (HRSTKey, error) {
+	hrst, _, err := UnpackHRSTAndChainID(signBytes)
+	return hrst, err
+}
+
+// UnpackHRSTAndChainID deserializes sign bytes and gets the height, round, step, timestamp and chain-id
+func UnpackHRSTAndChainID(signBytes []byte) (HRSTKey, string, error) {
 	{
 		var proposal cometproto.CanonicalProposal
 		if err := protoio.UnmarshalDelimited(signBytes, &proposal); err == nil {
-			return HRSTKey{proposal.Height, proposal.Round, stepPropose, proposal.Timestamp.UnixNano()}, nil
+			return HRSTKey{proposal.Height, proposal.Round, stepPropose, proposal.Timestamp.UnixNano()},
+				proposal.ChainID, nil
 		}
 	}
 
 	{
 		var vote cometproto.CanonicalVote
 		if err := protoio.UnmarshalDelimited(signBytes, &vote); err == nil {
-			return HRSTKey{vote.Height, vote.Round, CanonicalVoteToStep(&vote), vote.Timestamp.UnixNano()}, nil
+			return HRSTKey{vote.Height, vote.Round, CanonicalVoteToStep(&vote), vote.Timestamp.UnixNano()},
+				vote.ChainID, nil
 		}
 	}
 
-	return HRSTKey{0, 0, 0, 0}, errors.New("could not UnpackHRS from sign bytes")
+	return HRSTKey{0, 0, 0, 0}, "", errors.New("could not UnpackHRS from sign bytes")
 }
@@ -81,7 +81,7 @@ func WaitAndTerminate(logger cometlog.Logger, services []cometservice.Service, p
 	}
 	cometos.TrapSignal(logger, func() {
 		if err := os.Remove(pidFilePath); err != nil {
-			fmt.Printf("Error removing lock file: %v\n", err)
+			logger.Error("Error removing lock file", "file", pidFilePath, "err", err)
 		}
 		for _, service := range services {
 			err := service.Stop()
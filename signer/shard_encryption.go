@@ -0,0 +1,169 @@
+package signer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// ShardEncryptionSchemePassphrase encrypts shards with AES-256-GCM, using a key derived from a passphrase with scrypt.
+const ShardEncryptionSchemePassphrase = "scrypt-aes-256-gcm"
+
+const (
+	scryptN       = 1 << 15
+	scryptR       = 8
+	scryptP       = 1
+	scryptSaltLen = 16
+	scryptKeyLen  = 32
+)
+
+var errShardEncrypted = errors.New("key shard is encrypted")
+
+// EncryptedShard is the private shard of a key shard file, encrypted at rest.
+type EncryptedShard struct {
+	// Scheme identifies the ShardEncryption that encrypted the shard.
+	Scheme string `json:"scheme"`
+	// Params are specific to the scheme, e.g. the salt of the key derivation.
+	Params     json.RawMessage `json:"params,omitempty"`
+	Ciphertext []byte          `json:"ciphertext"`
+}
+
+// ShardEncryption encrypts the private shard of key shard files at rest. Besides the passphrase encryption,
+// it can be implemented by backends that hold the key themselves, such as a KMS or Vault transit.
+type ShardEncryption interface {
+	Scheme() string
+	Encrypt(plaintext []byte) (*EncryptedShard, error)
+	Decrypt(shard *EncryptedShard) ([]byte, error)
+}
+
+type passphraseShardEncryption struct {
+	passphrase []byte
+}
+
+type passphraseShardParams struct {
+	Salt  []byte `json:"salt"`
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	Nonce []byte `json:"nonce"`
+}
+
+// NewPassphraseShardEncryption returns the encryption of shards with a key derived from the passphrase.
+func NewPassphraseShardEncryption(passphrase []byte) (ShardEncryption, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("shard passphrase cannot be empty")
+	}
+	return &passphraseShardEncryption{passphrase: passphrase}, nil
+}
+
+func (e *passphraseShardEncryption) Scheme() string {
+	return ShardEncryptionSchemePassphrase
+}
+
+func (e *passphraseShardEncryption) aead(params passphraseShardParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key(e.passphrase, params.Salt, params.N, params.R, params.P, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive shard key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (e *passphraseShardEncryption) Encrypt(plaintext []byte) (*EncryptedShard, error) {
+	params := passphraseShardParams{
+		Salt: make([]byte, scryptSaltLen),
+		N:    scryptN,
+		R:    scryptR,
+		P:    scryptP,
+	}
+	if _, err := rand.Read(params.Salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := e.aead(params)
+	if err != nil {
+		return nil, err
+	}
+	params.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(params.Nonce); err != nil {
+		return nil, err
+	}
+
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedShard{
+		Scheme:     ShardEncryptionSchemePassphrase,
+		Params:     paramsJSON,
+		Ciphertext: aead.Seal(nil, params.Nonce, plaintext, nil),
+	}, nil
+}
+
+func (e *passphraseShardEncryption) Decrypt(shard *EncryptedShard) ([]byte, error) {
+	var params passphraseShardParams
+	if err := json.Unmarshal(shard.Params, &params); err != nil {
+		return nil, fmt.Errorf("invalid shard encryption params: %w", err)
+	}
+
+	aead, err := e.aead(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid shard encryption nonce size: %d", len(params.Nonce))
+	}
+
+	plaintext, err := aead.Open(nil, params.Nonce, shard.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt key shard, the passphrase is wrong or the file is corrupted")
+	}
+	return plaintext, nil
+}
+
+// Encrypted returns true if the private shard of the key is encrypted.
+func (key *CosignerEd25519Key) Encrypted() bool {
+	return key.EncryptedShard != nil
+}
+
+// Encrypt encrypts the private shard of the key, so that it is only written encrypted to shard files.
+func (key *CosignerEd25519Key) Encrypt(enc ShardEncryption) error {
+	shard, err := enc.Encrypt(key.PrivateShard)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt key shard: %w", err)
+	}
+	key.EncryptedShard = shard
+	key.PrivateShard = nil
+	return nil
+}
+
+// Decrypt decrypts the private shard of a key loaded from an encrypted shard file, in memory only.
+// It does nothing if the shard is not encrypted, and fails if it is encrypted and enc is nil.
+func (key *CosignerEd25519Key) Decrypt(enc ShardEncryption) error {
+	if key.EncryptedShard == nil {
+		return nil
+	}
+	if enc == nil {
+		return fmt.Errorf("%w, the shard passphrase is required to load it", errShardEncrypted)
+	}
+	if key.EncryptedShard.Scheme != enc.Scheme() {
+		return fmt.Errorf("%w with %s, cannot decrypt it with %s", errShardEncrypted, key.EncryptedShard.Scheme, enc.Scheme())
+	}
+
+	plaintext, err := enc.Decrypt(key.EncryptedShard)
+	if err != nil {
+		return err
+	}
+	key.PrivateShard = plaintext
+	key.EncryptedShard = nil
+	return nil
+}
@@ -0,0 +1,93 @@
+package signer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosignerEd25519KeyEncryption(t *testing.T) {
+	key := CreateCosignerEd25519ShardsFromSeed([]byte("horcrux-test-seed"), 2, 3)[0]
+	privateShard := key.PrivateShard
+
+	enc, err := NewPassphraseShardEncryption([]byte("correct horse battery staple"))
+	require.NoError(t, err)
+	require.NoError(t, key.Encrypt(enc))
+
+	file := filepath.Join(t.TempDir(), "test_shard.json")
+	require.NoError(t, WriteCosignerEd25519ShardFile(key, file))
+
+	// the private shard is not written in plaintext.
+	contents, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.NotContains(t, string(contents), "privateShard")
+
+	loaded, err := LoadCosignerEd25519Key(file)
+	require.NoError(t, err)
+	require.True(t, loaded.Encrypted())
+	require.Empty(t, loaded.PrivateShard)
+	require.Equal(t, key.PubKey, loaded.PubKey)
+	require.Equal(t, key.ID, loaded.ID)
+
+	require.ErrorIs(t, loaded.Decrypt(nil), errShardEncrypted)
+
+	wrong, err := NewPassphraseShardEncryption([]byte("wrong"))
+	require.NoError(t, err)
+	require.ErrorContains(t, loaded.Decrypt(wrong), "passphrase is wrong")
+	require.True(t, loaded.Encrypted())
+
+	require.NoError(t, loaded.Decrypt(enc))
+	require.False(t, loaded.Encrypted())
+	require.Equal(t, privateShard, loaded.PrivateShard)
+
+	// a key that is not encrypted is loaded as is.
+	require.NoError(t, loaded.Decrypt(nil))
+	require.Equal(t, privateShard, loaded.PrivateShard)
+
+	_, err = NewPassphraseShardEncryption(nil)
+	require.Error(t, err)
+}
+
+func TestNewThresholdSignerSoftEncrypted(t *testing.T) {
+	keys := CreateCosignerEd25519ShardsFromSeed([]byte("horcrux-test-seed"), 2, 3)
+
+	enc, err := NewPassphraseShardEncryption([]byte("correct horse battery staple"))
+	require.NoError(t, err)
+	require.NoError(t, keys[0].Encrypt(enc))
+
+	config := &RuntimeConfig{
+		HomeDir: t.TempDir(),
+		Config: Config{
+			ThresholdModeConfig: &ThresholdModeConfig{
+				Threshold: 2,
+				Cosigners: CosignersConfig{{ShardID: 1}, {ShardID: 2}, {ShardID: 3}},
+			},
+		},
+	}
+	require.NoError(t, WriteCosignerEd25519ShardFile(keys[0], config.KeyFilePathCosigner(testChainID)))
+
+	_, err = NewThresholdSignerSoft(config, 1, testChainID)
+	require.ErrorIs(t, err, errShardEncrypted)
+
+	config.ShardEncryption = enc
+	s, err := NewThresholdSignerSoft(config, 1, testChainID)
+	require.NoError(t, err)
+	require.Equal(t, keys[0].PubKey.Bytes(), s.PubKey())
+}
+
+func TestPassphraseShardEncryptionTampered(t *testing.T) {
+	enc, err := NewPassphraseShardEncryption([]byte("correct horse battery staple"))
+	require.NoError(t, err)
+
+	shard, err := enc.Encrypt([]byte("shard"))
+	require.NoError(t, err)
+	require.Equal(t, ShardEncryptionSchemePassphrase, shard.Scheme)
+	require.True(t, strings.Contains(string(shard.Params), `"salt"`))
+
+	shard.Ciphertext[0] ^= 0xff
+	_, err = enc.Decrypt(shard)
+	require.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package signer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// shardFileVersion is the version of the format of the Ed25519 shard files written by horcrux.
+// Shard files without a version were written before the checksum was added.
+const shardFileVersion = 1
+
+var errShardFileCorrupt = errors.New("shard file corrupt")
+
+// shardFileHeader is the version and checksum written along with the fields of the key in a shard file.
+type shardFileHeader struct {
+	Version  int    `json:"version"`
+	Checksum string `json:"checksum"`
+}
+
+// shardChecksum is the hex encoded SHA-256 of the canonical JSON encoding of the key,
+// without the version and checksum.
+func shardChecksum(key *CosignerEd25519Key) (string, []byte, error) {
+	keyBytes, err := json.Marshal(key)
+	if err != nil {
+		return "", nil, err
+	}
+	sum := sha256.Sum256(keyBytes)
+	return hex.EncodeToString(sum[:]), keyBytes, nil
+}
+
+// marshalShardFile encodes the key with the current shard file version and its checksum.
+func marshalShardFile(key *CosignerEd25519Key) ([]byte, error) {
+	checksum, keyBytes, err := shardChecksum(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(keyBytes, &fields); err != nil {
+		return nil, err
+	}
+	fields["version"], _ = json.Marshal(shardFileVersion)
+	fields["checksum"], _ = json.Marshal(checksum)
+
+	return json.Marshal(fields)
+}
+
+// unmarshalShardFile decodes a shard file and verifies its checksum. legacy is true for a shard file
+// written before the version and checksum were added, which has nothing to verify.
+func unmarshalShardFile(file string, bz []byte) (key CosignerEd25519Key, legacy bool, err error) {
+	var header shardFileHeader
+	if err := json.Unmarshal(bz, &header); err != nil {
+		return key, false, fmt.Errorf("%w: %s: %v", errShardFileCorrupt, file, err)
+	}
+	if err := json.Unmarshal(bz, &key); err != nil {
+		return key, false, fmt.Errorf("%w: %s: %v", errShardFileCorrupt, file, err)
+	}
+
+	switch header.Version {
+	case 0:
+		if header.Checksum != "" {
+			return key, false, fmt.Errorf("%w: %s: checksum without a version", errShardFileCorrupt, file)
+		}
+		return key, true, nil
+	case shardFileVersion:
+	default:
+		return key, false, fmt.Errorf("unsupported shard file version %d: %s, upgrade horcrux", header.Version, file)
+	}
+
+	checksum, _, err := shardChecksum(&key)
+	if err != nil {
+		return key, false, fmt.Errorf("%w: %s: %v", errShardFileCorrupt, file, err)
+	}
+	if checksum != header.Checksum {
+		return key, false, fmt.Errorf("%w: %s: checksum mismatch, restore the shard from a backup", errShardFileCorrupt, file)
+	}
+	return key, false, nil
+}
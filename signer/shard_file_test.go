@@ -0,0 +1,83 @@
+package signer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardFileChecksum(t *testing.T) {
+	key := CreateCosignerEd25519ShardsFromSeed([]byte("horcrux-test-seed"), 2, 3)[0]
+	file := filepath.Join(t.TempDir(), testChainID+"_shard.json")
+	require.NoError(t, WriteCosignerEd25519ShardFile(key, file))
+
+	bz, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var header shardFileHeader
+	require.NoError(t, json.Unmarshal(bz, &header))
+	require.Equal(t, shardFileVersion, header.Version)
+	require.Len(t, header.Checksum, 64)
+
+	loaded, err := LoadCosignerEd25519Key(file)
+	require.NoError(t, err)
+	require.Equal(t, key, loaded)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(bz, &fields))
+
+	// flip a bit of the private shard.
+	privateShard := append([]byte(nil), key.PrivateShard...)
+	privateShard[0] ^= 1
+	fields["privateShard"] = privateShard
+	corrupt, err := json.Marshal(fields)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, corrupt, 0600))
+
+	_, err = LoadCosignerEd25519Key(file)
+	require.ErrorIs(t, err, errShardFileCorrupt)
+	require.ErrorContains(t, err, file)
+	require.ErrorContains(t, err, "checksum mismatch")
+
+	// partial write.
+	require.NoError(t, os.WriteFile(file, bz[:len(bz)/2], 0600))
+	_, err = LoadCosignerEd25519Key(file)
+	require.ErrorIs(t, err, errShardFileCorrupt)
+	require.ErrorContains(t, err, file)
+
+	fields["privateShard"] = key.PrivateShard
+	fields["version"] = shardFileVersion + 1
+	future, err := json.Marshal(fields)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, future, 0600))
+	_, err = LoadCosignerEd25519Key(file)
+	require.ErrorContains(t, err, "unsupported shard file version")
+}
+
+func TestShardFileLegacy(t *testing.T) {
+	key := CreateCosignerEd25519ShardsFromSeed([]byte("horcrux-test-seed"), 2, 3)[0]
+	file := filepath.Join(t.TempDir(), testChainID+"_shard.json")
+
+	// shard file written before the version and checksum were added.
+	legacy, err := json.Marshal(&key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, legacy, 0600))
+
+	loaded, err := LoadCosignerEd25519Key(file)
+	require.NoError(t, err)
+	require.Equal(t, key, loaded)
+
+	// rewritten with a checksum.
+	bz, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var header shardFileHeader
+	require.NoError(t, json.Unmarshal(bz, &header))
+	require.Equal(t, shardFileVersion, header.Version)
+	require.NotEmpty(t, header.Checksum)
+
+	loaded, err = LoadCosignerEd25519Key(file)
+	require.NoError(t, err)
+	require.Equal(t, key, loaded)
+}
@@ -0,0 +1,69 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultShutdownDrainTimeout is used when the shutdownDrainTimeout is not configured.
+const defaultShutdownDrainTimeout = 5 * time.Second
+
+var errShuttingDown = errors.New("cosigner is shutting down")
+
+// requestDrain tracks the requests in flight, so that they can be finished before shutting down.
+type requestDrain struct {
+	mu       sync.Mutex
+	draining bool
+	wg       sync.WaitGroup
+}
+
+// begin registers a new request. It returns false once the drain has started, in which case
+// the request must be refused. Otherwise, done must be called when the request is finished.
+func (d *requestDrain) begin() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return false
+	}
+	d.wg.Add(1)
+	return true
+}
+
+func (d *requestDrain) done() {
+	d.wg.Done()
+}
+
+// drain refuses new requests and waits up to the timeout for the requests in flight to finish.
+// It returns false if they did not finish in time.
+func (d *requestDrain) drain(timeout time.Duration) bool {
+	d.mu.Lock()
+	d.draining = true
+	d.mu.Unlock()
+	return !waitUntilCompleteOrTimeout(&d.wg, timeout)
+}
+
+// unaryServerInterceptor refuses cosigner gRPC requests once the drain has started.
+// The raft transport RPCs are excluded, raft keeps running until the cosigner is stopped.
+func (d *requestDrain) unaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, "/"+proto.CosignerGRPC_ServiceDesc.ServiceName+"/") {
+		return handler(ctx, req)
+	}
+	if !d.begin() {
+		return nil, status.Error(codes.Unavailable, errShuttingDown.Error())
+	}
+	defer d.done()
+	return handler(ctx, req)
+}
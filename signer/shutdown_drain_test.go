@@ -0,0 +1,30 @@
+package signer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestDrain(t *testing.T) {
+	var d requestDrain
+
+	require.True(t, d.begin())
+
+	// the request in flight is not finished in time.
+	require.False(t, d.drain(10*time.Millisecond))
+	require.False(t, d.begin())
+
+	d.done()
+	require.True(t, d.drain(time.Second))
+}
+
+func TestThresholdValidatorSignBlockShuttingDown(t *testing.T) {
+	pv := &ThresholdValidator{}
+	require.True(t, pv.signs.drain(time.Second))
+
+	_, _, err := pv.SignBlock(context.Background(), testChainID, &Block{Height: 1})
+	require.ErrorIs(t, err, errShuttingDown)
+}
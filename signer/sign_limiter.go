@@ -0,0 +1,140 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+const defaultSignConcurrencyMaxQueued = 100
+
+var errSignQueueFull = errors.New("too many sign requests queued")
+
+// SignConcurrencyConfig limits the number of blocks that are signed at the same time, so that a burst
+// of sign requests across many chains does not slow every one of them down past the consensus timeouts.
+type SignConcurrencyConfig struct {
+	// MaxConcurrentSigns is the number of sign requests processed at the same time, the others wait in a queue.
+	MaxConcurrentSigns int `yaml:"maxConcurrentSigns"`
+	// PerChain applies the limits to each chain separately, rather than to all of the chains together.
+	PerChain bool `yaml:"perChain,omitempty"`
+	// MaxQueued is the number of sign requests that can wait in the queue, the requests beyond it are refused.
+	// Defaults to 100.
+	MaxQueued int `yaml:"maxQueued,omitempty"`
+	// QueueTimeout is how long a sign request waits in the queue before it gives up. Defaults to the grpcTimeout.
+	QueueTimeout string `yaml:"queueTimeout,omitempty"`
+}
+
+func (c *SignConcurrencyConfig) Validate() error {
+	if c.MaxConcurrentSigns < 1 {
+		return fmt.Errorf("signConcurrency maxConcurrentSigns (%d) must be at least 1", c.MaxConcurrentSigns)
+	}
+	if c.MaxQueued < 0 {
+		return fmt.Errorf("signConcurrency maxQueued (%d) must not be negative", c.MaxQueued)
+	}
+	if c.QueueTimeout != "" {
+		if d, err := time.ParseDuration(c.QueueTimeout); err != nil {
+			return fmt.Errorf("invalid signConcurrency queueTimeout: %w", err)
+		} else if d <= 0 {
+			return fmt.Errorf("signConcurrency queueTimeout (%s) must be greater than 0", d)
+		}
+	}
+	return nil
+}
+
+// signLimiter is a semaphore of the sign requests in flight, for all chains or for each chain,
+// with a bounded queue of the requests waiting for it.
+type signLimiter struct {
+	maxConcurrent int64
+	maxQueued     int
+	queueTimeout  time.Duration
+	perChain      bool
+
+	mu     sync.Mutex
+	sems   map[string]*semaphore.Weighted
+	queued map[string]int
+}
+
+// newSignLimiter returns nil if the sign concurrency is not configured, in which case it is not limited.
+// The queued requests give up after grpcTimeout, unless a queue timeout is configured.
+func newSignLimiter(config *RuntimeConfig, grpcTimeout time.Duration) *signLimiter {
+	if config == nil || config.Config.ThresholdModeConfig == nil ||
+		config.Config.ThresholdModeConfig.SignConcurrency == nil {
+		return nil
+	}
+	c := config.Config.ThresholdModeConfig.SignConcurrency
+
+	// Validated prior in ValidateThresholdModeConfig
+	maxQueued := defaultSignConcurrencyMaxQueued
+	if c.MaxQueued > 0 {
+		maxQueued = c.MaxQueued
+	}
+	queueTimeout := grpcTimeout
+	if c.QueueTimeout != "" {
+		// Validated prior in ValidateThresholdModeConfig
+		queueTimeout, _ = time.ParseDuration(c.QueueTimeout)
+	}
+	return &signLimiter{
+		maxConcurrent: int64(c.MaxConcurrentSigns),
+		maxQueued:     maxQueued,
+		queueTimeout:  queueTimeout,
+		perChain:      c.PerChain,
+		sems:          make(map[string]*semaphore.Weighted),
+		queued:        make(map[string]int),
+	}
+}
+
+// acquire waits until the sign request of the chain can be processed, for at most the queue timeout
+// or until ctx is done. The request is refused if maxQueued requests are already waiting.
+// Otherwise, the returned release must be called when the request is finished.
+func (l *signLimiter) acquire(ctx context.Context, chainID string) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	key := ""
+	if l.perChain {
+		key = chainID
+	}
+
+	l.mu.Lock()
+	sem, ok := l.sems[key]
+	if !ok {
+		sem = semaphore.NewWeighted(l.maxConcurrent)
+		l.sems[key] = sem
+	}
+	if !sem.TryAcquire(1) {
+		if l.queued[key] >= l.maxQueued {
+			l.mu.Unlock()
+			totalSignQueueFull.WithLabelValues(chainID).Inc()
+			return nil, fmt.Errorf("%w, %d sign requests are already waiting for the %d signs in flight",
+				errSignQueueFull, l.maxQueued, l.maxConcurrent)
+		}
+		l.queued[key]++
+		l.mu.Unlock()
+
+		// the chain node requests have no deadline of their own, so that the wait is bounded here.
+		queueCtx, cancel := context.WithTimeout(ctx, l.queueTimeout)
+		signRequestsQueued.WithLabelValues(chainID).Add(1)
+		err := sem.Acquire(queueCtx, 1)
+		signRequestsQueued.WithLabelValues(chainID).Add(-1)
+		cancel()
+
+		l.mu.Lock()
+		l.queued[key]--
+		if err != nil {
+			l.mu.Unlock()
+			return nil, fmt.Errorf("timed out waiting for the %d signs in flight: %w", l.maxConcurrent, err)
+		}
+	}
+	l.mu.Unlock()
+
+	signRequestsInFlight.WithLabelValues(chainID).Add(1)
+	return func() {
+		signRequestsInFlight.WithLabelValues(chainID).Add(-1)
+		sem.Release(1)
+	}, nil
+}
@@ -0,0 +1,154 @@
+package signer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSignLimiter(maxConcurrentSigns, maxQueued int, perChain bool) *signLimiter {
+	return newSignLimiter(&RuntimeConfig{Config: Config{ThresholdModeConfig: &ThresholdModeConfig{
+		SignConcurrency: &SignConcurrencyConfig{
+			MaxConcurrentSigns: maxConcurrentSigns,
+			MaxQueued:          maxQueued,
+			PerChain:           perChain,
+		},
+	}}}, time.Minute)
+}
+
+func TestSignConcurrencyConfig(t *testing.T) {
+	require.NoError(t, (&SignConcurrencyConfig{MaxConcurrentSigns: 4}).Validate())
+	require.EqualError(t, (&SignConcurrencyConfig{}).Validate(),
+		"signConcurrency maxConcurrentSigns (0) must be at least 1")
+	require.EqualError(t, (&SignConcurrencyConfig{MaxConcurrentSigns: 1, MaxQueued: -1}).Validate(),
+		"signConcurrency maxQueued (-1) must not be negative")
+	require.EqualError(t, (&SignConcurrencyConfig{MaxConcurrentSigns: 1, QueueTimeout: "0s"}).Validate(),
+		"signConcurrency queueTimeout (0s) must be greater than 0")
+	require.ErrorContains(t, (&SignConcurrencyConfig{MaxConcurrentSigns: 1, QueueTimeout: "soon"}).Validate(),
+		"invalid signConcurrency queueTimeout")
+
+	disabled := &RuntimeConfig{Config: Config{ThresholdModeConfig: &ThresholdModeConfig{}}}
+	require.Nil(t, newSignLimiter(disabled, time.Second))
+	require.Equal(t, defaultSignConcurrencyMaxQueued, newTestSignLimiter(1, 0, false).maxQueued)
+
+	// the queue timeout defaults to the grpc timeout.
+	require.Equal(t, time.Minute, newTestSignLimiter(1, 0, false).queueTimeout)
+	l := newSignLimiter(&RuntimeConfig{Config: Config{ThresholdModeConfig: &ThresholdModeConfig{
+		SignConcurrency: &SignConcurrencyConfig{MaxConcurrentSigns: 1, QueueTimeout: "3s"},
+	}}}, time.Minute)
+	require.Equal(t, 3*time.Second, l.queueTimeout)
+}
+
+func TestSignLimiter(t *testing.T) {
+	const chainID = "limiter-1"
+	l := newTestSignLimiter(1, 1, false)
+
+	release, err := l.acquire(context.Background(), chainID)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, testutil.ToFloat64(signRequestsInFlight.prom.WithLabelValues(chainID)))
+
+	// the next request waits in the queue.
+	acquired := make(chan func())
+	go func() {
+		release, err := l.acquire(context.Background(), chainID)
+		if err == nil {
+			acquired <- release
+		}
+	}()
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(signRequestsQueued.prom.WithLabelValues(chainID)) == 1
+	}, time.Second, time.Millisecond)
+
+	// the requests beyond the queue are refused, for any chain since the limit is shared.
+	_, err = l.acquire(context.Background(), "limiter-2")
+	require.ErrorIs(t, err, errSignQueueFull)
+	require.Equal(t, 1.0, testutil.ToFloat64(totalSignQueueFull.prom.WithLabelValues("limiter-2")))
+
+	// the queued request proceeds once the request in flight is finished.
+	release()
+	release = <-acquired
+	require.Equal(t, 0.0, testutil.ToFloat64(signRequestsQueued.prom.WithLabelValues(chainID)))
+	require.Equal(t, 1.0, testutil.ToFloat64(signRequestsInFlight.prom.WithLabelValues(chainID)))
+
+	// a queued request gives up at its deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx, chainID)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 0, l.queued[""])
+
+	release()
+	require.Equal(t, 0.0, testutil.ToFloat64(signRequestsInFlight.prom.WithLabelValues(chainID)))
+}
+
+func TestSignLimiterPerChain(t *testing.T) {
+	l := newTestSignLimiter(1, 1, true)
+
+	release1, err := l.acquire(context.Background(), "per-chain-1")
+	require.NoError(t, err)
+	defer release1()
+
+	// each chain has its own limit.
+	release2, err := l.acquire(context.Background(), "per-chain-2")
+	require.NoError(t, err)
+	defer release2()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx, "per-chain-1")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// a nil limiter does not limit.
+	var unlimited *signLimiter
+	release, err := unlimited.acquire(context.Background(), "per-chain-1")
+	require.NoError(t, err)
+	release()
+}
+
+func TestThresholdValidatorQueuedSignVoteGivesUp(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	cosigners[0].config.Config.ThresholdModeConfig.SignConcurrency = &SignConcurrencyConfig{
+		MaxConcurrentSigns: 1,
+		QueueTimeout:       "50ms",
+	}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		time.Second,
+		1,
+		cosigners[0],
+		nil,
+		&MockLeader{id: 1},
+	)
+	defer validator.Stop()
+
+	// another sign request is in flight and never finishes.
+	release, err := validator.signLimiter.acquire(context.Background(), testChainID)
+	require.NoError(t, err)
+	defer release()
+
+	// the chain node request has no deadline, but does not wait in the queue forever.
+	done := make(chan error)
+	go func() {
+		done <- validator.SignVote(testChainID, &cometproto.Vote{
+			Height:    1,
+			Type:      cometproto.PrevoteType,
+			Timestamp: time.Now(),
+		})
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued sign vote did not give up")
+	}
+	require.Equal(t, 0, validator.signLimiter.queued[""])
+}
@@ -250,18 +250,18 @@ func (signState *SignState) save(jsonBytes []byte) {
 // It panics if the HRS matches the arguments, there's a SignBytes, but no Signature.
 func (signState *SignState) CheckHRS(hrst HRSTKey) (bool, error) {
 	if signState.Height > hrst.Height {
-		return false, fmt.Errorf("height regression. Got %v, last height %v", hrst.Height, signState.Height)
+		return false, newRegressionError("height regression. Got %v, last height %v", hrst.Height, signState.Height)
 	}
 
 	if signState.Height == hrst.Height {
 		if signState.Round > hrst.Round {
-			return false, fmt.Errorf("round regression at height %v. Got %v, last round %v",
+			return false, newRegressionError("round regression at height %v. Got %v, last round %v",
 				hrst.Height, hrst.Round, signState.Round)
 		}
 
 		if signState.Round == hrst.Round {
 			if signState.Step > hrst.Step {
-				return false, fmt.Errorf("step regression at height %v round %v. Got %v, last step %v",
+				return false, newRegressionError("step regression at height %v round %v. Got %v, last step %v",
 					hrst.Height, hrst.Round, hrst.Step, signState.Step)
 			} else if signState.Step == hrst.Step {
 				if signState.SignBytes != nil {
@@ -277,6 +277,18 @@ func (signState *SignState) CheckHRS(hrst HRSTKey) (bool, error) {
 	return false, nil
 }
 
+// RegressionError is returned when the HRS to sign is lower than the sign state, since signing it
+// could be a double sign.
+type RegressionError struct {
+	msg string
+}
+
+func (e *RegressionError) Error() string { return e.msg }
+
+func newRegressionError(format string, a ...any) *RegressionError {
+	return &RegressionError{msg: fmt.Sprintf(format, a...)}
+}
+
 type SameHRSError struct {
 	msg string
 }
@@ -293,7 +305,7 @@ func (signState *SignState) GetErrorIfLessOrEqual(height int64, round int64, ste
 	hrs := HRSKey{Height: height, Round: round, Step: step}
 	signStateHRS := signState.HRSKey()
 	if signStateHRS.GreaterThan(hrs) {
-		return errors.New("regression not allowed")
+		return newRegressionError("regression not allowed")
 	}
 
 	if hrs == signStateHRS {
@@ -422,14 +434,14 @@ func checkVoteOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) error {
 	lastVoteBlockID := lastVote.GetBlockID()
 	newVoteBlockID := newVote.GetBlockID()
 	if newVoteBlockID == nil && lastVoteBlockID != nil {
-		return errors.New("already signed vote with non-nil BlockID. refusing to sign vote on nil BlockID")
+		return &ConflictingDataError{msg: "already signed vote with non-nil BlockID. refusing to sign vote on nil BlockID"}
 	}
 	if newVoteBlockID != nil && lastVoteBlockID == nil {
-		return errors.New("already signed vote with nil BlockID. refusing to sign vote on non-nil BlockID")
+		return &ConflictingDataError{msg: "already signed vote with nil BlockID. refusing to sign vote on non-nil BlockID"}
 	}
 	if !bytes.Equal(lastVoteBlockID.GetHash(), newVoteBlockID.GetHash()) {
-		return fmt.Errorf("differing block IDs - last Vote: %s, new Vote: %s",
-			lastVoteBlockID.GetHash(), newVoteBlockID.GetHash())
+		return &ConflictingDataError{msg: fmt.Sprintf("differing block IDs - last Vote: %s, new Vote: %s",
+			lastVoteBlockID.GetHash(), newVoteBlockID.GetHash())}
 	}
 	return newConflictingDataError(lastSignBytes, newSignBytes)
 }
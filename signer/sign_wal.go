@@ -0,0 +1,140 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	cometbytes "github.com/cometbft/cometbft/libs/bytes"
+)
+
+// signWALEntry is the block that the leader is about to sign for a chain.
+type signWALEntry struct {
+	Height    int64               `json:"height"`
+	Round     int64               `json:"round"`
+	Step      int8                `json:"step"`
+	SignBytes cometbytes.HexBytes `json:"signbytes"`
+}
+
+func (e *signWALEntry) HRSKey() HRSKey {
+	return HRSKey{Height: e.Height, Round: e.Round, Step: e.Step}
+}
+
+// signWAL is the write-ahead entry of the sign state. The leader persists each block to it before requesting
+// the signature parts, while the sign state is only written once the signature is combined, asynchronously.
+// If the cosigner crashes after returning the signature but before the sign state is written, the entry keeps it
+// from signing a conflicting block at the same HRS, or a lower HRS, after a restart. The entry is local to each
+// cosigner, the share sign states of the other cosigners protect against a new leader.
+type signWAL struct {
+	mu       sync.Mutex
+	filePath string
+	entry    *signWALEntry
+}
+
+// loadSignWAL loads the write-ahead entry from filePath, which is empty if the file does not exist.
+func loadSignWAL(filePath string) (*signWAL, error) {
+	w := &signWAL{filePath: filePath}
+
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return w, nil
+		}
+		return nil, err
+	}
+
+	var entry signWALEntry
+	if err := json.Unmarshal(bz, &entry); err != nil {
+		return nil, fmt.Errorf("failed to read sign write-ahead entry %s: %w", filePath, err)
+	}
+	w.entry = &entry
+
+	return w, nil
+}
+
+// pending returns the entry if it is ahead of the committed sign state, in which case a signature may have
+// been returned for it without the sign state being written.
+func (w *signWAL) pending(committed HRSKey) *signWALEntry {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.entry == nil || !w.entry.HRSKey().GreaterThan(committed) {
+		return nil
+	}
+	entry := *w.entry
+	return &entry
+}
+
+// begin persists the block as the write-ahead entry before it is signed. It returns a RegressionError if the
+// entry is already ahead of the block, and a ConflictingDataError if the entry is for the same HRS with sign
+// bytes that differ by more than the timestamp.
+func (w *signWAL) begin(block *Block) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	hrs := block.HRSKey()
+	if w.entry != nil {
+		entryHRS := w.entry.HRSKey()
+		if entryHRS.GreaterThan(hrs) {
+			return newRegressionError("sign write-ahead entry is ahead. Got %d/%d/%d, pending %d/%d/%d",
+				hrs.Height, hrs.Round, hrs.Step, entryHRS.Height, entryHRS.Round, entryHRS.Step)
+		}
+		if entryHRS == hrs {
+			if !bytes.Equal(w.entry.SignBytes, block.SignBytes) {
+				if err := onlyDifferByTimestamp(hrs.Step, w.entry.SignBytes, block.SignBytes); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	entry := &signWALEntry{
+		Height:    hrs.Height,
+		Round:     hrs.Round,
+		Step:      hrs.Step,
+		SignBytes: block.SignBytes,
+	}
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := writeFileSync(w.filePath, bz); err != nil {
+		return fmt.Errorf("failed to persist sign write-ahead entry: %w", err)
+	}
+	w.entry = entry
+
+	return nil
+}
+
+// writeFileSync atomically replaces the file with the data, and syncs it to disk before returning.
+func writeFileSync(file string, data []byte) error {
+	tmpFile := file + ".tmp"
+	f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile, file); err != nil {
+		return err
+	}
+
+	// sync the directory so that the rename is durable.
+	dir, err := os.Open(filepath.Dir(file))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
@@ -0,0 +1,139 @@
+package signer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	comet "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignWAL(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "sign_wal.json")
+
+	wal, err := loadSignWAL(filePath)
+	require.NoError(t, err)
+	require.Nil(t, wal.pending(HRSKey{}))
+
+	voteSignBytes := func(blockID string) []byte {
+		return comet.VoteSignBytes(testChainID, &cometproto.Vote{
+			Height:  2,
+			Round:   1,
+			Type:    cometproto.PrevoteType,
+			BlockID: cometproto.BlockID{Hash: tmhash.Sum([]byte(blockID))},
+		})
+	}
+
+	block := &Block{Height: 2, Round: 1, Step: stepPrevote, SignBytes: voteSignBytes("block A")}
+	require.NoError(t, wal.begin(block))
+
+	// the same block can be signed again at the same HRS.
+	require.NoError(t, wal.begin(block))
+
+	// the entry survives a restart.
+	wal, err = loadSignWAL(filePath)
+	require.NoError(t, err)
+	require.Equal(t, block.HRSKey(), wal.pending(HRSKey{Height: 1}).HRSKey())
+	require.Nil(t, wal.pending(block.HRSKey()))
+
+	err = wal.begin(&Block{Height: 2, Round: 1, Step: stepPrevote, SignBytes: voteSignBytes("block B")})
+	require.ErrorContains(t, err, "differing block IDs")
+
+	var regressionErr *RegressionError
+	err = wal.begin(&Block{Height: 2, Round: 0, Step: stepPrecommit, SignBytes: voteSignBytes("block A")})
+	require.ErrorAs(t, err, &regressionErr)
+
+	require.NoError(t, wal.begin(&Block{Height: 3, Round: 0, Step: stepPropose, SignBytes: voteSignBytes("block B")}))
+}
+
+// signCountTestCosigner wraps a cosigner to count the signature parts requested from it.
+type signCountTestCosigner struct {
+	Cosigner
+
+	signs atomic.Int32
+}
+
+func (c *signCountTestCosigner) SetNoncesAndSign(
+	ctx context.Context,
+	req CosignerSetNoncesAndSignRequest,
+) (*CosignerSignResponse, error) {
+	c.signs.Add(1)
+	return c.Cosigner.SetNoncesAndSign(ctx, req)
+}
+
+func TestThresholdValidatorSignWALCrash(t *testing.T) {
+	cosigners, pubKey := getTestLocalCosigners(t, 2, 2)
+	defer cosigners[1].waitForSignStatesToFlushToDisk()
+
+	newValidator := func(peer Cosigner) *ThresholdValidator {
+		leader := &MockLeader{id: 1}
+		validator := NewThresholdValidator(
+			cometlog.NewNopLogger(),
+			cosigners[0].config,
+			2,
+			time.Second,
+			1,
+			cosigners[0],
+			[]Cosigner{peer},
+			leader,
+		)
+		leader.leader = validator
+		require.NoError(t, validator.LoadSignStateIfNecessary(testChainID))
+		return validator
+	}
+
+	validator := newValidator(cosigners[1])
+
+	signStateFile := cosigners[0].config.PrivValStateFile(testChainID)
+	signStateBefore, err := os.ReadFile(signStateFile)
+	require.NoError(t, err)
+
+	vote := cometproto.Vote{
+		Height:    1,
+		Round:     0,
+		Type:      cometproto.PrevoteType,
+		Timestamp: time.Now(),
+		BlockID:   cometproto.BlockID{Hash: tmhash.Sum([]byte("block A"))},
+	}
+	require.NoError(t, validator.SignVote(testChainID, &vote))
+	require.True(t, pubKey.VerifySignature(comet.VoteSignBytes(testChainID, &vote), vote.Signature))
+	validator.Stop()
+
+	// crash after the signature is returned, before the sign state is written.
+	require.NoError(t, os.WriteFile(signStateFile, signStateBefore, 0600))
+
+	peer := &signCountTestCosigner{Cosigner: cosigners[1]}
+	validator = newValidator(peer)
+	defer validator.Stop()
+
+	conflicting := cometproto.Vote{
+		Height:    1,
+		Round:     0,
+		Type:      cometproto.PrevoteType,
+		Timestamp: time.Now(),
+		BlockID:   cometproto.BlockID{Hash: tmhash.Sum([]byte("block B"))},
+	}
+	err = validator.SignVote(testChainID, &conflicting)
+	require.ErrorContains(t, err, "differing block IDs")
+	require.Empty(t, conflicting.Signature)
+
+	// the conflicting block is refused before any signature part is requested.
+	require.Zero(t, peer.signs.Load())
+
+	next := cometproto.Vote{
+		Height:    2,
+		Round:     0,
+		Type:      cometproto.PrevoteType,
+		Timestamp: time.Now(),
+		BlockID:   cometproto.BlockID{Hash: tmhash.Sum([]byte("block C"))},
+	}
+	require.NoError(t, validator.SignVote(testChainID, &next))
+	require.True(t, pubKey.VerifySignature(comet.VoteSignBytes(testChainID, &next), next.Signature))
+}
@@ -30,6 +30,10 @@ func NewThresholdSignerSoft(config *RuntimeConfig, id int, chainID string) (*Thr
 		return nil, fmt.Errorf("error reading cosigner key: %s", err)
 	}
 
+	if err := key.Decrypt(config.ShardEncryption); err != nil {
+		return nil, fmt.Errorf("error decrypting cosigner key %s: %w", keyFile, err)
+	}
+
 	if key.ID != id {
 		return nil, fmt.Errorf("key shard ID (%d) in (%s) does not match cosigner ID (%d)", key.ID, keyFile, id)
 	}
@@ -2,9 +2,12 @@ package signer
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -14,10 +17,16 @@ import (
 	cometrpcjsontypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	comet "github.com/cometbft/cometbft/types"
 	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var _ PrivValidator = &ThresholdValidator{}
 
+var errBlockTimestampOutOfWindow = errors.New("block timestamp is outside of the block timestamp window")
+
+var errInvalidCombinedSignature = errors.New("combined signature is not valid")
+
 type ThresholdValidator struct {
 	config *RuntimeConfig
 
@@ -27,6 +36,9 @@ type ThresholdValidator struct {
 
 	chainState sync.Map
 
+	// serializes the initialization of missing sign states
+	signStateInitMutex sync.Mutex
+
 	// our own cosigner
 	myCosigner *LocalCosigner
 
@@ -39,7 +51,31 @@ type ThresholdValidator struct {
 
 	pendingDiskWG sync.WaitGroup
 
+	// signs are the sign requests in flight, drained on shutdown.
+	signs requestDrain
+
 	maxWaitForSameBlockAttempts int
+
+	// tracks peers with a clock ahead of ours
+	peerClocks *peerClockTracker
+
+	// transfers leadership when we are slow to sign as the leader, nil if disabled
+	rebalancer *leaderRebalancer
+
+	// refuses to sign while paused for maintenance
+	pause *signingPause
+
+	// records every sign decision, nil if disabled
+	audit *auditLog
+
+	// notifies of double signs prevented and repeated sign failures, nil if disabled
+	notifications *notifications
+
+	// limits the blocks signed at the same time, nil if disabled
+	signLimiter *signLimiter
+
+	// tracks which peers answer our nonce and sign requests as the leader
+	peerLiveness *peerLiveness
 }
 
 type ChainSignState struct {
@@ -51,6 +87,9 @@ type ChainSignState struct {
 	// stores the last sign state that we've started progress on
 	lastSignStateInitiated      *SignState
 	lastSignStateInitiatedMutex *sync.Mutex
+
+	// persists the block before it is signed as the leader
+	wal *signWAL
 }
 
 // NewThresholdValidator creates and returns a new ThresholdValidator
@@ -73,6 +112,13 @@ func NewThresholdValidator(
 		myCosigner:                  myCosigner,
 		peerCosigners:               peerCosigners,
 		leader:                      leader,
+		peerClocks:                  newPeerClockTracker(),
+		rebalancer:                  newLeaderRebalancer(config),
+		pause:                       newSigningPause(config.StateDir),
+		audit:                       newAuditLog(config.AuditLogPath()),
+		notifications:               newNotifications(logger, config, myCosigner.GetID()),
+		signLimiter:                 newSignLimiter(config, grpcTimeout),
+		peerLiveness:                newPeerLiveness(logger, threshold, peerCosigners),
 	}
 }
 
@@ -217,6 +263,8 @@ func (pv *ThresholdValidator) notifyBlockSignError(chainID string, hrs HRSKey) {
 // Stop safely shuts down the ThresholdValidator.
 func (pv *ThresholdValidator) Stop() {
 	pv.waitForSignStatesToFlushToDisk()
+
+	pv.myCosigner.stopNoncePools()
 }
 
 // waitForSignStatesToFlushToDisk waits for any sign states to finish writing to disk.
@@ -241,9 +289,11 @@ func (pv *ThresholdValidator) SignVote(chainID string, vote *cometproto.Vote) er
 		Step:      VoteToStep(vote),
 		Timestamp: vote.Timestamp,
 		SignBytes: comet.VoteSignBytes(chainID, vote),
+		BlockID:   &vote.BlockID,
 	}
+	block.Deadline = pv.blockDeadline(block.Timestamp)
 
-	sig, stamp, err := pv.SignBlock(chainID, block)
+	sig, stamp, err := pv.SignBlock(context.Background(), chainID, block)
 
 	vote.Signature = sig
 	vote.Timestamp = stamp
@@ -260,9 +310,12 @@ func (pv *ThresholdValidator) SignProposal(chainID string, proposal *cometproto.
 		Step:      ProposalToStep(proposal),
 		Timestamp: proposal.Timestamp,
 		SignBytes: comet.ProposalSignBytes(chainID, proposal),
+		BlockID:   &proposal.BlockID,
+		POLRound:  int64(proposal.PolRound),
 	}
+	block.Deadline = pv.blockDeadline(block.Timestamp)
 
-	sig, stamp, err := pv.SignBlock(chainID, block)
+	sig, stamp, err := pv.SignBlock(context.Background(), chainID, block)
 
 	proposal.Signature = sig
 	proposal.Timestamp = stamp
@@ -276,6 +329,14 @@ type Block struct {
 	Step      int8
 	SignBytes []byte
 	Timestamp time.Time
+	// Deadline is an optional hint of when the signature is no longer useful to the chain node,
+	// derived from the timestamp with the signDeadline. When set, it bounds the sign context rather
+	// than the fixed grpc timeout alone.
+	Deadline time.Time
+	// BlockID and POLRound are the remaining fields of the canonical proposal or vote,
+	// so that the sign bytes can be reconstructed and verified.
+	BlockID  *cometproto.BlockID
+	POLRound int64
 }
 
 func (block Block) HRSKey() HRSKey {
@@ -296,13 +357,95 @@ func (block Block) HRSTKey() HRSTKey {
 }
 
 func (block Block) toProto() *proto.Block {
-	return &proto.Block{
+	pb := &proto.Block{
 		Height:    block.Height,
 		Round:     block.Round,
 		Step:      int32(block.Step),
 		SignBytes: block.SignBytes,
 		Timestamp: block.Timestamp.UnixNano(),
 	}
+	if !block.Deadline.IsZero() {
+		pb.Deadline = block.Deadline.UnixNano()
+	}
+	if block.BlockID != nil {
+		pb.BlockID = &proto.BlockID{
+			Hash: block.BlockID.Hash,
+			PartSetHeader: &proto.PartSetHeader{
+				Total: block.BlockID.PartSetHeader.Total,
+				Hash:  block.BlockID.PartSetHeader.Hash,
+			},
+		}
+		pb.PolRound = block.POLRound
+	}
+	return pb
+}
+
+// BlockFromProto returns a Block from a proto.Block.
+func BlockFromProto(pb *proto.Block) *Block {
+	block := &Block{
+		Height:    pb.GetHeight(),
+		Round:     pb.GetRound(),
+		Step:      int8(pb.GetStep()),
+		SignBytes: pb.GetSignBytes(),
+		Timestamp: time.Unix(0, pb.GetTimestamp()),
+	}
+	if deadline := pb.GetDeadline(); deadline != 0 {
+		block.Deadline = time.Unix(0, deadline)
+	}
+	if blockID := pb.GetBlockID(); blockID != nil {
+		block.BlockID = &cometproto.BlockID{
+			Hash: blockID.GetHash(),
+			PartSetHeader: cometproto.PartSetHeader{
+				Total: blockID.GetPartSetHeader().GetTotal(),
+				Hash:  blockID.GetPartSetHeader().GetHash(),
+			},
+		}
+		block.POLRound = pb.GetPolRound()
+	}
+	return block
+}
+
+// VerifySignBytes reconstructs the canonical sign bytes of the proposal or vote from the fields of the block,
+// and returns an error if they differ from the sign bytes of the block.
+// Blocks without a BlockID can not be reconstructed and are refused.
+func (block Block) VerifySignBytes(chainID string) error {
+	if block.BlockID == nil {
+		return fmt.Errorf("block %d.%d.%d has no BlockID, its sign bytes can not be verified",
+			block.Height, block.Round, block.Step)
+	}
+
+	var signBytes []byte
+	switch block.Step {
+	case stepPropose:
+		signBytes = comet.ProposalSignBytes(chainID, &cometproto.Proposal{
+			Type:      cometproto.ProposalType,
+			Height:    block.Height,
+			Round:     int32(block.Round),
+			PolRound:  int32(block.POLRound),
+			BlockID:   *block.BlockID,
+			Timestamp: block.Timestamp,
+		})
+	case stepPrevote, stepPrecommit:
+		voteType := cometproto.PrevoteType
+		if block.Step == stepPrecommit {
+			voteType = cometproto.PrecommitType
+		}
+		signBytes = comet.VoteSignBytes(chainID, &cometproto.Vote{
+			Type:      voteType,
+			Height:    block.Height,
+			Round:     int32(block.Round),
+			BlockID:   *block.BlockID,
+			Timestamp: block.Timestamp,
+		})
+	default:
+		return fmt.Errorf("unknown step %d", block.Step)
+	}
+
+	if !bytes.Equal(signBytes, block.SignBytes) {
+		return fmt.Errorf("sign bytes for %d.%d.%d do not match the canonical encoding of the block",
+			block.Height, block.Round, block.Step)
+	}
+	return nil
 }
 
 type BeyondBlockError struct {
@@ -343,6 +486,29 @@ type SameBlockError struct {
 
 func (e *SameBlockError) Error() string { return e.msg }
 
+// isDoubleSignPrevented returns true if err is a refusal to sign because the block regresses or conflicts
+// with the sign state, so that signing it could be a double sign.
+func isDoubleSignPrevented(err error) bool {
+	var (
+		regressionErr  *RegressionError
+		conflictingErr *ConflictingDataError
+	)
+	return errors.As(err, &regressionErr) || errors.As(err, &conflictingErr)
+}
+
+// isSignStateRefusal returns true if err is a refusal to sign because of the sign state, either to prevent
+// a double sign or because the HRS of the block is already signed or being signed, e.g. when several sentries
+// request the same block. Retrying the request can not succeed.
+func isSignStateRefusal(err error) bool {
+	var (
+		sameHRSErr     *SameHRSError
+		beyondBlockErr *BeyondBlockError
+		sameBlockErr   *SameBlockError
+	)
+	return isDoubleSignPrevented(err) || errors.As(err, &sameHRSErr) || errors.As(err, &beyondBlockErr) ||
+		errors.As(err, &sameBlockErr)
+}
+
 func newSameBlockError(chainID string, hrs HRSKey) *SameBlockError {
 	return &SameBlockError{
 		msg: fmt.Sprintf("[%s] Same block: %d.%d.%d",
@@ -350,22 +516,162 @@ func newSameBlockError(chainID string, hrs HRSKey) *SameBlockError {
 	}
 }
 
+// peerPriority returns the configured nonce request priority for the cosigner with the given shard ID.
+func (pv *ThresholdValidator) peerPriority(id int) int {
+	if pv.config == nil || pv.config.Config.ThresholdModeConfig == nil {
+		return 0
+	}
+	for _, c := range pv.config.Config.ThresholdModeConfig.Cosigners {
+		if c.ShardID == id {
+			return c.Priority
+		}
+	}
+	return 0
+}
+
+// peerNonceTiers splits the peer cosigners into the preferred set, which is asked for nonces right away,
+// and the backups, which are only asked when a preferred peer fails or is too slow to respond.
+// The preferred set is the threshold-1 peers with the lowest priority values, plus any peers tied
+// with the last of them. If all peers share a priority, all of them are preferred.
+//
+// Peers with a clock ahead of ours are excluded by moving them to the end of the backups,
+// as long as the remaining peers are still enough to reach the threshold.
+func (pv *ThresholdValidator) peerNonceTiers() (preferred []Cosigner, backups []Cosigner) {
+	need := pv.threshold - 1
+
+	now := time.Now()
+	peers := make([]Cosigner, 0, len(pv.peerCosigners))
+	var excluded []Cosigner
+	for _, peer := range pv.peerCosigners {
+		if pv.peerClocks.excluded(peer.GetID(), now) {
+			excluded = append(excluded, peer)
+		} else {
+			peers = append(peers, peer)
+		}
+	}
+	if len(peers) < need {
+		peers = append(peers, excluded...)
+		excluded = nil
+	}
+
+	preferred, backups = pv.peerNonceTiersByPriority(peers, need)
+	if len(excluded) == 0 {
+		return preferred, backups
+	}
+	return preferred, append(backups, excluded...)
+}
+
+func (pv *ThresholdValidator) peerNonceTiersByPriority(
+	peers []Cosigner,
+	need int,
+) (preferred []Cosigner, backups []Cosigner) {
+	if need < 1 || len(peers) <= need {
+		return peers, nil
+	}
+
+	sort.SliceStable(peers, func(i, j int) bool {
+		return pv.peerPriority(peers[i].GetID()) < pv.peerPriority(peers[j].GetID())
+	})
+
+	cutoff := pv.peerPriority(peers[need-1].GetID())
+	for i, peer := range peers {
+		if pv.peerPriority(peer.GetID()) > cutoff {
+			return peers[:i], peers[i:]
+		}
+	}
+	return peers, nil
+}
+
+// requestPeerNonces fans out the nonce requests for a block to the peer cosigners in priority order.
+// Backups are asked one at a time for each failed request, and all remaining backups are asked
+// if the threshold has not been reached after half of the grpc timeout. The fan-out stops once done is closed.
+func (pv *ThresholdValidator) requestPeerNonces(
+	ctx context.Context,
+	chainID string,
+	hrst HRSTKey,
+	wg *sync.WaitGroup,
+	nonces map[Cosigner][]CosignerNonce,
+	thresholdPeersMutex *sync.Mutex,
+	done <-chan struct{},
+) {
+	preferred, backups := pv.peerNonceTiers()
+
+	failed := make(chan struct{}, len(pv.peerCosigners))
+	request := func(peer Cosigner) {
+		go func() {
+			if !pv.waitForPeerNonces(ctx, chainID, peer, hrst, wg, nonces, thresholdPeersMutex) {
+				failed <- struct{}{}
+			}
+		}()
+	}
+
+	for _, peer := range preferred {
+		request(peer)
+	}
+
+	if len(backups) == 0 {
+		return
+	}
+
+	go func() {
+		timer := time.NewTimer(timeoutWithin(ctx, pv.grpcTimeout) / 2)
+		defer timer.Stop()
+
+		for len(backups) > 0 {
+			select {
+			case <-done:
+				return
+			case <-failed:
+				pv.logger.Debug("Requesting nonces from backup cosigner", "cosigner", backups[0].GetID())
+				request(backups[0])
+				backups = backups[1:]
+			case <-timer.C:
+				pv.logger.Debug("Preferred cosigners are slow, requesting nonces from all backup cosigners")
+				for _, peer := range backups {
+					request(peer)
+				}
+				return
+			}
+		}
+	}()
+}
+
+// waitForPeerNonces requests nonces from a peer cosigner and includes them in the threshold set
+// if it is not yet complete. It returns false if the peer failed to provide nonces.
 func (pv *ThresholdValidator) waitForPeerNonces(
+	ctx context.Context,
 	chainID string,
 	peer Cosigner,
 	hrst HRSTKey,
 	wg *sync.WaitGroup,
 	nonces map[Cosigner][]CosignerNonce,
 	thresholdPeersMutex *sync.Mutex,
-) {
+) bool {
+	ctx, span := tracer.Start(ctx, "GetNonces", hrstAttributes(chainID, hrst),
+		trace.WithAttributes(attribute.Int("cosigner", peer.GetID())))
+
+	peerCtx, cancel := pv.peerContext(ctx)
+	defer cancel()
+
 	peerStartTime := time.Now()
-	peerNonces, err := peer.GetNonces(chainID, hrst)
+	peerNonces, err := peer.GetNonces(peerCtx, chainID, hrst)
+	endSpan(span, err)
+	pv.peerLiveness.record(ctx, peer, err)
 	if err != nil {
+		pv.checkPeerTimeout(ctx, peerCtx, peer)
 		// Significant missing shares may lead to signature failure
 		missedNonces.WithLabelValues(peer.GetAddress()).Add(float64(1))
 		totalMissedNonces.WithLabelValues(peer.GetAddress()).Inc()
-		pv.logger.Error("Error getting nonces", "cosigner", peer.GetID(), "err", err)
-		return
+		pv.logger.Error(
+			"Error getting nonces",
+			"cosigner", peer.GetID(),
+			"chain_id", chainID,
+			"height", hrst.Height,
+			"round", hrst.Round,
+			"step", hrst.Step,
+			"err", err,
+		)
+		return false
 	}
 	// Significant missing shares may lead to signature failure
 	missedNonces.WithLabelValues(peer.GetAddress()).Set(0)
@@ -378,8 +684,12 @@ func (pv *ThresholdValidator) waitForPeerNonces(
 		defer wg.Done()
 	}
 	thresholdPeersMutex.Unlock()
+
+	return true
 }
+
 func (pv *ThresholdValidator) waitForPeerSetNoncesAndSign(
+	ctx context.Context,
 	chainID string,
 	peer Cosigner,
 	hrst HRSTKey,
@@ -413,23 +723,61 @@ func (pv *ThresholdValidator) waitForPeerSetNoncesAndSign(
 		}
 	}
 
-	sigRes, err := peer.SetNoncesAndSign(CosignerSetNoncesAndSignRequest{
+	ctx, span := tracer.Start(ctx, "SetNoncesAndSign", hrstAttributes(chainID, hrst),
+		trace.WithAttributes(attribute.Int("cosigner", peerID)))
+
+	peerCtx, cancel := pv.peerContext(ctx)
+	defer cancel()
+
+	sigRes, err := peer.SetNoncesAndSign(peerCtx, CosignerSetNoncesAndSignRequest{
 		ChainID:   chainID,
 		Nonces:    peerNonces,
 		HRST:      hrst,
 		SignBytes: signBytes,
 	})
+	endSpan(span, err)
+
+	pv.peerLiveness.record(ctx, peer, err)
 
 	if err != nil {
+		pv.checkPeerTimeout(ctx, peerCtx, peer)
 		pv.logger.Error(
 			"Cosigner failed to set nonces and sign",
 			"id", peerID,
+			"chain_id", chainID,
+			"height", hrst.Height,
+			"round", hrst.Round,
+			"step", hrst.Step,
 			"err", err.Error(),
 		)
 		return
 	}
 
-	timedCosignerSignLag.WithLabelValues(peer.GetAddress()).Observe(time.Since(peerStartTime).Seconds())
+	// a cosigner that does not report the HRST predates it, so its response can not be checked.
+	if sigRes.HRST != (HRSTKey{}) && sigRes.HRST != hrst {
+		totalStaleSignResponses.Inc()
+		pv.logger.Error(
+			"Discarding signature part for a different HRST",
+			"cosigner", peerID,
+			"chain_id", chainID,
+			"height", hrst.Height,
+			"round", hrst.Round,
+			"step", hrst.Step,
+			"timestamp", hrst.Timestamp,
+			"response_height", sigRes.HRST.Height,
+			"response_round", sigRes.HRST.Round,
+			"response_step", sigRes.HRST.Step,
+			"response_timestamp", sigRes.HRST.Timestamp,
+		)
+		return
+	}
+
+	peerSignLag := time.Since(peerStartTime)
+	timedCosignerSignLag.WithLabelValues(peer.GetAddress()).Observe(peerSignLag.Seconds())
+	if pv.rebalancer != nil {
+		pv.rebalancer.observePeer(peer.GetID(), peerSignLag)
+	}
+	pv.checkPeerClock(peer, sigRes.Timestamp)
 	pv.logger.Debug(
 		"Received signature part",
 		"cosigner", peerID,
@@ -447,6 +795,162 @@ func (pv *ThresholdValidator) waitForPeerSetNoncesAndSign(
 	copy((*shareSignatures)[peerIdx], sigRes.Signature)
 }
 
+// peerContext bounds a request to a single peer by the peerTimeout, if configured.
+// The request is canceled when the peer is abandoned, so that the peer stops working on it.
+func (pv *ThresholdValidator) peerContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if pv.config == nil || pv.config.Config.ThresholdModeConfig == nil ||
+		pv.config.Config.ThresholdModeConfig.PeerTimeout == "" {
+		return context.WithCancel(ctx)
+	}
+	// Validated prior in ValidateThresholdModeConfig
+	timeout, _ := time.ParseDuration(pv.config.Config.ThresholdModeConfig.PeerTimeout)
+	return context.WithTimeout(ctx, timeout)
+}
+
+// checkPeerTimeout counts a failed request to a peer if it ran out of the peerTimeout
+// rather than the time for the whole sign.
+func (pv *ThresholdValidator) checkPeerTimeout(ctx, peerCtx context.Context, peer Cosigner) {
+	if ctx.Err() == nil && errors.Is(peerCtx.Err(), context.DeadlineExceeded) {
+		totalPeerTimeouts.WithLabelValues(peer.GetAddress()).Inc()
+		pv.logger.Debug("Abandoned request to slow cosigner after the peer timeout", "cosigner", peer.GetID())
+	}
+}
+
+// peerClockTolerance returns how far a peer's clock may be ahead of ours before it is reported.
+func (pv *ThresholdValidator) peerClockTolerance() time.Duration {
+	if pv.config == nil || pv.config.Config.ThresholdModeConfig == nil {
+		return defaultPeerClockTolerance
+	}
+	// Validated prior in ValidateThresholdModeConfig
+	tolerance, err := time.ParseDuration(pv.config.Config.ThresholdModeConfig.PeerClockTolerance)
+	if err != nil {
+		return defaultPeerClockTolerance
+	}
+	return tolerance
+}
+
+// blockTimestampWindow returns how far a block timestamp may be from our clock, or 0 if it is not checked.
+func (pv *ThresholdValidator) blockTimestampWindow() time.Duration {
+	if pv.config == nil || pv.config.Config.ThresholdModeConfig == nil {
+		return 0
+	}
+	// Validated prior in ValidateThresholdModeConfig
+	window, err := time.ParseDuration(pv.config.Config.ThresholdModeConfig.BlockTimestampWindow)
+	if err != nil {
+		return 0
+	}
+	return window
+}
+
+// blockDeadline returns when the signature of a vote or proposal with the timestamp is no longer useful
+// to the chain node, or the zero time if the signDeadline is not configured.
+func (pv *ThresholdValidator) blockDeadline(timestamp time.Time) time.Time {
+	if pv.config == nil || pv.config.Config.ThresholdModeConfig == nil || timestamp.IsZero() {
+		return time.Time{}
+	}
+	// Validated prior in ValidateThresholdModeConfig
+	deadline, err := time.ParseDuration(pv.config.Config.ThresholdModeConfig.SignDeadline)
+	if err != nil {
+		return time.Time{}
+	}
+	return timestamp.Add(deadline)
+}
+
+// verifyCombinedSignature returns errInvalidCombinedSignature if the signature combined from the partial
+// signatures is not valid for the sign bytes, logging the shards that contributed to it. The verification
+// is skipped if skipSignatureVerification is set.
+func (pv *ThresholdValidator) verifyCombinedSignature(
+	chainID string,
+	hrst HRSTKey,
+	signBytes, signature []byte,
+	shareSigs []PartialSignature,
+) error {
+	if pv.config != nil && pv.config.Config.ThresholdModeConfig != nil &&
+		pv.config.Config.ThresholdModeConfig.SkipSignatureVerification {
+		return nil
+	}
+	if pv.myCosigner.VerifySignature(chainID, signBytes, signature) {
+		return nil
+	}
+
+	shardIDs := partialSignatureIDs(shareSigs)
+
+	totalInvalidSignature.Inc()
+	pv.logger.Error(
+		"Combined signature is not valid, one of the shards may have signed with a wrong key or nonce",
+		"chain_id", chainID,
+		"height", hrst.Height,
+		"round", hrst.Round,
+		"step", hrst.Step,
+		"shards", shardIDs,
+	)
+	return fmt.Errorf("%w, combined from shards %v", errInvalidCombinedSignature, shardIDs)
+}
+
+// partialSignatureIDs returns the shard IDs of the cosigners that produced the partial signatures.
+func partialSignatureIDs(shareSigs []PartialSignature) []int {
+	shardIDs := make([]int, len(shareSigs))
+	for i, sig := range shareSigs {
+		shardIDs[i] = sig.ID
+	}
+	return shardIDs
+}
+
+// checkBlockTimestamp refuses to sign a block with a timestamp further than the block timestamp window from now,
+// e.g. one fed by a compromised or misbehaving chain node.
+func (pv *ThresholdValidator) checkBlockTimestamp(block *Block, now time.Time) error {
+	window := pv.blockTimestampWindow()
+	if window == 0 {
+		return nil
+	}
+	drift := block.Timestamp.Sub(now)
+	if drift > window || drift < -window {
+		return fmt.Errorf("%w: %s is %s from the cosigner clock, more than %s",
+			errBlockTimestampOutOfWindow, block.Timestamp.UTC().Format(time.RFC3339Nano), drift, window)
+	}
+	return nil
+}
+
+// checkPeerClock compares the timestamp reported by a peer in its sign response against our clock.
+// A peer that is consistently ahead is excluded from nonce requests for a while, since the
+// timestamps it contributes would be rejected.
+func (pv *ThresholdValidator) checkPeerClock(peer Cosigner, peerTimestamp time.Time) {
+	// peers running an older version do not report a timestamp
+	if peerTimestamp.UnixNano() <= 0 {
+		return
+	}
+
+	now := time.Now()
+	ahead := peerTimestamp.Sub(now)
+	isAhead := ahead > pv.peerClockTolerance()
+
+	if isAhead {
+		pv.logger.Error(
+			fmt.Sprintf("Peer %d clock appears ahead by ~%dms", peer.GetID(), ahead.Milliseconds()),
+			"cosigner", peer.GetID(),
+			"tolerance", pv.peerClockTolerance(),
+		)
+	}
+
+	if pv.peerClocks.observe(peer.GetID(), isAhead, now) {
+		pv.logger.Error(
+			"Excluding peer from nonce requests while threshold can be met without it, check the peer's clock sync",
+			"cosigner", peer.GetID(),
+			"duration", peerClockAheadExcludeDuration,
+		)
+	}
+}
+
+// timeoutWithin returns timeout, or the time remaining until the deadline of ctx if that is sooner.
+func timeoutWithin(ctx context.Context, timeout time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			return remaining
+		}
+	}
+	return timeout
+}
+
 func waitUntilCompleteOrTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
 	c := make(chan struct{})
 	go func() {
@@ -461,6 +965,142 @@ func waitUntilCompleteOrTimeout(wg *sync.WaitGroup, timeout time.Duration) bool
 	}
 }
 
+// initSignStateIfMissing applies the configured MissingSignStateAction when a sign request
+// is received for a chain that does not have a sign state file yet.
+func (pv *ThresholdValidator) initSignStateIfMissing(chainID string) error {
+	if _, ok := pv.chainState.Load(chainID); ok {
+		return nil
+	}
+
+	pv.signStateInitMutex.Lock()
+	defer pv.signStateInitMutex.Unlock()
+
+	stateFile := pv.config.PrivValStateFile(chainID)
+	if _, err := os.Stat(stateFile); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("unexpected error checking sign state file existence (%s): %w", stateFile, err)
+	}
+
+	var action MissingSignStateAction
+	if pv.config.Config.ThresholdModeConfig != nil {
+		action = pv.config.Config.ThresholdModeConfig.MissingSignState
+	}
+
+	var floor SignStateConsensus
+	var err error
+	switch action {
+	case MissingSignStateFloor:
+		floor, err = pv.chainNodeSignStateFloor(chainID)
+	case MissingSignStatePeers:
+		floor, err = pv.peerSignStateFloor(chainID)
+	default:
+		pv.logger.Error(
+			"Refusing to sign for chain without a sign state file. "+
+				"Create it with `horcrux state set` or `horcrux state import`, "+
+				"or set thresholdMode.missingSignState to floor or peers",
+			"chain_id", chainID,
+			"state_file", stateFile,
+		)
+		return fmt.Errorf("no sign state found for chain %s at %s", chainID, stateFile)
+	}
+	if err != nil {
+		pv.logger.Error(
+			"Refusing to sign for chain without a sign state file, failed to determine the floor height",
+			"chain_id", chainID,
+			"missing_sign_state", action,
+			"error", err,
+		)
+		return fmt.Errorf("failed to initialize sign state for chain %s: %w", chainID, err)
+	}
+
+	for _, file := range []string{stateFile, pv.config.CosignerStateFile(chainID)} {
+		signState, err := LoadOrCreateSignState(file)
+		if err != nil {
+			return err
+		}
+		if err := signState.Save(floor, nil); err != nil && signState.HRSKey().LessThan(floor.HRSKey()) {
+			return fmt.Errorf("failed to save sign state floor to %s: %w", file, err)
+		}
+	}
+
+	pv.logger.Info(
+		"Initialized missing sign state at the floor",
+		"chain_id", chainID,
+		"missing_sign_state", action,
+		"floor_height", floor.Height,
+		"floor_round", floor.Round,
+		"floor_step", floor.Step,
+		"state_file", stateFile,
+	)
+
+	return nil
+}
+
+// chainNodeSignStateFloor returns the sign state just above the height currently reported by the chain nodes.
+func (pv *ThresholdValidator) chainNodeSignStateFloor(chainID string) (SignStateConsensus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pv.grpcTimeout)
+	defer cancel()
+
+	height, err := ChainNodeHeight(ctx, pv.config.Config.ChainNodes, chainID)
+	if err != nil {
+		return SignStateConsensus{}, err
+	}
+
+	// The chain node has already committed the reported height, so nothing at or below it may be signed.
+	return NewSignStateConsensus(height+1, 0, 0), nil
+}
+
+// signStateReporter is implemented by peer cosigners that can report the HRS of their last signed share.
+type signStateReporter interface {
+	GetSignState(ctx context.Context, chainID string) (HRSKey, error)
+}
+
+// peerSignStateFloor returns the highest sign state reported by the peer cosigners, e.g. to recover
+// a cosigner whose state directory was lost. Any block signed by the cluster was signed by threshold cosigners,
+// which may include this cosigner, so at least total-threshold+1 peers must report their sign state
+// for one of them to have signed it.
+func (pv *ThresholdValidator) peerSignStateFloor(chainID string) (SignStateConsensus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pv.grpcTimeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		highest HRSKey
+		reports int
+	)
+	for _, peer := range pv.peerCosigners {
+		reporter, ok := peer.(signStateReporter)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(peer Cosigner) {
+			defer wg.Done()
+			hrs, err := reporter.GetSignState(ctx, chainID)
+			if err != nil {
+				pv.logger.Error("Failed to get sign state of cosigner", "cosigner", peer.GetID(), "err", err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			reports++
+			if highest.LessThan(hrs) {
+				highest = hrs
+			}
+		}(peer)
+	}
+	wg.Wait()
+
+	if need := len(pv.peerCosigners) + 2 - pv.threshold; reports < need {
+		return SignStateConsensus{}, fmt.Errorf("only %d peer cosigners reported their sign state, need at least %d",
+			reports, need)
+	}
+
+	return NewSignStateConsensus(highest.Height, highest.Round, highest.Step), nil
+}
+
 func (pv *ThresholdValidator) LoadSignStateIfNecessary(chainID string) error {
 	if _, ok := pv.chainState.Load(chainID); ok {
 		return nil
@@ -474,12 +1114,32 @@ func (pv *ThresholdValidator) LoadSignStateIfNecessary(chainID string) error {
 	lastSignStateInitiated := signState.FreshCache()
 	lastSignStateInitiated.filePath = os.DevNull
 
+	wal, err := loadSignWAL(pv.config.SignWALFile(chainID))
+	if err != nil {
+		return err
+	}
+	if entry := wal.pending(signState.HRSKey()); entry != nil {
+		// the signature may have been returned before a crash, only the same block can be signed at this HRS.
+		pv.logger.Info(
+			"Sign write-ahead entry is ahead of the sign state, refusing conflicting blocks at its HRS",
+			"chain_id", chainID,
+			"height", entry.Height,
+			"round", entry.Round,
+			"step", entry.Step,
+			"sign_state_height", signState.Height,
+			"sign_state_round", signState.Round,
+			"sign_state_step", signState.Step,
+		)
+	}
+
 	pv.chainState.Store(chainID, ChainSignState{
 		lastSignState:          signState,
 		lastSignStateInitiated: lastSignStateInitiated,
 
 		lastSignStateMutex:          &sync.Mutex{},
 		lastSignStateInitiatedMutex: &sync.Mutex{},
+
+		wal: wal,
 	})
 
 	return pv.myCosigner.LoadSignStateIfNecessary(chainID)
@@ -559,9 +1219,91 @@ func (pv *ThresholdValidator) compareBlockSignatureAgainstHRS(
 	return newStillWaitingForBlockError(chainID, blockHRS)
 }
 
-func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, time.Time, error) {
+// SignBlock signs the block, either by managing the threshold signing process with the peer cosigners
+// when this cosigner is the raft leader, or by proxying the request to the raft leader.
+// Each sign decision is recorded in the audit log if configured, and notified if it prevents
+// a double sign or repeats a sign failure.
+func (pv *ThresholdValidator) SignBlock(
+	ctx context.Context,
+	chainID string,
+	block *Block,
+) (sig []byte, stamp time.Time, err error) {
+	audit := newAuditRecord(chainID, block)
+	defer func() {
+		pv.audit.record(pv.logger, audit, err)
+		pv.notifications.signResult(chainID, block, err)
+	}()
+
+	if !pv.signs.begin() {
+		return nil, block.Timestamp, errShuttingDown
+	}
+	defer pv.signs.done()
+
+	if pv.pause.isPaused() {
+		return nil, block.Timestamp, fmt.Errorf("%w, run horcrux resume to sign again", errSigningPaused)
+	}
+
+	if err := pv.checkBlockTimestamp(block, time.Now()); err != nil {
+		totalRejectedBlockTimestamps.WithLabelValues(chainID).Inc()
+		return nil, block.Timestamp, err
+	}
+
+	if !block.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, block.Deadline)
+		defer cancel()
+	}
+
+	release, err := pv.signLimiter.acquire(ctx, chainID)
+	if err != nil {
+		return nil, block.Timestamp, err
+	}
+	defer release()
+
+	ctx, span := tracer.Start(ctx, "SignBlock", hrstAttributes(chainID, block.HRSTKey()))
+	sig, stamp, err = pv.signBlock(ctx, chainID, block, &audit)
+	endSpan(span, err)
+	recordSignBlock(chainID, block, err)
+	return sig, stamp, err
+}
+
+// recordSignBlock updates the sign metrics of the chain with the result of a SignBlock.
+func recordSignBlock(chainID string, block *Block, err error) {
+	if err != nil {
+		if isDoubleSignPrevented(err) {
+			totalDoubleSignsPrevented.WithLabelValues(chainID).Inc()
+		}
+		return
+	}
+
+	lastSignedHeight.WithLabelValues(chainID).Set(float64(block.Height))
+	lastSignedRound.WithLabelValues(chainID).Set(float64(block.Round))
+	lastSignedStep.WithLabelValues(chainID).Set(float64(block.Step))
+	totalSignedBlocks.WithLabelValues(chainID).Inc()
+}
+
+// observeSignBlockDuration records the end-to-end time of a sign request started at start, whether it succeeded
+// or not, so that sign requests timing out are visible in the upper buckets.
+func observeSignBlockDuration(chainID string, isLeader bool, start time.Time) {
+	timedSignBlockDuration.WithLabelValues(chainID, strconv.FormatBool(isLeader)).Observe(time.Since(start).Seconds())
+}
+
+func (pv *ThresholdValidator) signBlock(
+	ctx context.Context,
+	chainID string,
+	block *Block,
+	audit *auditRecord,
+) ([]byte, time.Time, error) {
 	height, round, step, stamp, signBytes := block.Height, block.Round, block.Step, block.Timestamp, block.SignBytes
 
+	isLeader := pv.leader.IsLeader()
+	audit.Leader = isLeader
+	defer observeSignBlockDuration(chainID, isLeader, time.Now())
+
+	if err := pv.initSignStateIfMissing(chainID); err != nil {
+		return nil, stamp, err
+	}
+
 	if err := pv.LoadSignStateIfNecessary(chainID); err != nil {
 		return nil, stamp, err
 	}
@@ -570,7 +1312,7 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 
 	// Only the leader can execute this function. Followers can handle the requests,
 	// but they just need to proxy the request to the raft leader
-	if !pv.leader.IsLeader() {
+	if !isLeader {
 		pv.logger.Debug("I am not the raft leader. Proxying request to the leader",
 			"chain_id", chainID,
 			"height", height,
@@ -578,7 +1320,7 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 			"step", step,
 		)
 		totalNotRaftLeader.Inc()
-		signRes, err := pv.leader.SignBlock(CosignerSignBlockRequest{
+		signRes, err := pv.leader.SignBlock(ctx, CosignerSignBlockRequest{
 			ChainID: chainID,
 			Block:   block,
 		})
@@ -617,10 +1359,24 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 		return nil, stamp, err
 	}
 	if existingSignature != nil {
-		pv.logger.Debug("Returning existing signature", "signature", fmt.Sprintf("%x", existingSignature))
+		pv.logger.Debug(
+			"Returning existing signature",
+			"chain_id", chainID,
+			"height", height,
+			"round", round,
+			"step", step,
+			"signature", fmt.Sprintf("%x", existingSignature),
+		)
 		return existingSignature, existingTimestamp, nil
 	}
 
+	// persist the block before requesting the signature parts, so that a conflicting block is never signed
+	// at the same HRS, even if this cosigner crashes before the sign state is written.
+	if err := pv.mustLoadChainState(chainID).wal.begin(block); err != nil {
+		pv.notifyBlockSignError(chainID, block.HRSKey())
+		return nil, stamp, err
+	}
+
 	numPeers := len(pv.peerCosigners)
 	total := uint8(numPeers + 1)
 	getEphemeralWaitGroup := sync.WaitGroup{}
@@ -632,13 +1388,13 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 	nonces := make(map[Cosigner][]CosignerNonce)
 	thresholdPeersMutex := sync.Mutex{}
 
-	for _, c := range pv.peerCosigners {
-		go pv.waitForPeerNonces(chainID, c, hrst, &getEphemeralWaitGroup,
-			nonces, &thresholdPeersMutex)
-	}
+	timeStartGetNonces := time.Now()
+	noncesDone := make(chan struct{})
+	pv.requestPeerNonces(ctx, chainID, hrst, &getEphemeralWaitGroup, nonces, &thresholdPeersMutex, noncesDone)
 
-	myNonces, err := pv.myCosigner.GetNonces(chainID, hrst)
+	myNonces, err := pv.myCosigner.GetNonces(ctx, chainID, hrst)
 	if err != nil {
+		close(noncesDone)
 		pv.notifyBlockSignError(chainID, block.HRSKey())
 		// Our ephemeral secret parts are required, cannot proceed
 		return nil, stamp, err
@@ -646,7 +1402,10 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 
 	// Wait for threshold cosigners to be complete
 	// A Cosigner will either respond in time, or be cancelled with timeout
-	if waitUntilCompleteOrTimeout(&getEphemeralWaitGroup, pv.grpcTimeout) {
+	noncesTimedOut := waitUntilCompleteOrTimeout(&getEphemeralWaitGroup, timeoutWithin(ctx, pv.grpcTimeout))
+	close(noncesDone)
+	timedSignBlockGetNoncesDuration.WithLabelValues(chainID).Observe(time.Since(timeStartGetNonces).Seconds())
+	if noncesTimedOut {
 		pv.notifyBlockSignError(chainID, block.HRSKey())
 		return nil, stamp, errors.New("timed out waiting for ephemeral shares")
 	}
@@ -675,15 +1434,20 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 	// share sigs is updated by goroutines
 	shareSignaturesMutex := sync.Mutex{}
 
+	timeStartSetNoncesAndSign := time.Now()
 	for cosigner := range nonces {
 		// set peerNonces and sign in single rpc call.
-		go pv.waitForPeerSetNoncesAndSign(chainID, cosigner, hrst, nonces,
+		go pv.waitForPeerSetNoncesAndSign(ctx, chainID, cosigner, hrst, nonces,
 			signBytes, &shareSignatures, &shareSignaturesMutex, &setEphemeralAndSignWaitGroup)
 	}
 
 	// Wait for threshold cosigners to be complete
 	// A Cosigner will either respond in time, or be cancelled with timeout
-	if waitUntilCompleteOrTimeout(&setEphemeralAndSignWaitGroup, 4*time.Second) {
+	signTimedOut := waitUntilCompleteOrTimeout(&setEphemeralAndSignWaitGroup, timeoutWithin(ctx, 4*time.Second))
+	timedSignBlockSetNoncesAndSignDuration.WithLabelValues(chainID).Observe(
+		time.Since(timeStartSetNoncesAndSign).Seconds(),
+	)
+	if signTimedOut {
 		pv.notifyBlockSignError(chainID, block.HRSKey())
 		return nil, stamp, errors.New("timed out waiting for peers to sign")
 	}
@@ -712,6 +1476,8 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 		})
 	}
 
+	audit.Shards = partialSignatureIDs(shareSigs)
+
 	if len(shareSigs) < pv.threshold {
 		totalInsufficientCosigners.Inc()
 		pv.notifyBlockSignError(chainID, block.HRSKey())
@@ -719,7 +1485,9 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 	}
 
 	// assemble into final signature
+	_, combineSpan := tracer.Start(ctx, "CombineSignatures")
 	signature, err := pv.myCosigner.CombineSignatures(chainID, shareSigs)
+	endSpan(combineSpan, err)
 	if err != nil {
 		pv.notifyBlockSignError(chainID, block.HRSKey())
 		return nil, stamp, err
@@ -733,11 +1501,10 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 		"step", hrst.Step,
 	)
 
-	// verify the combined signature before saving to watermark
-	if !pv.myCosigner.VerifySignature(chainID, signBytes, signature) {
-		totalInvalidSignature.Inc()
+	// verify the combined signature before saving to watermark, so that an invalid signature is never emitted.
+	if err := pv.verifyCombinedSignature(chainID, hrst, signBytes, signature, shareSigs); err != nil {
 		pv.notifyBlockSignError(chainID, block.HRSKey())
-		return nil, stamp, errors.New("combined signature is not valid")
+		return nil, stamp, err
 	}
 
 	newLss := ChainSignStateConsensus{
@@ -754,9 +1521,11 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 	css := pv.mustLoadChainState(chainID)
 
 	// Err will be present if newLss is not above high watermark
+	_, saveSpan := tracer.Start(ctx, "SaveSignState")
 	css.lastSignStateMutex.Lock()
 	err = css.lastSignState.Save(newLss.SignStateConsensus, &pv.pendingDiskWG)
 	css.lastSignStateMutex.Unlock()
+	endSpan(saveSpan, err)
 	if err != nil {
 		if _, isSameHRSError := err.(*SameHRSError); !isSameHRSError {
 			pv.notifyBlockSignError(chainID, block.HRSKey())
@@ -767,11 +1536,51 @@ func (pv *ThresholdValidator) SignBlock(chainID string, block *Block) ([]byte, t
 	// Emit last signed state to cluster
 	err = pv.leader.ShareSigned(newLss)
 	if err != nil {
-		pv.logger.Error("Error emitting LSS", err.Error())
+		pv.logger.Error(
+			"Error emitting LSS",
+			"chain_id", chainID,
+			"height", height,
+			"round", round,
+			"step", step,
+			"err", err,
+		)
 	}
 
-	timeSignBlock := time.Since(timeStartSignBlock).Seconds()
-	timedSignBlockLag.Observe(timeSignBlock)
+	timeSignBlock := time.Since(timeStartSignBlock)
+	timedSignBlockLag.Observe(timeSignBlock.Seconds())
+
+	if pv.rebalancer != nil {
+		if avg, ok := pv.rebalancer.observeSign(timeSignBlock, time.Now()); ok {
+			go pv.rebalanceLeader(avg)
+		}
+	}
 
 	return signature, stamp, nil
 }
+
+// rebalanceLeader transfers leadership to the peer that responds the fastest,
+// after the average time taken to sign blocks as the leader exceeded the threshold.
+func (pv *ThresholdValidator) rebalanceLeader(avgSignLatency time.Duration) {
+	transferer, ok := pv.leader.(leadershipTransferer)
+	if !ok {
+		return
+	}
+
+	id, peerLatency, ok := pv.rebalancer.fastestPeer()
+	if !ok {
+		return
+	}
+
+	pv.logger.Info(
+		"Sign latency as leader exceeded threshold, transferring leadership",
+		"avg_sign_latency", avgSignLatency,
+		"threshold", pv.rebalancer.threshold,
+		"new_leader", id,
+		"new_leader_avg_sign_latency", peerLatency,
+	)
+	totalLeaderRebalances.Inc()
+
+	if err := transferer.TransferLeadershipTo(id); err != nil {
+		pv.logger.Error("Failed to transfer leadership", "new_leader", id, "error", err)
+	}
+}
@@ -2,11 +2,17 @@ package signer
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
 	mrand "math/rand"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"os"
@@ -17,10 +23,16 @@ import (
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cometlog "github.com/cometbft/cometbft/libs/log"
 	cometrand "github.com/cometbft/cometbft/libs/rand"
+	"github.com/cometbft/cometbft/p2p"
 	cometproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cometrpcjsontypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	comet "github.com/cometbft/cometbft/types"
 	"github.com/ethereum/go-ethereum/crypto/ecies"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	tsed25519 "gitlab.com/unit410/threshold-ed25519/pkg"
 	"golang.org/x/sync/errgroup"
@@ -94,6 +106,9 @@ func testThresholdValidator(t *testing.T, threshold, total uint8) {
 	err := validator.LoadSignStateIfNecessary(testChainID)
 	require.NoError(t, err)
 
+	err = validator.LoadSignStateIfNecessary(testChainID2)
+	require.NoError(t, err)
+
 	proposal := cometproto.Proposal{
 		Height: 1,
 		Round:  20,
@@ -500,3 +515,772 @@ func testThresholdValidatorLeaderElection(t *testing.T, threshold, total uint8)
 func TestThresholdValidatorLeaderElection2of3(t *testing.T) {
 	testThresholdValidatorLeaderElection(t, 2, 3)
 }
+
+// priorityTestCosigner wraps a cosigner to count nonce requests and optionally fail them.
+type priorityTestCosigner struct {
+	Cosigner
+
+	fail          bool
+	nonceRequests atomic.Int32
+}
+
+func (c *priorityTestCosigner) GetNonces(
+	ctx context.Context,
+	chainID string,
+	hrst HRSTKey,
+) (*CosignerNoncesResponse, error) {
+	c.nonceRequests.Add(1)
+	if c.fail {
+		return nil, fmt.Errorf("cosigner %d unavailable", c.GetID())
+	}
+	return c.Cosigner.GetNonces(ctx, chainID, hrst)
+}
+
+func TestThresholdValidatorNoncePriority(t *testing.T) {
+	cosigners, pubKey := getTestLocalCosigners(t, 2, 3)
+
+	// cosigner 2 is only a backup, cosigner 3 is preferred.
+	cosignersConfig := cosigners[0].config.Config.ThresholdModeConfig.Cosigners
+	cosignersConfig[1].Priority = 1
+
+	backup := &priorityTestCosigner{Cosigner: cosigners[1]}
+	preferred := &priorityTestCosigner{Cosigner: cosigners[2]}
+
+	leader := &MockLeader{id: 1}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{backup, preferred},
+		leader,
+	)
+	defer validator.Stop()
+	for _, c := range cosigners[1:] {
+		defer c.waitForSignStatesToFlushToDisk()
+	}
+
+	leader.leader = validator
+
+	err := validator.LoadSignStateIfNecessary(testChainID)
+	require.NoError(t, err)
+
+	proposal := cometproto.Proposal{
+		Height: 1,
+		Round:  0,
+		Type:   cometproto.ProposalType,
+	}
+
+	err = validator.SignProposal(testChainID, &proposal)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID, &proposal), proposal.Signature))
+
+	require.Equal(t, int32(1), preferred.nonceRequests.Load())
+	require.Zero(t, backup.nonceRequests.Load())
+
+	// the backup should be used when the preferred cosigner is unavailable.
+	preferred.fail = true
+
+	proposal = cometproto.Proposal{
+		Height: 2,
+		Round:  0,
+		Type:   cometproto.ProposalType,
+	}
+
+	err = validator.SignProposal(testChainID, &proposal)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID, &proposal), proposal.Signature))
+
+	require.Equal(t, int32(2), preferred.nonceRequests.Load())
+	require.Equal(t, int32(1), backup.nonceRequests.Load())
+}
+
+// clockAheadTestCosigner wraps a cosigner to report sign response timestamps ahead of the leader's clock.
+type clockAheadTestCosigner struct {
+	priorityTestCosigner
+
+	ahead time.Duration
+}
+
+func (c *clockAheadTestCosigner) SetNoncesAndSign(
+	ctx context.Context,
+	req CosignerSetNoncesAndSignRequest,
+) (*CosignerSignResponse, error) {
+	res, err := c.Cosigner.SetNoncesAndSign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res.Timestamp = res.Timestamp.Add(c.ahead)
+	return res, nil
+}
+
+func TestThresholdValidatorPeerClockAhead(t *testing.T) {
+	cosigners, pubKey := getTestLocalCosigners(t, 2, 3)
+
+	// cosigner 3 is preferred, but its clock is ahead.
+	cosignersConfig := cosigners[0].config.Config.ThresholdModeConfig.Cosigners
+	cosignersConfig[1].Priority = 1
+
+	backup := &priorityTestCosigner{Cosigner: cosigners[1]}
+	ahead := &clockAheadTestCosigner{
+		priorityTestCosigner: priorityTestCosigner{Cosigner: cosigners[2]},
+		ahead:                5 * time.Second,
+	}
+
+	leader := &MockLeader{id: 1}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{backup, ahead},
+		leader,
+	)
+	defer validator.Stop()
+	for _, c := range cosigners[1:] {
+		defer c.waitForSignStatesToFlushToDisk()
+	}
+
+	leader.leader = validator
+
+	err := validator.LoadSignStateIfNecessary(testChainID)
+	require.NoError(t, err)
+
+	for i := 1; i <= peerClockAheadExcludeAfter+1; i++ {
+		proposal := cometproto.Proposal{
+			Height: int64(i),
+			Round:  0,
+			Type:   cometproto.ProposalType,
+		}
+
+		err = validator.SignProposal(testChainID, &proposal)
+		require.NoError(t, err)
+		require.True(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID, &proposal), proposal.Signature))
+	}
+
+	// the peer is excluded after its clock was ahead for consecutive blocks, since the threshold is met without it.
+	require.Equal(t, int32(peerClockAheadExcludeAfter), ahead.nonceRequests.Load())
+	require.Equal(t, int32(1), backup.nonceRequests.Load())
+	require.True(t, validator.peerClocks.excluded(ahead.GetID(), time.Now()))
+
+	// the excluded peer is still used when the threshold cannot be met without it.
+	backup.fail = true
+
+	proposal := cometproto.Proposal{
+		Height: peerClockAheadExcludeAfter + 2,
+		Round:  0,
+		Type:   cometproto.ProposalType,
+	}
+
+	err = validator.SignProposal(testChainID, &proposal)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID, &proposal), proposal.Signature))
+	require.Equal(t, int32(peerClockAheadExcludeAfter+1), ahead.nonceRequests.Load())
+}
+
+func TestThresholdValidatorStaleSignResponse(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 2)
+
+	leader := &MockLeader{id: 1}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{cosigners[1]},
+		leader,
+	)
+	defer validator.Stop()
+	defer cosigners[1].waitForSignStatesToFlushToDisk()
+
+	leader.leader = validator
+
+	err := validator.LoadSignStateIfNecessary(testChainID)
+	require.NoError(t, err)
+
+	proposal := cometproto.Proposal{
+		Height:    1,
+		Round:     0,
+		Type:      cometproto.ProposalType,
+		Timestamp: time.Now(),
+	}
+	signBytes := comet.ProposalSignBytes(testChainID, &proposal)
+	require.NoError(t, validator.SignProposal(testChainID, &proposal))
+
+	setNoncesAndSign := func(hrst HRSTKey) []byte {
+		shareSignatures := make([][]byte, 2)
+		var (
+			mu sync.Mutex
+			wg sync.WaitGroup
+		)
+		wg.Add(1)
+		validator.waitForPeerSetNoncesAndSign(context.Background(), testChainID, cosigners[1], hrst,
+			map[Cosigner][]CosignerNonce{}, signBytes, &shareSignatures, &mu, &wg)
+		return shareSignatures[1]
+	}
+
+	hrst := HRSTKey{Height: 1, Round: 0, Step: stepPropose, Timestamp: proposal.Timestamp.UnixNano()}
+	require.NotEmpty(t, setNoncesAndSign(hrst))
+
+	// the peer returns its signature for round 0, which is discarded for a request for round 1.
+	stale := testutil.ToFloat64(totalStaleSignResponses.vec.prom.WithLabelValues())
+	hrst.Round = 1
+	require.Empty(t, setNoncesAndSign(hrst))
+	require.Equal(t, stale+1, testutil.ToFloat64(totalStaleSignResponses.vec.prom.WithLabelValues()))
+}
+
+// tamperedTestCosigner wraps a cosigner to respond with a tampered partial signature.
+type tamperedTestCosigner struct {
+	Cosigner
+}
+
+func (c *tamperedTestCosigner) SetNoncesAndSign(
+	ctx context.Context,
+	req CosignerSetNoncesAndSignRequest,
+) (*CosignerSignResponse, error) {
+	res, err := c.Cosigner.SetNoncesAndSign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// the first half is the ephemeral public key, which is checked when combining, tamper the second half.
+	res.Signature[len(res.Signature)/2] ^= 1
+	return res, nil
+}
+
+func TestThresholdValidatorTamperedPartialSignature(t *testing.T) {
+	cosigners, pubKey := getTestLocalCosigners(t, 2, 2)
+
+	leader := &MockLeader{id: 1}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{&tamperedTestCosigner{Cosigner: cosigners[1]}},
+		leader,
+	)
+	defer validator.Stop()
+	defer cosigners[1].waitForSignStatesToFlushToDisk()
+
+	leader.leader = validator
+
+	err := validator.LoadSignStateIfNecessary(testChainID)
+	require.NoError(t, err)
+
+	proposal := cometproto.Proposal{
+		Height: 1,
+		Round:  0,
+		Type:   cometproto.ProposalType,
+	}
+
+	err = validator.SignProposal(testChainID, &proposal)
+	require.ErrorIs(t, err, errInvalidCombinedSignature)
+	require.ErrorContains(t, err, "combined from shards [1 2]")
+	require.Empty(t, proposal.Signature)
+
+	// with the verification skipped, the invalid signature is returned.
+	cosigners[0].config.Config.ThresholdModeConfig.SkipSignatureVerification = true
+
+	proposal = cometproto.Proposal{
+		Height: 2,
+		Round:  0,
+		Type:   cometproto.ProposalType,
+	}
+
+	err = validator.SignProposal(testChainID, &proposal)
+	require.NoError(t, err)
+	require.NotEmpty(t, proposal.Signature)
+	require.False(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID, &proposal), proposal.Signature))
+}
+
+// slowTestCosigner wraps a cosigner to delay its nonce responses.
+type slowTestCosigner struct {
+	Cosigner
+
+	delay time.Duration
+}
+
+func (c *slowTestCosigner) GetNonces(
+	ctx context.Context,
+	chainID string,
+	hrst HRSTKey,
+) (*CosignerNoncesResponse, error) {
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return c.Cosigner.GetNonces(ctx, chainID, hrst)
+}
+
+func TestThresholdValidatorPeerTimeout(t *testing.T) {
+	cosigners, pubKey := getTestLocalCosigners(t, 2, 3)
+
+	// cosigner 2 is preferred but slow, cosigner 3 is only a backup.
+	thresholdCfg := cosigners[0].config.Config.ThresholdModeConfig
+	thresholdCfg.Cosigners[2].Priority = 1
+	thresholdCfg.PeerTimeout = "100ms"
+
+	slow := &slowTestCosigner{Cosigner: cosigners[1], delay: 10 * time.Second}
+
+	leader := &MockLeader{id: 1}
+
+	// without the peer timeout, the backup would only be asked after half of the grpc timeout.
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		4*time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{slow, cosigners[2]},
+		leader,
+	)
+	defer validator.Stop()
+	for _, c := range cosigners[1:] {
+		defer c.waitForSignStatesToFlushToDisk()
+	}
+
+	leader.leader = validator
+
+	err := validator.LoadSignStateIfNecessary(testChainID)
+	require.NoError(t, err)
+
+	proposal := cometproto.Proposal{
+		Height: 1,
+		Round:  0,
+		Type:   cometproto.ProposalType,
+	}
+
+	start := time.Now()
+	err = validator.SignProposal(testChainID, &proposal)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID, &proposal), proposal.Signature))
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestThresholdValidatorBlockTimestampWindow(t *testing.T) {
+	cosigners, pubKey := getTestLocalCosigners(t, 2, 3)
+	cosigners[0].config.Config.ThresholdModeConfig.BlockTimestampWindow = "10s"
+
+	leader := &MockLeader{id: 1}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{cosigners[1]},
+		leader,
+	)
+	defer validator.Stop()
+	defer cosigners[1].waitForSignStatesToFlushToDisk()
+
+	leader.leader = validator
+
+	err := validator.LoadSignStateIfNecessary(testChainID)
+	require.NoError(t, err)
+
+	for _, timestamp := range []time.Time{time.Now().Add(-time.Hour), time.Now().Add(time.Minute), {}} {
+		proposal := cometproto.Proposal{
+			Height:    1,
+			Round:     0,
+			Type:      cometproto.ProposalType,
+			Timestamp: timestamp,
+		}
+		err = validator.SignProposal(testChainID, &proposal)
+		require.ErrorIs(t, err, errBlockTimestampOutOfWindow)
+		require.Nil(t, proposal.Signature)
+	}
+
+	proposal := cometproto.Proposal{
+		Height:    1,
+		Round:     0,
+		Type:      cometproto.ProposalType,
+		Timestamp: time.Now().Add(-5 * time.Second),
+	}
+	err = validator.SignProposal(testChainID, &proposal)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID, &proposal), proposal.Signature))
+}
+
+func TestThresholdValidatorSignDeadline(t *testing.T) {
+	cosigners, pubKey := getTestLocalCosigners(t, 2, 3)
+
+	leader := &MockLeader{id: 1}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		2*time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{
+			&slowTestCosigner{Cosigner: cosigners[1], delay: 500 * time.Millisecond},
+			&slowTestCosigner{Cosigner: cosigners[2], delay: 500 * time.Millisecond},
+		},
+		leader,
+	)
+	defer validator.Stop()
+	for _, c := range cosigners[1:] {
+		defer c.waitForSignStatesToFlushToDisk()
+	}
+
+	leader.leader = validator
+
+	err := validator.LoadSignStateIfNecessary(testChainID)
+	require.NoError(t, err)
+
+	// the deadline hint is tighter than the grpc timeout, so signing gives up before the nonces arrive.
+	start := time.Now()
+	_, _, err = validator.SignBlock(context.Background(), testChainID, &Block{
+		Height:   1,
+		Step:     stepPropose,
+		Deadline: time.Now().Add(100 * time.Millisecond),
+	})
+	require.EqualError(t, err, "timed out waiting for ephemeral shares")
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// the deadline of a chain node proposal is derived from its timestamp.
+	validator.config.Config.ThresholdModeConfig.SignDeadline = "100ms"
+	start = time.Now()
+	err = validator.SignProposal(testChainID, &cometproto.Proposal{
+		Height:    2,
+		Type:      cometproto.ProposalType,
+		Timestamp: start,
+	})
+	require.EqualError(t, err, "timed out waiting for ephemeral shares")
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+	validator.config.Config.ThresholdModeConfig.SignDeadline = ""
+
+	// without a deadline hint, the grpc timeout leaves enough time for the nonces.
+	proposal := cometproto.Proposal{
+		Height: 3,
+		Round:  0,
+		Type:   cometproto.ProposalType,
+	}
+
+	err = validator.SignProposal(testChainID, &proposal)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID, &proposal), proposal.Signature))
+}
+
+// newTestChainNodeRPC starts a chain node RPC server that reports the given chain ID and latest height.
+func newTestChainNodeRPC(t *testing.T, chainID string, height int64) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req cometrpcjsontypes.RPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		res := cometrpcjsontypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultStatus{
+			NodeInfo: p2p.DefaultNodeInfo{Network: chainID},
+			SyncInfo: ctypes.SyncInfo{LatestBlockHeight: height},
+		})
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestThresholdValidatorMissingSignState(t *testing.T) {
+	cosigners, pubKey := getTestLocalCosigners(t, 2, 3)
+
+	leader := &MockLeader{id: 1}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{cosigners[1], cosigners[2]},
+		leader,
+	)
+	defer validator.Stop()
+	for _, c := range cosigners[1:] {
+		defer c.waitForSignStatesToFlushToDisk()
+	}
+
+	leader.leader = validator
+
+	// load the peer sign states up front, so that a peer left out of the threshold
+	// does not create its sign state after the test directory has been removed.
+	for _, c := range cosigners[1:] {
+		require.NoError(t, c.LoadSignStateIfNecessary(testChainID2))
+	}
+
+	proposal := cometproto.Proposal{
+		Height: 1,
+		Round:  0,
+		Type:   cometproto.ProposalType,
+	}
+
+	// fail closed by default
+	err := validator.SignProposal(testChainID2, &proposal)
+	require.ErrorContains(t, err, "no sign state found")
+	require.NoFileExists(t, cosigners[0].config.PrivValStateFile(testChainID2))
+
+	cosigners[0].config.Config.ThresholdModeConfig.MissingSignState = MissingSignStateFloor
+
+	srv := newTestChainNodeRPC(t, testChainID2, 100)
+	cosigners[0].config.Config.ChainNodes = ChainNodes{{PrivValAddr: "tcp://127.0.0.1:1234", RPCAddr: srv.URL}}
+
+	// the floor is above the height already committed by the chain node.
+	proposal.Height = 100
+	err = validator.SignProposal(testChainID2, &proposal)
+	require.Error(t, err)
+
+	signState, err := LoadSignState(cosigners[0].config.PrivValStateFile(testChainID2))
+	require.NoError(t, err)
+	require.Equal(t, HRSKey{Height: 101}, signState.HRSKey())
+
+	proposal.Height = 101
+	err = validator.SignProposal(testChainID2, &proposal)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID2, &proposal), proposal.Signature))
+}
+
+// signStateTestCosigner wraps a local cosigner to report its sign state like a remote cosigner.
+type signStateTestCosigner struct {
+	*LocalCosigner
+
+	fail bool
+}
+
+func (c *signStateTestCosigner) GetSignState(_ context.Context, chainID string) (HRSKey, error) {
+	if c.fail {
+		return HRSKey{}, fmt.Errorf("cosigner %d unavailable", c.GetID())
+	}
+	return c.LastSignState(chainID)
+}
+
+func TestThresholdValidatorMissingSignStatePeers(t *testing.T) {
+	cosigners, pubKey := getTestLocalCosigners(t, 2, 3)
+	cosigners[0].config.Config.ThresholdModeConfig.MissingSignState = MissingSignStatePeers
+
+	peers := []*signStateTestCosigner{{LocalCosigner: cosigners[1]}, {LocalCosigner: cosigners[2]}}
+
+	leader := &MockLeader{id: 1}
+
+	validator := NewThresholdValidator(
+		cometlog.NewNopLogger(),
+		cosigners[0].config,
+		2,
+		time.Second,
+		1,
+		cosigners[0],
+		[]Cosigner{peers[0], peers[1]},
+		leader,
+	)
+	defer validator.Stop()
+	for _, c := range cosigners[1:] {
+		defer c.waitForSignStatesToFlushToDisk()
+	}
+
+	leader.leader = validator
+
+	// the peers have signed up to different heights, while the state directory of cosigner 1 was lost.
+	for i, height := range []int64{50, 40} {
+		c := cosigners[i+1]
+		require.NoError(t, c.LoadSignStateIfNecessary(testChainID2))
+		require.NoError(t, c.SaveLastSignedState(testChainID2, NewSignStateConsensus(height, 0, stepPrecommit)))
+	}
+
+	proposal := cometproto.Proposal{
+		Height: 50,
+		Round:  0,
+		Type:   cometproto.ProposalType,
+	}
+
+	// refuse to sign while no peer can report its sign state.
+	peers[0].fail, peers[1].fail = true, true
+	err := validator.SignProposal(testChainID2, &proposal)
+	require.ErrorContains(t, err, "only 0 peer cosigners reported their sign state, need at least 2")
+	require.NoFileExists(t, cosigners[0].config.PrivValStateFile(testChainID2))
+
+	// the silent peer may have signed the last block together with this cosigner.
+	peers[1].fail = false
+	err = validator.SignProposal(testChainID2, &proposal)
+	require.ErrorContains(t, err, "only 1 peer cosigners reported their sign state, need at least 2")
+	require.NoFileExists(t, cosigners[0].config.PrivValStateFile(testChainID2))
+
+	// the state is fast-forwarded to the highest sign state reported by the peers.
+	peers[0].fail = false
+	err = validator.SignProposal(testChainID2, &proposal)
+	require.Error(t, err)
+
+	signState, err := LoadSignState(cosigners[0].config.PrivValStateFile(testChainID2))
+	require.NoError(t, err)
+	require.Equal(t, HRSKey{Height: 50, Step: stepPrecommit}, signState.HRSKey())
+
+	proposal.Height = 51
+	err = validator.SignProposal(testChainID2, &proposal)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(comet.ProposalSignBytes(testChainID2, &proposal), proposal.Signature))
+}
+
+func TestBlockVerifySignBytes(t *testing.T) {
+	blockID := cometproto.BlockID{
+		Hash: tmhash.Sum([]byte("block")),
+		PartSetHeader: cometproto.PartSetHeader{
+			Total: 3,
+			Hash:  tmhash.Sum([]byte("parts")),
+		},
+	}
+	now := time.Now()
+
+	proposal := &cometproto.Proposal{
+		Type:      cometproto.ProposalType,
+		Height:    10,
+		Round:     1,
+		PolRound:  0,
+		BlockID:   blockID,
+		Timestamp: now,
+	}
+	prevote := &cometproto.Vote{
+		Type:      cometproto.PrevoteType,
+		Height:    10,
+		Round:     1,
+		BlockID:   blockID,
+		Timestamp: now,
+	}
+	precommit := &cometproto.Vote{
+		Type:      cometproto.PrecommitType,
+		Height:    10,
+		Round:     1,
+		BlockID:   blockID,
+		Timestamp: now,
+	}
+
+	blocks := []Block{
+		{
+			Height:    proposal.Height,
+			Round:     int64(proposal.Round),
+			Step:      ProposalToStep(proposal),
+			Timestamp: proposal.Timestamp,
+			SignBytes: comet.ProposalSignBytes(testChainID, proposal),
+			BlockID:   &proposal.BlockID,
+			POLRound:  int64(proposal.PolRound),
+		},
+	}
+	for _, vote := range []*cometproto.Vote{prevote, precommit} {
+		blocks = append(blocks, Block{
+			Height:    vote.Height,
+			Round:     int64(vote.Round),
+			Step:      VoteToStep(vote),
+			Timestamp: vote.Timestamp,
+			SignBytes: comet.VoteSignBytes(testChainID, vote),
+			BlockID:   &vote.BlockID,
+		})
+	}
+
+	for _, block := range blocks {
+		// the block as received by the leader from another cosigner.
+		proxied := BlockFromProto(block.toProto())
+		require.NoError(t, proxied.VerifySignBytes(testChainID))
+
+		require.Error(t, proxied.VerifySignBytes(testChainID2))
+
+		tampered := *proxied
+		tampered.BlockID = &cometproto.BlockID{
+			Hash: blockID.Hash,
+			PartSetHeader: cometproto.PartSetHeader{
+				Total: blockID.PartSetHeader.Total + 1,
+				Hash:  blockID.PartSetHeader.Hash,
+			},
+		}
+		require.Error(t, tampered.VerifySignBytes(testChainID))
+
+		// blocks from cosigners that do not send the block ID can not be verified.
+		legacy := *proxied
+		legacy.BlockID = nil
+		require.Error(t, legacy.VerifySignBytes(testChainID))
+	}
+}
+
+func TestThresholdValidatorSignBlockDurationMetrics(t *testing.T) {
+	cosigners, _ := getTestLocalCosigners(t, 2, 3)
+	for _, c := range cosigners {
+		require.NoError(t, c.LoadSignStateIfNecessary(testChainID))
+		defer c.waitForSignStatesToFlushToDisk()
+	}
+
+	leader := &MockLeader{id: 1}
+	logger := cometlog.NewNopLogger()
+
+	validator := NewThresholdValidator(logger, cosigners[0].config, 2, time.Second, 1,
+		cosigners[0], []Cosigner{cosigners[1], cosigners[2]}, leader)
+	defer validator.Stop()
+	leader.SetLeader(validator)
+
+	// the follower proxies its sign requests to the validator.
+	followerLeader := &MockLeader{id: 2, leader: validator}
+	follower := NewThresholdValidator(logger, cosigners[1].config, 2, time.Second, 1,
+		cosigners[1], []Cosigner{cosigners[0], cosigners[2]}, followerLeader)
+	defer follower.Stop()
+
+	require.NoError(t, validator.LoadSignStateIfNecessary(testChainID))
+	require.NoError(t, follower.LoadSignStateIfNecessary(testChainID))
+
+	// the metrics are shared with the other tests signing for the same chain.
+	leaderSigns := histogramSampleCount(t, timedSignBlockDuration, testChainID, "true")
+	followerSigns := histogramSampleCount(t, timedSignBlockDuration, testChainID, "false")
+	getNonces := histogramSampleCount(t, timedSignBlockGetNoncesDuration, testChainID)
+	setNoncesAndSign := histogramSampleCount(t, timedSignBlockSetNoncesAndSignDuration, testChainID)
+
+	require.NoError(t, validator.SignProposal(testChainID, &cometproto.Proposal{
+		Height: 1, Round: 0, Type: cometproto.ProposalType,
+	}))
+	require.NoError(t, follower.SignProposal(testChainID, &cometproto.Proposal{
+		Height: 2, Round: 0, Type: cometproto.ProposalType,
+	}))
+
+	// the request proxied by the follower is also signed by the leader.
+	require.Equal(t, leaderSigns+2, histogramSampleCount(t, timedSignBlockDuration, testChainID, "true"))
+	require.Equal(t, followerSigns+1, histogramSampleCount(t, timedSignBlockDuration, testChainID, "false"))
+	require.Equal(t, getNonces+2, histogramSampleCount(t, timedSignBlockGetNoncesDuration, testChainID))
+	require.Equal(t, setNoncesAndSign+2,
+		histogramSampleCount(t, timedSignBlockSetNoncesAndSignDuration, testChainID))
+}
+
+func histogramSampleCount(t *testing.T, h *histogramVec, labelValues ...string) uint64 {
+	var m dto.Metric
+	require.NoError(t, h.prom.WithLabelValues(labelValues...).(prometheus.Histogram).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestRecordSignBlock(t *testing.T) {
+	const chainID = "record-sign-block-1"
+
+	block := &Block{Height: 10, Round: 1, Step: stepPrecommit}
+	recordSignBlock(chainID, block, nil)
+	require.Equal(t, 10.0, testutil.ToFloat64(lastSignedHeight.prom.WithLabelValues(chainID)))
+	require.Equal(t, 1.0, testutil.ToFloat64(lastSignedRound.prom.WithLabelValues(chainID)))
+	require.Equal(t, float64(stepPrecommit), testutil.ToFloat64(lastSignedStep.prom.WithLabelValues(chainID)))
+	require.Equal(t, 1.0, testutil.ToFloat64(totalSignedBlocks.prom.WithLabelValues(chainID)))
+
+	// a refused regression is counted, and does not update the last signed block.
+	recordSignBlock(chainID, &Block{Height: 9}, fmt.Errorf("failed: %w", newRegressionError("height regression")))
+	require.Equal(t, 1.0, testutil.ToFloat64(totalDoubleSignsPrevented.prom.WithLabelValues(chainID)))
+	require.Equal(t, 10.0, testutil.ToFloat64(lastSignedHeight.prom.WithLabelValues(chainID)))
+
+	// other errors are not, including requests for a block that is already signed, e.g. from another sentry.
+	recordSignBlock(chainID, &Block{Height: 11}, errors.New("timed out waiting for peers to sign"))
+	recordSignBlock(chainID, &Block{Height: 9}, &BeyondBlockError{msg: "progress already started on block 10.1.3"})
+	recordSignBlock(chainID, &Block{Height: 10}, newSameHRSError(HRSKey{Height: 10, Round: 1, Step: stepPrecommit}))
+	require.Equal(t, 1.0, testutil.ToFloat64(totalDoubleSignsPrevented.prom.WithLabelValues(chainID)))
+}
@@ -0,0 +1,123 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+
+	"github.com/strangelove-ventures/horcrux/signer/proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+const tracerName = "github.com/strangelove-ventures/horcrux/signer"
+
+// tracer resolves against the global tracer provider, so spans are no-ops unless the TracingService is started.
+var tracer = otel.Tracer(tracerName)
+
+var _ service.Service = &TracingService{}
+
+// TracingService exports the OpenTelemetry spans of the sign pipeline to an OTLP collector
+// for the lifetime of the signer process.
+type TracingService struct {
+	service.BaseService
+
+	cfg      TracingConfig
+	shutdown func(context.Context) error
+}
+
+func NewTracingService(logger log.Logger, cfg TracingConfig) *TracingService {
+	ts := &TracingService{cfg: cfg}
+	ts.BaseService = *service.NewBaseService(logger, "TracingService", ts)
+	return ts
+}
+
+// OnStart configures the global tracer provider to export spans to the configured OTLP endpoint.
+func (ts *TracingService) OnStart() error {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(ts.cfg.OTLPEndpoint)}
+	if ts.cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	// the exporter connects lazily, so an unavailable collector does not prevent startup.
+	exporter, err := otlptracegrpc.New(context.Background(), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("horcrux"),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	ts.shutdown = tp.Shutdown
+
+	ts.Logger.Info("Exporting traces", "otlp_endpoint", ts.cfg.OTLPEndpoint)
+
+	return nil
+}
+
+// OnStop flushes any pending spans and shuts down the exporter.
+func (ts *TracingService) OnStop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ts.shutdown(ctx); err != nil {
+		ts.Logger.Error("Failed to flush traces", "error", err)
+	}
+}
+
+// cosignerGRPCInterceptorFilter limits trace propagation to the cosigner service so that
+// the high frequency raft transport RPCs do not produce spans.
+var cosignerGRPCInterceptorFilter = otelgrpc.WithInterceptorFilter(
+	filters.ServiceName(proto.CosignerGRPC_ServiceDesc.ServiceName),
+)
+
+func tracingDialOption() grpc.DialOption {
+	return grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor(cosignerGRPCInterceptorFilter))
+}
+
+func tracingServerOption() grpc.ServerOption {
+	return grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor(cosignerGRPCInterceptorFilter))
+}
+
+// hrstAttributes returns the span attributes identifying the block being signed.
+func hrstAttributes(chainID string, hrst HRSTKey) trace.SpanStartOption {
+	return trace.WithAttributes(
+		attribute.String("chain_id", chainID),
+		attribute.Int64("height", hrst.Height),
+		attribute.Int64("round", hrst.Round),
+		attribute.Int("step", int(hrst.Step)),
+	)
+}
+
+// endSpan records err, if any, on the span before ending it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,253 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ShardEncryptionSchemeVaultTransit encrypts shards with a key of the transit secrets engine of HashiCorp Vault.
+const ShardEncryptionSchemeVaultTransit = "vault-transit"
+
+const (
+	defaultVaultTransitMount = "transit"
+	vaultRequestTimeout      = 10 * time.Second
+
+	// envVaultToken is the environment variable of the Vault token, as used by the Vault CLI.
+	envVaultToken = "VAULT_TOKEN"
+)
+
+// VaultTransitConfig encrypts the key shards with a key held by the transit secrets engine of HashiCorp Vault,
+// so that the private shards never touch the disk in plaintext.
+type VaultTransitConfig struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200.
+	Address string `yaml:"address"`
+	// Mount is the path of the transit secrets engine. Defaults to transit.
+	Mount string `yaml:"mount,omitempty"`
+	// KeyName is the name of the transit key that encrypts new shards.
+	KeyName string `yaml:"keyName"`
+	// TokenFile contains the Vault token. Defaults to the VAULT_TOKEN environment variable, unless RoleID is set.
+	TokenFile string `yaml:"tokenFile,omitempty"`
+	// RoleID and the secret ID in SecretIDFile log in with the AppRole auth method instead of using a token.
+	RoleID       string `yaml:"roleID,omitempty"`
+	SecretIDFile string `yaml:"secretIDFile,omitempty"`
+	// CAFile verifies the certificate of the Vault server instead of the system roots.
+	CAFile string `yaml:"caFile,omitempty"`
+}
+
+func (c *VaultTransitConfig) Validate() error {
+	u, err := url.Parse(c.Address)
+	if err != nil {
+		return fmt.Errorf("invalid vaultTransit address (%s): %w", c.Address, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid vaultTransit address (%s): scheme must be http or https", c.Address)
+	}
+	if c.KeyName == "" {
+		return fmt.Errorf("vaultTransit keyName cannot be empty")
+	}
+	if (c.RoleID == "") != (c.SecretIDFile == "") {
+		return fmt.Errorf("vaultTransit roleID and secretIDFile must be set together")
+	}
+	if c.RoleID != "" && c.TokenFile != "" {
+		return fmt.Errorf("vaultTransit tokenFile and roleID are mutually exclusive")
+	}
+	return nil
+}
+
+type vaultTransitShardEncryption struct {
+	client  *http.Client
+	address string
+	mount   string
+	keyName string
+	token   string
+}
+
+type vaultTransitShardParams struct {
+	// KeyName is the transit key that encrypted the shard.
+	KeyName string `json:"keyName"`
+}
+
+// VaultTransitShardEncryption returns the encryption of shards with the Vault transit key of the config.
+// It logs in to Vault if an AppRole is configured, so it fails if Vault cannot be reached.
+func (c RuntimeConfig) VaultTransitShardEncryption() (ShardEncryption, error) {
+	vc := c.Config.VaultTransit
+	if vc == nil {
+		return nil, errors.New("vaultTransit is not configured")
+	}
+	if err := vc.Validate(); err != nil {
+		return nil, err
+	}
+
+	e := &vaultTransitShardEncryption{
+		client:  &http.Client{Timeout: vaultRequestTimeout},
+		address: strings.TrimSuffix(vc.Address, "/"),
+		mount:   strings.Trim(vc.Mount, "/"),
+		keyName: vc.KeyName,
+	}
+	if e.mount == "" {
+		e.mount = defaultVaultTransitMount
+	}
+
+	if vc.CAFile != "" {
+		ca, err := os.ReadFile(c.vaultTransitPath(vc.CAFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vaultTransit caFile: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in vaultTransit caFile %s", vc.CAFile)
+		}
+		e.client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}}
+	}
+
+	token, err := e.login(c, vc)
+	if err != nil {
+		return nil, err
+	}
+	e.token = token
+	return e, nil
+}
+
+func (c RuntimeConfig) vaultTransitPath(file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(c.HomeDir, file)
+}
+
+// login returns the Vault token, logging in with the AppRole if configured.
+func (e *vaultTransitShardEncryption) login(c RuntimeConfig, vc *VaultTransitConfig) (string, error) {
+	switch {
+	case vc.RoleID != "":
+		secretID, err := os.ReadFile(c.vaultTransitPath(vc.SecretIDFile))
+		if err != nil {
+			return "", fmt.Errorf("failed to read vaultTransit secretIDFile: %w", err)
+		}
+		var res struct {
+			Auth struct {
+				ClientToken string `json:"client_token"`
+			} `json:"auth"`
+		}
+		if err := e.request("auth/approle/login", map[string]string{
+			"role_id":   vc.RoleID,
+			"secret_id": strings.TrimSpace(string(secretID)),
+		}, &res); err != nil {
+			return "", fmt.Errorf("failed to log in to vault: %w", err)
+		}
+		if res.Auth.ClientToken == "" {
+			return "", errors.New("failed to log in to vault: no client token in response")
+		}
+		return res.Auth.ClientToken, nil
+	case vc.TokenFile != "":
+		token, err := os.ReadFile(c.vaultTransitPath(vc.TokenFile))
+		if err != nil {
+			return "", fmt.Errorf("failed to read vaultTransit tokenFile: %w", err)
+		}
+		return strings.TrimSpace(string(token)), nil
+	default:
+		token := os.Getenv(envVaultToken)
+		if token == "" {
+			return "", fmt.Errorf("no vault token, set vaultTransit tokenFile or roleID, or %s", envVaultToken)
+		}
+		return token, nil
+	}
+}
+
+// request sends a request to the Vault API and decodes the response into res.
+func (e *vaultTransitShardEncryption) request(path string, body any, res any) error {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, e.address+"/v1/"+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.token != "" {
+		req.Header.Set("X-Vault-Token", e.token)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(respBody, &vaultErr)
+		return fmt.Errorf("vault returned %s for %s: %s", resp.Status, path, strings.Join(vaultErr.Errors, "; "))
+	}
+	return json.Unmarshal(respBody, res)
+}
+
+func (e *vaultTransitShardEncryption) Scheme() string {
+	return ShardEncryptionSchemeVaultTransit
+}
+
+func (e *vaultTransitShardEncryption) Encrypt(plaintext []byte) (*EncryptedShard, error) {
+	var res struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	if err := e.request(e.mount+"/encrypt/"+url.PathEscape(e.keyName), map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(plaintext),
+	}, &res); err != nil {
+		return nil, err
+	}
+	if res.Data.Ciphertext == "" {
+		return nil, errors.New("no ciphertext in vault transit response")
+	}
+
+	params, err := json.Marshal(vaultTransitShardParams{KeyName: e.keyName})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedShard{
+		Scheme:     ShardEncryptionSchemeVaultTransit,
+		Params:     params,
+		Ciphertext: []byte(res.Data.Ciphertext),
+	}, nil
+}
+
+func (e *vaultTransitShardEncryption) Decrypt(shard *EncryptedShard) ([]byte, error) {
+	params := vaultTransitShardParams{KeyName: e.keyName}
+	if len(shard.Params) > 0 {
+		if err := json.Unmarshal(shard.Params, &params); err != nil {
+			return nil, fmt.Errorf("invalid shard encryption params: %w", err)
+		}
+	}
+
+	var res struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	if err := e.request(e.mount+"/decrypt/"+url.PathEscape(params.KeyName), map[string]string{
+		"ciphertext": string(shard.Ciphertext),
+	}, &res); err != nil {
+		return nil, fmt.Errorf("failed to decrypt key shard with vault transit key %s: %w", params.KeyName, err)
+	}
+	return base64.StdEncoding.DecodeString(res.Data.Plaintext)
+}
@@ -0,0 +1,180 @@
+package signer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestVaultTransit returns a fake Vault server with a transit key named horcrux, accepting the token
+// "test-token" and the AppRole "test-role" with the secret ID "test-secret".
+func newTestVaultTransit(t *testing.T) *httptest.Server {
+	vaultError := func(w http.ResponseWriter, code int, err string) {
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {err}})
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			vaultError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if r.URL.Path == "/v1/auth/approle/login" {
+			if req["role_id"] != "test-role" || req["secret_id"] != "test-secret" {
+				vaultError(w, http.StatusBadRequest, "invalid role or secret ID")
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"auth": map[string]string{"client_token": "test-token"}})
+			return
+		}
+
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			vaultError(w, http.StatusForbidden, "permission denied")
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/horcrux":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]string{"ciphertext": "vault:v1:" + req["plaintext"]},
+			})
+		case "/v1/transit/decrypt/horcrux":
+			if !strings.HasPrefix(req["ciphertext"], "vault:v1:") {
+				vaultError(w, http.StatusBadRequest, "invalid ciphertext")
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]string{"plaintext": strings.TrimPrefix(req["ciphertext"], "vault:v1:")},
+			})
+		default:
+			vaultError(w, http.StatusNotFound, "no handler for route")
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestVaultTransitShardEncryption(t *testing.T) {
+	srv := newTestVaultTransit(t)
+	t.Setenv(envVaultToken, "test-token")
+
+	config := RuntimeConfig{
+		HomeDir: t.TempDir(),
+		Config: Config{
+			VaultTransit: &VaultTransitConfig{Address: srv.URL, KeyName: "horcrux"},
+		},
+	}
+
+	enc, err := config.VaultTransitShardEncryption()
+	require.NoError(t, err)
+
+	key := CreateCosignerEd25519ShardsFromSeed([]byte("horcrux-test-seed"), 2, 3)[0]
+	privateShard := key.PrivateShard
+	require.NoError(t, key.Encrypt(enc))
+	require.Equal(t, ShardEncryptionSchemeVaultTransit, key.EncryptedShard.Scheme)
+
+	file := config.KeyFilePathCosigner(testChainID)
+	require.NoError(t, WriteCosignerEd25519ShardFile(key, file))
+
+	loaded, err := LoadCosignerEd25519Key(file)
+	require.NoError(t, err)
+	require.True(t, loaded.Encrypted())
+	require.NoError(t, loaded.Decrypt(enc))
+	require.Equal(t, privateShard, loaded.PrivateShard)
+
+	// a passphrase can not decrypt a shard encrypted with Vault.
+	passphrase, err := NewPassphraseShardEncryption([]byte("passphrase"))
+	require.NoError(t, err)
+	loaded, err = LoadCosignerEd25519Key(file)
+	require.NoError(t, err)
+	require.ErrorIs(t, loaded.Decrypt(passphrase), errShardEncrypted)
+
+	// Vault rejecting the token.
+	t.Setenv(envVaultToken, "wrong-token")
+	enc, err = config.VaultTransitShardEncryption()
+	require.NoError(t, err)
+	require.ErrorContains(t, loaded.Decrypt(enc), "permission denied")
+
+	// Vault unreachable.
+	srv.Close()
+	t.Setenv(envVaultToken, "test-token")
+	enc, err = config.VaultTransitShardEncryption()
+	require.NoError(t, err)
+	require.Error(t, loaded.Decrypt(enc))
+	require.True(t, loaded.Encrypted())
+}
+
+func TestVaultTransitShardEncryptionAppRole(t *testing.T) {
+	srv := newTestVaultTransit(t)
+	t.Setenv(envVaultToken, "")
+
+	home := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(home, "secret-id"), []byte("test-secret\n"), 0600))
+
+	config := RuntimeConfig{
+		HomeDir: home,
+		Config: Config{
+			VaultTransit: &VaultTransitConfig{
+				Address:      srv.URL,
+				KeyName:      "horcrux",
+				RoleID:       "test-role",
+				SecretIDFile: "secret-id",
+			},
+		},
+	}
+
+	enc, err := config.VaultTransitShardEncryption()
+	require.NoError(t, err)
+	shard, err := enc.Encrypt([]byte("shard"))
+	require.NoError(t, err)
+	plaintext, err := enc.Decrypt(shard)
+	require.NoError(t, err)
+	require.Equal(t, []byte("shard"), plaintext)
+
+	config.Config.VaultTransit.RoleID = "wrong-role"
+	_, err = config.VaultTransitShardEncryption()
+	require.ErrorContains(t, err, "failed to log in to vault")
+
+	config.Config.VaultTransit = &VaultTransitConfig{Address: srv.URL, KeyName: "horcrux"}
+	_, err = config.VaultTransitShardEncryption()
+	require.ErrorContains(t, err, "no vault token")
+}
+
+func TestVaultTransitConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		config    VaultTransitConfig
+		expectErr string
+	}{
+		{"valid token", VaultTransitConfig{Address: "https://vault:8200", KeyName: "horcrux"}, ""},
+		{"valid approle", VaultTransitConfig{
+			Address: "https://vault:8200", KeyName: "horcrux", RoleID: "role", SecretIDFile: "secret-id",
+		}, ""},
+		{"invalid scheme", VaultTransitConfig{Address: "vault:8200", KeyName: "horcrux"}, "scheme must be http or https"},
+		{"no key name", VaultTransitConfig{Address: "https://vault:8200"}, "keyName cannot be empty"},
+		{"role without secret", VaultTransitConfig{Address: "https://vault:8200", KeyName: "horcrux", RoleID: "role"},
+			"must be set together"},
+		{"token and role", VaultTransitConfig{
+			Address: "https://vault:8200", KeyName: "horcrux", TokenFile: "token", RoleID: "role", SecretIDFile: "secret-id",
+		}, "mutually exclusive"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
@@ -160,19 +160,7 @@ func TestDownedSigners2of3(t *testing.T) {
 
 	// Test taking down each node in the signer cluster for a period of time
 	for _, cosigner := range cosigners {
-		t.Logf("{%s} -> Stopping signer...", cosigner.Name())
-		require.NoError(t, cosigner.StopContainer(ctx))
-
-		t.Logf("{%s} -> Waiting for blocks after stopping cosigner {%s}", ourValidator.Name(), cosigner.Name())
-		require.NoError(t, testutil.WaitForBlocks(ctx, 5, cw.chain))
-
-		requireHealthyValidator(t, ourValidator, pubKey.Address())
-
-		t.Logf("{%s} -> Restarting signer...", cosigner.Name())
-		require.NoError(t, cosigner.StartContainer(ctx))
-
-		t.Logf("{%s} -> Waiting for blocks after restarting cosigner {%s}", ourValidator.Name(), cosigner.Name())
-		require.NoError(t, testutil.WaitForBlocks(ctx, 5, cw.chain))
+		requireSigningWithDownedCosigner(ctx, t, ourValidator, pubKey.Address(), cosigner, 5)
 
 		requireHealthyValidator(t, ourValidator, pubKey.Address())
 	}
@@ -232,6 +220,37 @@ func TestDownedSigners3of5(t *testing.T) {
 	}
 }
 
+// TestRecreatedSigners2of3 tests recreating the container of each node in the 2/3 threshold horcrux cluster,
+// which must resume signing from the sign state persisted in its volume.
+func TestRecreatedSigners2of3(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		totalValidators   = 2
+		totalSigners      = 3
+		threshold         = 2
+		totalSentries     = 3
+		sentriesPerSigner = 3
+	)
+
+	cw, pubKey := startChainSingleNodeAndHorcruxThreshold(
+		ctx, t, totalValidators, totalSigners, threshold, totalSentries, sentriesPerSigner,
+	)
+
+	ourValidator := cw.chain.Validators[0]
+	requireHealthyValidator(t, ourValidator, pubKey.Address())
+
+	for _, cosigner := range ourValidator.Sidecars {
+		t.Logf("{%s} -> Recreating signer...", cosigner.Name())
+		require.NoError(t, recreateCosigner(ctx, cosigner))
+
+		t.Logf("{%s} -> Waiting for blocks after recreating cosigner {%s}", ourValidator.Name(), cosigner.Name())
+		requireValidatorSigning(ctx, t, ourValidator, pubKey.Address(), 5)
+
+		requireHealthyValidator(t, ourValidator, pubKey.Address())
+	}
+}
+
 // TestLeaderElection2of3 tests electing a specific leader in a 2/3 threshold horcrux cluster.
 func TestLeaderElection2of3(t *testing.T) {
 	ctx := context.Background()
@@ -297,6 +316,15 @@ func TestLeaderElection2of3(t *testing.T) {
 
 // TestChainPureHorcrux tests a chain with only horcrux validators.
 func TestChainPureHorcrux(t *testing.T) {
+	testChainPureHorcrux(t, gaiaChain)
+}
+
+// TestChainPureHorcruxComet tests a CometBFT v0.37 chain with only horcrux validators.
+func TestChainPureHorcruxComet(t *testing.T) {
+	testChainPureHorcrux(t, cometChain)
+}
+
+func testChainPureHorcrux(t *testing.T, ct chainType) {
 	ctx := context.Background()
 	client, network := interchaintest.DockerSetup(t)
 	logger := zaptest.NewLogger(t)
@@ -311,6 +339,7 @@ func TestChainPureHorcrux(t *testing.T) {
 
 	pubKeys := make([]crypto.PubKey, totalValidators)
 	cw := &chainWrapper{
+		chainType:       ct,
 		totalValidators: totalValidators,
 		totalSentries:   1 + totalValidators*(sentriesPerValidator-1),
 		modifyGenesis:   modifyGenesisStrictUptime,
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -27,9 +28,6 @@ import (
 )
 
 const (
-	testChain        = "gaia" // ghcr.io/strangelove-ventures/heighliner/gaia
-	testChainVersion = "v10.0.2"
-
 	signerPort       = "2222"
 	signerPortDocker = signerPort + "/tcp"
 
@@ -42,13 +40,48 @@ const (
 	signerImageHomeDir = "/home/horcrux"
 )
 
+// chainType is the interchaintest built-in chain and version that a test chain runs.
+// interchaintest waits for each step of the chain lifecycle, so only the image differs between chains.
+type chainType struct {
+	name    string
+	version string
+}
+
+var (
+	// gaiaChain runs gaiad, ghcr.io/strangelove-ventures/heighliner/gaia.
+	gaiaChain = chainType{name: "gaia", version: "v10.0.2"}
+
+	// cometChain runs the ibc-go simd on CometBFT v0.37, ghcr.io/strangelove-ventures/heighliner/ibc-go-simd.
+	cometChain = chainType{name: "ibc-go-simd", version: "v7.2.0"}
+)
+
 // chainWrapper holds the initial configuration for a chain to start from genesis.
 type chainWrapper struct {
 	chain           *cosmos.CosmosChain
-	totalValidators int // total number of validators on chain at genesis
-	totalSentries   int // number of additional sentry nodes
+	chainType       chainType // defaults to gaiaChain
+	totalValidators int       // total number of validators on chain at genesis
+	totalSentries   int       // number of additional sentry nodes
 	modifyGenesis   func(cc ibc.ChainConfig, b []byte) ([]byte, error)
 	preGenesis      func(*chainWrapper) func(ibc.ChainConfig) error
+
+	// consensus timeouts of all nodes, the interchaintest block time by default.
+	timeoutCommit  time.Duration
+	timeoutPropose time.Duration
+}
+
+// configFileOverrides returns the config.toml overrides for the consensus timeouts, nil if they are not set.
+func (c *chainWrapper) configFileOverrides() map[string]any {
+	consensus := make(testutil.Toml)
+	if c.timeoutCommit != 0 {
+		consensus["timeout_commit"] = c.timeoutCommit.String()
+	}
+	if c.timeoutPropose != 0 {
+		consensus["timeout_propose"] = c.timeoutPropose.String()
+	}
+	if len(consensus) == 0 {
+		return nil
+	}
+	return map[string]any{"config/config.toml": testutil.Toml{"consensus": consensus}}
 }
 
 // startChains starts the given chains locally within docker composed of containers.
@@ -69,14 +102,19 @@ func startChains(
 		if c.preGenesis != nil {
 			preGenesis = c.preGenesis(c)
 		}
+		ct := c.chainType
+		if ct == (chainType{}) {
+			ct = gaiaChain
+		}
 		cs[i] = &interchaintest.ChainSpec{
-			Name:          testChain,
-			Version:       testChainVersion,
+			Name:          ct.name,
+			Version:       ct.version,
 			NumValidators: &c.totalValidators,
 			NumFullNodes:  &c.totalSentries,
 			ChainConfig: ibc.ChainConfig{
-				ModifyGenesis: c.modifyGenesis,
-				PreGenesis:    preGenesis,
+				ModifyGenesis:       c.modifyGenesis,
+				PreGenesis:          preGenesis,
+				ConfigFileOverrides: c.configFileOverrides(),
 			},
 		}
 	}
@@ -94,16 +132,18 @@ func startChains(
 		ic.AddChain(chain)
 	}
 
+	// containers and volumes are removed by the cleanup of interchaintest.DockerSetup, including the ones of
+	// a chain that failed to build, close the interchain even if the build fails part way.
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
 	err = ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
 		TestName:  t.Name(),
 		Client:    client,
 		NetworkID: network,
 	})
 	require.NoError(t, err)
-
-	t.Cleanup(func() {
-		_ = ic.Close()
-	})
 }
 
 // modifyGenesisStrictUptime modifies the genesis file to have a strict uptime slashing window.
@@ -218,6 +258,75 @@ func requireHealthyValidator(t *testing.T, referenceNode *cosmos.ChainNode, vali
 	require.Zero(t, signingInfo.MissedBlocksCounter)
 }
 
+// requireValidatorSigning waits for the chain to advance by the given number of blocks, and asserts that the
+// commit of each block includes a signature from the given validator.
+func requireValidatorSigning(
+	ctx context.Context,
+	t *testing.T,
+	referenceNode *cosmos.ChainNode,
+	validatorAddress cometbytes.HexBytes,
+	blocks int,
+) {
+	start, err := referenceNode.Height(ctx)
+	require.NoError(t, err)
+
+	err = testutil.WaitForBlocks(ctx, blocks+1, referenceNode)
+	require.NoError(t, err)
+
+	for h := int64(start) + 1; h <= int64(start)+int64(blocks); h++ {
+		h := h
+		res, err := referenceNode.Client.Commit(ctx, &h)
+		require.NoError(t, err)
+
+		signed := false
+		for _, sig := range res.Commit.Signatures {
+			if sig.ForBlock() && bytes.Equal(sig.ValidatorAddress, validatorAddress) {
+				signed = true
+				break
+			}
+		}
+		require.Truef(t, signed, "validator %s did not sign block %d", validatorAddress, h)
+	}
+}
+
+// requireSigningWithDownedCosigner stops the given cosigner, asserts that the remaining cosigners keep signing
+// each block for the validator, then restarts the cosigner and asserts that the validator keeps signing.
+func requireSigningWithDownedCosigner(
+	ctx context.Context,
+	t *testing.T,
+	referenceNode *cosmos.ChainNode,
+	validatorAddress cometbytes.HexBytes,
+	cosigner *cosmos.SidecarProcess,
+	blocks int,
+) {
+	t.Logf("{%s} -> Stopping signer...", cosigner.Name())
+	require.NoError(t, cosigner.StopContainer(ctx))
+
+	t.Logf("{%s} -> Waiting for blocks after stopping cosigner {%s}", referenceNode.Name(), cosigner.Name())
+	requireValidatorSigning(ctx, t, referenceNode, validatorAddress, blocks)
+
+	t.Logf("{%s} -> Restarting signer...", cosigner.Name())
+	require.NoError(t, cosigner.StartContainer(ctx))
+
+	t.Logf("{%s} -> Waiting for blocks after restarting cosigner {%s}", referenceNode.Name(), cosigner.Name())
+	requireValidatorSigning(ctx, t, referenceNode, validatorAddress, blocks)
+}
+
+// recreateCosigner removes the container of the given cosigner and creates and starts a new one.
+// The home directory of the cosigner is a docker volume, so the config, key shard and sign state persist.
+func recreateCosigner(ctx context.Context, cosigner *cosmos.SidecarProcess) error {
+	if err := cosigner.StopContainer(ctx); err != nil {
+		return err
+	}
+	if err := cosigner.RemoveContainer(ctx); err != nil {
+		return err
+	}
+	if err := cosigner.CreateContainer(ctx); err != nil {
+		return err
+	}
+	return cosigner.StartContainer(ctx)
+}
+
 // transferLeadership elects a new raft leader.
 func transferLeadership(ctx context.Context, cosigner *cosmos.SidecarProcess) error {
 	_, _, err := cosigner.Exec(ctx, []string{binary, "elect", strconv.FormatInt(int64(cosigner.Index+1), 10)}, nil)
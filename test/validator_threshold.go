@@ -43,6 +43,7 @@ func testChainSingleNodeAndHorcruxThreshold(
 	require.NoError(t, err)
 
 	requireHealthyValidator(t, ourValidator, pubKey.Address())
+	requireValidatorSigning(ctx, t, ourValidator, pubKey.Address(), 5)
 }
 
 // startChainSingleNodeAndHorcruxThreshold starts a single chain with a single horcrux (threshold mode) validator and single node validators for the rest of the validators.
@@ -302,11 +303,24 @@ func writeConfigAndKeysThreshold(
 		if err = cosigner.WriteFile(ctx, ed25519KeyBz, fmt.Sprintf(".horcrux/%s_shard.json", key.chainID)); err != nil {
 			return fmt.Errorf("failed to write %s_shard.json: %w", key.chainID, err)
 		}
+
+		// horcrux refuses to sign for a chain without sign state, so initialize it for the new chain.
+		for _, stateFile := range []string{
+			fmt.Sprintf(".horcrux/state/%s_priv_validator_state.json", key.chainID),
+			fmt.Sprintf(".horcrux/state/%s_share_sign_state.json", key.chainID),
+		} {
+			if err := cosigner.WriteFile(ctx, []byte(initialSignState), stateFile); err != nil {
+				return fmt.Errorf("failed to write %s: %w", stateFile, err)
+			}
+		}
 	}
 
 	return nil
 }
 
+// initialSignState is the sign state for a chain that has not been signed for yet.
+const initialSignState = `{"height":"0","round":"0","step":0}`
+
 // getSentriesForCosignerConnection will return a slice of sentries for each cosigner to connect to.
 // The sentries will be picked for each cosigner in a round robin.
 func getSentriesForCosignerConnection(sentries cosmos.ChainNodes, numSigners int, sentriesPerSigner int) []cosmos.ChainNodes {